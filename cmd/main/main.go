@@ -5,7 +5,10 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
+	_ "time/tzdata" // Embed the timezone database, the runtime image has none
 
 	"github.com/joho/godotenv"
 	"github.com/labstack/echo/v4"
@@ -51,32 +54,104 @@ func main() {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 
-	err = db.AutoMigrate(&types.Deck{}, &types.Card{}, &types.User{}, &types.SessionLog{})
+	err = db.AutoMigrate(&types.Deck{}, &types.Card{}, &types.User{}, &types.SessionLog{}, &types.Attachment{}, &types.AttachmentOwner{}, &types.DailyRollup{}, &types.CardDraft{}, &types.ExplanationTurn{}, &types.LLMUsage{}, &types.LLMCacheEntry{}, &types.PromptTemplate{}, &types.LintJob{}, &types.LintFinding{}, &types.CardRevision{}, &types.CardHint{})
 	if err != nil {
 		slogger.Error("Failed to migrate database", "error", err)
 		log.Fatalf("Failed to migrate database: %v", err)
 	}
 
 	// Initialize LLM Repository
-	apiKey := os.Getenv("GOOGLE_API_KEY")
-	model := os.Getenv("GOOGLE_MODEL")
-	if model == "" {
-		model = "gemini-pro" // default model
+	llmConfig := repositories.LLMConfig{
+		Provider: os.Getenv("LLM_PROVIDER"),
+		Model:    os.Getenv("LLM_MODEL"),
+		BaseURL:  os.Getenv("LLM_BASE_URL"),
+		APIKey:   os.Getenv("LLM_API_KEY"),
 	}
-	llmRepo, err := repositories.NewLLMRepositoryLangChain(apiKey, model)
+	if value := os.Getenv("LLM_TIMEOUT"); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			slogger.Error("Invalid LLM_TIMEOUT, using the default", "value", value, "error", err)
+		}
+		llmConfig.Timeout = timeout
+	}
+	if llmConfig.Provider == "" || llmConfig.Provider == repositories.LLMProviderGoogleAI {
+		// The Google AI variables predate the provider selection
+		if llmConfig.APIKey == "" {
+			llmConfig.APIKey = os.Getenv("GOOGLE_API_KEY")
+		}
+		if llmConfig.Model == "" {
+			llmConfig.Model = os.Getenv("GOOGLE_MODEL")
+		}
+	}
+	llmRepo, err := repositories.NewLLMRepository(llmConfig)
 	if err != nil {
 		slogger.Error("Failed to initialize LLM repository", "error", err)
 		log.Fatalf("Failed to initialize LLM repository: %v", err)
 	}
 
+	// Attachments live next to the database by default
+	attachmentsPath := filepath.Join(filepath.Dir(dbPath), "attachments")
+	if path := os.Getenv("ATTACHMENTS_PATH"); path != "" {
+		attachmentsPath = path
+	}
+	slogger.Info("Attachments path set", "path", attachmentsPath)
+
 	// Initialize Repositories
 	deckRepo := repositories.NewDeckRepositorySQLite(db)
 	cardRepo := repositories.NewCardRepositorySQLite(db)
 	userRepo := repositories.NewUserRepositorySQLite(db)
 	sessionLogRepo := repositories.NewSessionLogRepositorySQLite(db)
+	draftRepo := repositories.NewCardDraftRepositorySQLite(db)
+	threadRepo := repositories.NewExplanationRepositorySQLite(db)
+	usageRepo := repositories.NewLLMUsageRepositorySQLite(db)
+	templateRepo := repositories.NewPromptTemplateRepositorySQLite(db)
+	lintRepo := repositories.NewLintRepositorySQLite(db)
+	attachmentRepo, err := repositories.NewAttachmentRepositorySQLite(db, attachmentsPath)
+	if err != nil {
+		slogger.Error("Failed to initialize attachment repository", "error", err)
+		log.Fatalf("Failed to initialize attachment repository: %v", err)
+	}
+
+	// Build the daily rollups from the existing session logs before any are pruned
+	if n, err := sessionLogRepo.BackfillRollups(); err != nil {
+		slogger.Error("Failed to backfill daily rollups", "error", err)
+		log.Fatalf("Failed to backfill daily rollups: %v", err)
+	} else if n > 0 {
+		slogger.Info("Backfilled daily rollups", "rows", n)
+	}
+
+	// Lint jobs run in the background, so any left running were cut short by a restart
+	if n, err := lintRepo.FailRunningJobs("interrupted by a restart"); err != nil {
+		slogger.Error("Failed to close interrupted lint jobs", "error", err)
+	} else if n > 0 {
+		slogger.Info("Closed interrupted lint jobs", "jobs", n)
+	}
 
 	// Initialize Service
-	service := domain.NewService(slogger, deckRepo, cardRepo, userRepo, sessionLogRepo, llmRepo)
+	service := domain.NewService(slogger, deckRepo, cardRepo, userRepo, sessionLogRepo, llmRepo, attachmentRepo, draftRepo, threadRepo, usageRepo, templateRepo, lintRepo)
+
+	// Remove attachments that are no longer referenced once a day
+	go func() {
+		ticker := time.NewTicker(24 * time.Hour)
+		defer ticker.Stop()
+		for range ticker.C {
+			if _, err := service.GarbageCollectAttachments(); err != nil {
+				slogger.Error("Attachment garbage collection failed", "error", err)
+			}
+		}
+	}()
+
+	// Prune session logs past each user's retention at startup and once a day
+	go func() {
+		ticker := time.NewTicker(24 * time.Hour)
+		defer ticker.Stop()
+		for {
+			if _, err := service.PruneSessionLogs(); err != nil {
+				slogger.Error("Session log pruning failed", "error", err)
+			}
+			<-ticker.C
+		}
+	}()
 
 	// Read JWT secret from environment
 	jwtSecret := os.Getenv("JWT_SECRET")
@@ -93,6 +168,7 @@ func main() {
 	e := echo.New()
 
 	// Middleware
+	e.Use(controller.RedactTokenQuery)
 	e.Use(middleware.Logger())
 	e.Use(middleware.Recover())
 	e.Use(middleware.CORS())
@@ -102,6 +178,7 @@ func main() {
 	deckGroup := api.Group("/decks")
 	sessionGroup := api.Group("/sessions")
 	cardGroup := api.Group("/cards")
+	attachmentGroup := api.Group("/attachments")
 
 	// Authentication Route
 	api.POST("/login", meowController.Login)
@@ -112,12 +189,21 @@ func main() {
 		SigningKey: JWTSecret,
 	})
 
+	// EventSource, <img> and <audio> cannot set headers, so event streams and attachments
+	// also accept the token as a query parameter. RedactTokenQuery keeps it out of the access log.
+	streamJWTMiddleware := middleware.JWTWithConfig(middleware.JWTConfig{
+		SigningKey:  JWTSecret,
+		TokenLookup: "header:" + echo.HeaderAuthorization + ",query:token",
+	})
+
 	adminGroup := api.Group("/admin", jwtMiddleware, controller.AdminMiddleware)
 
 	// Protect deck, card, and session routes
 	protectedDeckGroup := deckGroup.Group("", jwtMiddleware)
 	protectedDeckGroup.GET("", meowController.GetAllDecks)
 	protectedDeckGroup.POST("/default", meowController.CreateDefaultDeck)
+	protectedDeckGroup.GET("/forecast", meowController.GetForecast)
+	protectedDeckGroup.GET("/analytics/:id", meowController.GetDeckAnalytics)
 	protectedDeckGroup.GET("/:id", meowController.GetDeckByID)
 	protectedDeckGroup.POST("", meowController.CreateDeck)
 	protectedDeckGroup.PUT("/:id", meowController.UpdateDeck)
@@ -126,36 +212,81 @@ func main() {
 	protectedDeckGroup.GET("/export/:id", meowController.ExportDeck)
 	protectedDeckGroup.POST("/stats/:id", meowController.ClearDeckStats)
 	protectedDeckGroup.POST("/collapse", meowController.CollapseDecks)
+	protectedDeckGroup.POST("/lint/:id", meowController.StartDeckLint)
+	protectedDeckGroup.GET("/lint/:id", meowController.GetDeckLint)
+	protectedDeckGroup.DELETE("/lint/:id", meowController.CancelDeckLint)
+	protectedDeckGroup.GET("/lint/:id/findings", meowController.GetLintFindings)
+	protectedDeckGroup.POST("/translate/:id", meowController.TranslateDeck)
 
 	protectedCardGroup := cardGroup.Group("", jwtMiddleware)
 	protectedCardGroup.POST("/stats", meowController.UpdateCardStats)
 	protectedCardGroup.POST("/explain/:id", meowController.ExplainCard)
 	protectedCardGroup.GET("/explain/status", meowController.GetLLMStatus)
+	protectedCardGroup.GET("/explain/threads", meowController.GetExplanationThreads)
+	protectedCardGroup.GET("/explain/threads/:card_id", meowController.GetExplanationThread)
+	protectedCardGroup.DELETE("/explain/threads/:card_id", meowController.DeleteExplanationThread)
+	protectedCardGroup.POST("/explain/turns/:id/promote", meowController.PromoteExplanation)
+	protectedCardGroup.GET("/leeches", meowController.GetLeeches)
+	protectedCardGroup.POST("/generate", meowController.GenerateCards)
+	protectedCardGroup.GET("/drafts", meowController.GetCardDrafts)
+	protectedCardGroup.POST("/drafts/:id/approve", meowController.ApproveCardDraft)
+	protectedCardGroup.DELETE("/drafts/:id", meowController.RejectCardDraft)
+	protectedCardGroup.POST("/lint/:id/accept", meowController.AcceptLintFinding)
+	protectedCardGroup.POST("/lint/:id/dismiss", meowController.DismissLintFinding)
+	protectedCardGroup.GET("/revisions/:id", meowController.GetCardRevisions)
+	protectedCardGroup.POST("/hint/:id", meowController.GetCardHint)
+	protectedCardGroup.POST("/translate/:id", meowController.RetranslateCard)
 	protectedCardGroup.GET("/:id", meowController.GetCardByID)
 	protectedCardGroup.POST("/:id", meowController.CreateCard)
 	protectedCardGroup.PUT("/:id", meowController.UpdateCard)
 	protectedCardGroup.DELETE("/:id", meowController.DeleteCard)
 
+	// Stream an explanation as Server-Sent Events:
+	cardGroup.GET("/explain/:id/stream", meowController.StreamExplanation, streamJWTMiddleware)
+
 	protectedSessionGroup := sessionGroup.Group("", jwtMiddleware)
 	protectedSessionGroup.POST("/start", meowController.StartSession)
 	protectedSessionGroup.GET("/next", meowController.GetNextCard)
 	protectedSessionGroup.DELETE("/clear", meowController.ClearSession)
 	protectedSessionGroup.GET("/stats", meowController.GetSessionStats)
+	protectedSessionGroup.POST("/answer", meowController.SubmitAnswer)
+	protectedSessionGroup.POST("/undo", meowController.UndoReviews)
 
 	protectedSessionGroup.GET("/overview/:id", meowController.GetSessionOverview)
+	protectedSessionGroup.GET("/report/:session_id", meowController.GetSessionReport)
 
 	// Get all logs for a given session:
 	protectedSessionGroup.GET("/:session_id", meowController.GetSessionLogs)
 
+	// Follow a session live as Server-Sent Events:
+	sessionGroup.GET("/:session_id/events", meowController.StreamSessionEvents, streamJWTMiddleware)
+
 	// Get distinct session log IDs for a user (optionally by deck):
 	protectedSessionGroup.GET("/ids", meowController.GetSessionLogIds)
 
+	protectedAttachmentGroup := attachmentGroup.Group("", jwtMiddleware)
+	protectedAttachmentGroup.POST("", meowController.UploadAttachment)
+
+	// Serve attachment content, embedded in card markdown as <img> or <audio>:
+	attachmentGroup.GET("/:id", meowController.GetAttachment, streamJWTMiddleware)
+
 	userGroup := api.Group("/user", jwtMiddleware)
 	userGroup.PUT("/password", meowController.ChangePassword)
+	userGroup.PUT("/settings", meowController.UpdateUserSettings)
+	userGroup.GET("/activity", meowController.GetActivityCalendar)
+	userGroup.GET("/llm-usage", meowController.GetLLMUsage)
+	userGroup.GET("/templates", meowController.GetPromptTemplates)
+	userGroup.PUT("/templates/:kind", meowController.SavePromptTemplate)
+	userGroup.DELETE("/templates/:kind", meowController.ResetPromptTemplate)
 
 	adminGroup.GET("/users", meowController.AdminGetAllUsers)
 	adminGroup.POST("/users", meowController.AdminCreateUser)
 	adminGroup.DELETE("/users/:id", meowController.AdminDeleteUser)
+	adminGroup.PUT("/users/:id/llm-quota", meowController.AdminSetLLMQuota)
+	adminGroup.GET("/templates", meowController.AdminGetPromptTemplates)
+	adminGroup.PUT("/templates/:kind", meowController.AdminSavePromptTemplate)
+	adminGroup.DELETE("/templates/:kind", meowController.AdminResetPromptTemplate)
+	adminGroup.POST("/attachments/gc", meowController.GarbageCollectAttachments)
 
 	// Swagger endpoint
 	e.GET("/swagger/*", httpSwagger.WrapHandler)
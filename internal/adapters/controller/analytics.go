@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GetDeckAnalytics returns the health of a deck
+// @Summary Get deck analytics
+// @Description Get the pass rate distribution, hardest cards, never reviewed and retired counts,
+// @Description star histogram and the trend over the recent study days of a deck
+// @Tags Decks
+// @Produce json
+// @Param id path string true "Deck ID"
+// @Security BearerAuth
+// @Success 200 {object} types.DeckAnalytics
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /decks/analytics/{id} [get]
+func (hc *MeowController) GetDeckAnalytics(c echo.Context) error {
+	username, err := getUserIDFromContext(c)
+	if err != nil {
+		hc.logger.Error("Unauthorized access attempt", "error", err)
+		return c.JSON(http.StatusUnauthorized, echo.Map{"message": "unauthorized"})
+	}
+
+	deckID := c.Param("id")
+	analytics, err := hc.service.GetDeckAnalytics(deckID, username)
+	if err != nil {
+		if err.Error() == "deck not found" {
+			return c.JSON(http.StatusNotFound, echo.Map{"message": "Deck not found"})
+		}
+		hc.logger.Error("Failed to build deck analytics", "deck_id", deckID, "error", err)
+		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Failed to build deck analytics"})
+	}
+	return c.JSON(http.StatusOK, analytics)
+}
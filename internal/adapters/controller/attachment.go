@@ -0,0 +1,142 @@
+// internal/adapters/controller/attachment.go
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/robstave/meowmorize/internal/domain/types"
+)
+
+// maxAttachmentUpload is slightly above the domain limit so oversized files
+// are reported as too large rather than silently truncated
+const maxAttachmentUpload = 10<<20 + 1
+
+// UploadAttachment stores an image or audio file for use in cards
+// @Summary Upload an attachment
+// @Description Upload an image or audio file. Reference it from card text with the returned url, e.g. ![alt](/api/attachments/{id}).
+// @Tags Attachments
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "Image or audio file"
+// @Security BearerAuth
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 413 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /attachments [post]
+func (hc *MeowController) UploadAttachment(c echo.Context) error {
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		hc.logger.Error("Failed to extract user ID from token", "error", err)
+		return c.JSON(http.StatusUnauthorized, echo.Map{"message": "unauthorized"})
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		hc.logger.Error("Failed to read attachment file", "error", err)
+		return c.JSON(http.StatusBadRequest, echo.Map{"message": "File is required"})
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		hc.logger.Error("Failed to open attachment file", "error", err)
+		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Failed to open file"})
+	}
+	defer src.Close()
+
+	content, err := io.ReadAll(io.LimitReader(src, maxAttachmentUpload))
+	if err != nil {
+		hc.logger.Error("Failed to read attachment content", "error", err)
+		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Failed to read file"})
+	}
+
+	attachment, err := hc.service.UploadAttachment(userID, file.Filename, content)
+	if err != nil {
+		switch {
+		case errors.Is(err, types.ErrAttachmentTooLarge):
+			return c.JSON(http.StatusRequestEntityTooLarge, echo.Map{"message": "File is too large"})
+		case errors.Is(err, types.ErrUnsupportedAttachment):
+			return c.JSON(http.StatusBadRequest, echo.Map{"message": "Only image and audio files are supported"})
+		}
+		hc.logger.Error("Failed to upload attachment", "error", err)
+		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Failed to upload attachment"})
+	}
+
+	return c.JSON(http.StatusCreated, echo.Map{
+		"attachment": attachment,
+		"url":        attachment.URL(),
+	})
+}
+
+// GetAttachment serves the content of an attachment
+// @Summary Get an attachment
+// @Description Serve an uploaded image or audio file. Content never changes for a given ID, so responses are cacheable. Only users who uploaded the file can get it.
+// @Tags Attachments
+// @Produce octet-stream
+// @Param id path string true "Attachment ID"
+// @Param token query string false "JWT, for clients that cannot set the Authorization header"
+// @Security BearerAuth
+// @Success 200 {file} file
+// @Success 304
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /attachments/{id} [get]
+func (hc *MeowController) GetAttachment(c echo.Context) error {
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		hc.logger.Error("Failed to extract user ID from token", "error", err)
+		return c.JSON(http.StatusUnauthorized, echo.Map{"message": "unauthorized"})
+	}
+
+	id := c.Param("id")
+	attachment, content, err := hc.service.GetAttachment(id, userID)
+	if err != nil {
+		if err.Error() == "attachment not found" {
+			return c.JSON(http.StatusNotFound, echo.Map{"message": "Attachment not found"})
+		}
+		hc.logger.Error("Failed to get attachment", "attachment_id", id, "error", err)
+		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Failed to retrieve attachment"})
+	}
+	defer content.Close()
+
+	// Attachments are content addressed, so a matching ETag is always current
+	etag := fmt.Sprintf("%q", attachment.ID)
+	if c.Request().Header.Get("If-None-Match") == etag {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentType, attachment.ContentType)
+	header.Set("Cache-Control", "private, max-age=31536000, immutable")
+	header.Set("ETag", etag)
+	header.Set("X-Content-Type-Options", "nosniff")
+
+	// ServeContent handles range requests so audio can be seeked
+	http.ServeContent(c.Response(), c.Request(), attachment.Filename, attachment.CreatedAt, content)
+	return nil
+}
+
+// GarbageCollectAttachments removes attachments no card or deck references
+// @Summary Garbage collect attachments
+// @Description Delete attachments that are no longer referenced by any card, deck, card draft or pending lint suggestion (admin only)
+// @Tags Attachments
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]int
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/attachments/gc [post]
+func (hc *MeowController) GarbageCollectAttachments(c echo.Context) error {
+	removed, err := hc.service.GarbageCollectAttachments()
+	if err != nil {
+		hc.logger.Error("Failed to garbage collect attachments", "error", err)
+		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Failed to garbage collect attachments"})
+	}
+	return c.JSON(http.StatusOK, map[string]int{"removed": removed})
+}
@@ -14,12 +14,12 @@ import (
 type CardStatsRequest struct {
 	CardID string           `json:"card_id" validate:"required"`
 	DeckID string           `json:"deck_id,omitempty"`
-	Action types.CardAction `json:"action" validate:"required,oneof=IncrementFail IncrementPass IncrementSkip SetStars Retire Unretire ResetStats"`
+	Action types.CardAction `json:"action" validate:"required,oneof=IncrementFail IncrementPass IncrementSkip SetStars Retire Unretire ResetStats ClearLeech"`
 	Value  *int             `json:"value,omitempty"` // Used only for SetStars
 }
 
 // @Summary Update card statistics
-// @Description Update the statistics of a card based on the specified action
+// @Description Update the statistics of a card based on the specified action. Typed and LLMGraded sessions reject pass, fail and skip unless the LLM left the card to be self graded.
 // @Tags Cards
 // @Accept json
 // @Produce json
@@ -49,11 +49,16 @@ func (c *MeowController) UpdateCardStats(ctx echo.Context) error {
 	// Update the card stats
 	// WE are passing the deckID in case we want to update the session too
 	if err := c.service.UpdateCardStats(req.CardID, req.Action, req.Value, req.DeckID, userID); err != nil {
-		if err.Error() == "card not found" {
-			c.logger.Warn("Card not found", "card_id", req.CardID)
+		switch err.Error() {
+		case "card not found", "card not found in session":
+			c.logger.Warn("Card not found", "card_id", req.CardID, "error", err)
 			return ctx.JSON(http.StatusNotFound, echo.Map{
 				"message": "Card not found",
 			})
+		case "session only takes typed answers":
+			return ctx.JSON(http.StatusBadRequest, echo.Map{
+				"message": "Typed sessions are graded with POST /sessions/answer",
+			})
 		}
 		c.logger.Error("Failed to update card stats", "error", err)
 		return ctx.JSON(http.StatusInternalServerError, echo.Map{
@@ -47,6 +47,10 @@ type CreateCardRequest struct {
 	Front  CardContentReq `json:"front" validate:"required"`
 	Back   CardContentReq `json:"back" validate:"required"`
 	Link   string         `json:"link"`
+	// Alternates are extra accepted answers for typed sessions
+	Alternates []string `json:"alternates"`
+	// Hint is shown instead of generated hints when set
+	Hint string `json:"hint"`
 }
 
 // CardContentReq represents the content structure for front and back of a card
@@ -59,6 +63,10 @@ type UpdateCardRequest struct {
 	Front *CardContentReq `json:"front"`
 	Back  *CardContentReq `json:"back"`
 	Link  *string         `json:"link"`
+	// Alternates replaces the accepted alternate answers when provided
+	Alternates *[]string `json:"alternates"`
+	Notes      *string   `json:"notes"`
+	Hint       *string   `json:"hint"`
 }
 
 // @Summary Create a new card
@@ -115,7 +123,9 @@ func (c *MeowController) CreateCard(ctx echo.Context) error {
 		Back: types.CardBack{
 			Text: req.Back.Text,
 		},
-		Link: req.Link,
+		Link:       req.Link,
+		Alternates: req.Alternates,
+		Hint:       req.Hint,
 	}
 
 	// Set card owner
@@ -176,6 +186,15 @@ func (c *MeowController) UpdateCard(ctx echo.Context) error {
 	if req.Link != nil {
 		existingCard.Link = *req.Link
 	}
+	if req.Alternates != nil {
+		existingCard.Alternates = *req.Alternates
+	}
+	if req.Notes != nil {
+		existingCard.Notes = *req.Notes
+	}
+	if req.Hint != nil {
+		existingCard.Hint = *req.Hint
+	}
 
 	// Call the service to update the card
 	if err := c.service.UpdateCard(*existingCard); err != nil {
@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// maxForecastDays caps how far ahead the forecast looks
+const maxForecastDays = 365
+
+// GetForecast returns the reviews due per day for the coming days and the estimated retention
+// @Summary Get review forecast
+// @Description Forecast the number of reviews due per day for the next days, per deck and in total,
+// @Description and estimate each deck's retention from the recent passes and fails
+// @Tags Decks
+// @Produce json
+// @Param days query int false "Number of days to forecast, starting today (default 30, max 365)"
+// @Security BearerAuth
+// @Success 200 {object} types.Forecast
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /decks/forecast [get]
+func (hc *MeowController) GetForecast(c echo.Context) error {
+	username, err := getUserIDFromContext(c)
+	if err != nil {
+		hc.logger.Error("Unauthorized access attempt", "error", err)
+		return c.JSON(http.StatusUnauthorized, echo.Map{"message": "unauthorized"})
+	}
+
+	days := 30
+	if value := c.QueryParam("days"); value != "" {
+		days, err = strconv.Atoi(value)
+		if err != nil || days < 1 || days > maxForecastDays {
+			return c.JSON(http.StatusBadRequest, echo.Map{"message": "days must be between 1 and 365"})
+		}
+	}
+
+	forecast, err := hc.service.GetForecast(username, days)
+	if err != nil {
+		hc.logger.Error("Failed to build forecast", "error", err)
+		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Failed to build forecast"})
+	}
+	return c.JSON(http.StatusOK, forecast)
+}
@@ -0,0 +1,208 @@
+// internal/adapters/controller/generate.go
+package controller
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/robstave/meowmorize/internal/domain/types"
+)
+
+// maxSourceUpload is slightly above the domain limit so oversized files are reported
+// as too large rather than silently truncated
+const maxSourceUpload = 64<<10 + 1
+
+// GenerateCardsRequest asks for cards on pasted text or an uploaded file
+type GenerateCardsRequest struct {
+	DeckID string `json:"deck_id" form:"deck_id"`
+	Count  int    `json:"count" form:"count"`
+	Text   string `json:"text" form:"text"`
+}
+
+// GenerateCards has the LLM write cards from source text into the deck's review queue
+// @Summary Generate cards with the LLM
+// @Description Generate cards from pasted text, or from an uploaded markdown or plain text file, for a deck. The cards go into the review queue and are only added to the deck once approved.
+// @Tags Cards
+// @Accept json,mpfd
+// @Produce json
+// @Param request body GenerateCardsRequest false "Source text, deck and card count"
+// @Param source_file formData file false "Markdown or plain text file, used instead of text"
+// @Security BearerAuth
+// @Success 201 {array} types.CardDraft
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 413 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Failure 504 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /cards/generate [post]
+func (hc *MeowController) GenerateCards(c echo.Context) error {
+	username, err := getUserIDFromContext(c)
+	if err != nil {
+		hc.logger.Error("Failed to extract user ID from token", "error", err)
+		return c.JSON(http.StatusUnauthorized, echo.Map{"message": "unauthorized"})
+	}
+
+	var req GenerateCardsRequest
+	if err := c.Bind(&req); err != nil {
+		hc.logger.Error("Failed to bind generate request", "error", err)
+		return c.JSON(http.StatusBadRequest, echo.Map{"message": "Invalid request format"})
+	}
+	if req.DeckID == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"message": "Deck ID is required"})
+	}
+
+	request := types.GenerateCardsRequest{DeckID: req.DeckID, Count: req.Count, Text: req.Text}
+	if file, err := c.FormFile("source_file"); err == nil {
+		switch strings.ToLower(filepath.Ext(file.Filename)) {
+		case ".md", ".markdown", ".txt":
+		default:
+			return c.JSON(http.StatusBadRequest, echo.Map{"message": "Only markdown and plain text files are supported"})
+		}
+
+		src, err := file.Open()
+		if err != nil {
+			hc.logger.Error("Failed to open source file", "error", err)
+			return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Failed to open file"})
+		}
+		defer src.Close()
+
+		content, err := io.ReadAll(io.LimitReader(src, maxSourceUpload))
+		if err != nil {
+			hc.logger.Error("Failed to read source file", "error", err)
+			return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Failed to read file"})
+		}
+		request.Text = string(content)
+		request.Source = file.Filename
+	}
+
+	drafts, err := hc.service.GenerateCards(c.Request().Context(), username, request)
+	if err != nil {
+		switch {
+		case errors.Is(err, types.ErrLLMNotInitialized):
+			return c.JSON(http.StatusServiceUnavailable, echo.Map{"message": "LLM service is not available"})
+		case errors.Is(err, types.ErrLLMQuotaExceeded):
+			return c.JSON(http.StatusTooManyRequests, echo.Map{"message": "Daily LLM quota reached"})
+		case errors.Is(err, types.ErrSourceTooLarge):
+			return c.JSON(http.StatusRequestEntityTooLarge, echo.Map{"message": "Source text is too large"})
+		case errors.Is(err, types.ErrNoCardsGenerated):
+			return c.JSON(http.StatusBadGateway, echo.Map{"message": "The LLM did not return any cards"})
+		case errors.Is(err, context.DeadlineExceeded):
+			return c.JSON(http.StatusGatewayTimeout, echo.Map{"message": "The LLM took too long to answer"})
+		case errors.Is(err, context.Canceled):
+			return c.JSON(http.StatusServiceUnavailable, echo.Map{"message": "The request was cancelled"})
+		}
+		switch err.Error() {
+		case "deck not found":
+			return c.JSON(http.StatusNotFound, echo.Map{"message": "Deck not found"})
+		case "source text is required", "card count must be between 1 and 50":
+			return c.JSON(http.StatusBadRequest, echo.Map{"message": err.Error()})
+		}
+		hc.logger.Error("Failed to generate cards", "error", err)
+		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Failed to generate cards"})
+	}
+
+	return c.JSON(http.StatusCreated, drafts)
+}
+
+// GetCardDrafts lists the generated cards waiting for review
+// @Summary List card drafts
+// @Description List the authenticated user's generated cards waiting for review, oldest first
+// @Tags Cards
+// @Produce json
+// @Param deck_id query string false "Only list the drafts of this deck"
+// @Security BearerAuth
+// @Success 200 {array} types.CardDraft
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /cards/drafts [get]
+func (hc *MeowController) GetCardDrafts(c echo.Context) error {
+	username, err := getUserIDFromContext(c)
+	if err != nil {
+		hc.logger.Error("Failed to extract user ID from token", "error", err)
+		return c.JSON(http.StatusUnauthorized, echo.Map{"message": "unauthorized"})
+	}
+
+	drafts, err := hc.service.GetCardDrafts(username, c.QueryParam("deck_id"))
+	if err != nil {
+		hc.logger.Error("Failed to retrieve card drafts", "error", err)
+		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Failed to retrieve card drafts"})
+	}
+	return c.JSON(http.StatusOK, drafts)
+}
+
+// ApproveCardDraft adds a generated card to its deck
+// @Summary Approve a card draft
+// @Description Create the card of a draft in its deck, optionally with an edited front or back, and remove the draft from the review queue
+// @Tags Cards
+// @Accept json
+// @Produce json
+// @Param id path string true "Draft ID"
+// @Param edit body types.CardDraftEdit false "Edits to apply"
+// @Security BearerAuth
+// @Success 201 {object} types.Card
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /cards/drafts/{id}/approve [post]
+func (hc *MeowController) ApproveCardDraft(c echo.Context) error {
+	username, err := getUserIDFromContext(c)
+	if err != nil {
+		hc.logger.Error("Failed to extract user ID from token", "error", err)
+		return c.JSON(http.StatusUnauthorized, echo.Map{"message": "unauthorized"})
+	}
+
+	var edit types.CardDraftEdit
+	if err := c.Bind(&edit); err != nil {
+		hc.logger.Error("Failed to bind draft edit", "error", err)
+		return c.JSON(http.StatusBadRequest, echo.Map{"message": "Invalid request format"})
+	}
+
+	card, err := hc.service.ApproveCardDraft(c.Param("id"), username, edit)
+	if err != nil {
+		switch err.Error() {
+		case "draft not found":
+			return c.JSON(http.StatusNotFound, echo.Map{"message": "Draft not found"})
+		case "card front and back are required":
+			return c.JSON(http.StatusBadRequest, echo.Map{"message": err.Error()})
+		}
+		hc.logger.Error("Failed to approve card draft", "error", err)
+		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Failed to approve card draft"})
+	}
+	return c.JSON(http.StatusCreated, card)
+}
+
+// RejectCardDraft drops a generated card
+// @Summary Reject a card draft
+// @Description Remove a draft from the review queue without creating a card
+// @Tags Cards
+// @Produce json
+// @Param id path string true "Draft ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /cards/drafts/{id} [delete]
+func (hc *MeowController) RejectCardDraft(c echo.Context) error {
+	username, err := getUserIDFromContext(c)
+	if err != nil {
+		hc.logger.Error("Failed to extract user ID from token", "error", err)
+		return c.JSON(http.StatusUnauthorized, echo.Map{"message": "unauthorized"})
+	}
+
+	if err := hc.service.RejectCardDraft(c.Param("id"), username); err != nil {
+		if err.Error() == "draft not found" {
+			return c.JSON(http.StatusNotFound, echo.Map{"message": "Draft not found"})
+		}
+		hc.logger.Error("Failed to reject card draft", "error", err)
+		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Failed to reject card draft"})
+	}
+	return c.JSON(http.StatusOK, echo.Map{"message": "Draft rejected"})
+}
@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/robstave/meowmorize/internal/domain/types"
+)
+
+// @Summary Get a hint for a card
+// @Description Get a nudge toward the answer. A card with an author-written hint returns that one; otherwise the LLM writes hints of increasing specificity, up to level 3, which are cached. Asking during a session records the hint on the card, so passing it afterwards counts as a hinted pass and is graded lower than a clean one.
+// @Tags Cards
+// @Produce json
+// @Param id path string true "Card ID"
+// @Param deck_id query string false "Deck of the running session"
+// @Param level query int false "Hint level, from 1 (default) to 3"
+// @Security BearerAuth
+// @Success 200 {object} types.Hint
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /cards/hint/{id} [post]
+func (c *MeowController) GetCardHint(ctx echo.Context) error {
+	username, err := getUserIDFromContext(ctx)
+	if err != nil {
+		c.logger.Error("Failed to extract user ID from token", "error", err)
+		return ctx.JSON(http.StatusUnauthorized, echo.Map{"message": "unauthorized"})
+	}
+
+	level := 1
+	if param := ctx.QueryParam("level"); param != "" {
+		level, err = strconv.Atoi(param)
+		if err != nil || level < 1 {
+			return ctx.JSON(http.StatusBadRequest, echo.Map{"message": "Invalid hint level"})
+		}
+	}
+
+	hint, err := c.service.GetCardHint(ctx.Request().Context(), ctx.Param("id"), ctx.QueryParam("deck_id"), username, level)
+	if err != nil {
+		switch {
+		case err.Error() == "card not found":
+			return ctx.JSON(http.StatusNotFound, echo.Map{"message": "Card not found"})
+		case errors.Is(err, types.ErrLLMNotInitialized):
+			return ctx.JSON(http.StatusServiceUnavailable, echo.Map{"message": "LLM service is not available"})
+		case errors.Is(err, types.ErrLLMQuotaExceeded):
+			return ctx.JSON(http.StatusTooManyRequests, echo.Map{"message": "Daily LLM quota reached"})
+		case errors.Is(err, context.DeadlineExceeded):
+			return ctx.JSON(http.StatusGatewayTimeout, echo.Map{"message": "The LLM took too long to answer"})
+		}
+		c.logger.Error("Failed to get hint", "card_id", ctx.Param("id"), "error", err)
+		return ctx.JSON(http.StatusInternalServerError, echo.Map{"message": "Failed to get hint"})
+	}
+	return ctx.JSON(http.StatusOK, hint)
+}
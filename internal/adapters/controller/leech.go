@@ -0,0 +1,33 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GetLeeches lists the cards that keep failing
+// @Summary List leeches
+// @Description List the leeches across all of the authenticated user's decks, each with a shortcut to have the LLM explain the card.
+// @Description A card becomes a leech once its lapses reach the user's leech threshold; the ClearLeech card action gives it a fresh start.
+// @Tags Cards
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} types.Leech
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /cards/leeches [get]
+func (hc *MeowController) GetLeeches(c echo.Context) error {
+	username, err := getUserIDFromContext(c)
+	if err != nil {
+		hc.logger.Error("Unauthorized access attempt", "error", err)
+		return c.JSON(http.StatusUnauthorized, echo.Map{"message": "unauthorized"})
+	}
+
+	leeches, err := hc.service.GetLeeches(username)
+	if err != nil {
+		hc.logger.Error("Failed to list leeches", "error", err)
+		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Failed to list leeches"})
+	}
+	return c.JSON(http.StatusOK, leeches)
+}
@@ -0,0 +1,232 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/robstave/meowmorize/internal/domain/types"
+)
+
+// StartDeckLint starts linting the cards of a deck with the LLM
+// @Summary Lint a deck
+// @Description Start a background job that has the LLM review every card of the deck for ambiguity, multiple facts per card, factual doubts and formatting. Findings nobody acted on from an earlier job are replaced. Follow the progress with GET /decks/lint/{id}.
+// @Tags Decks
+// @Produce json
+// @Param id path string true "Deck ID"
+// @Security BearerAuth
+// @Success 202 {object} types.LintJob
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /decks/lint/{id} [post]
+func (hc *MeowController) StartDeckLint(c echo.Context) error {
+	username, err := getUserIDFromContext(c)
+	if err != nil {
+		hc.logger.Error("Unauthorized access attempt", "error", err)
+		return c.JSON(http.StatusUnauthorized, echo.Map{"message": "unauthorized"})
+	}
+
+	job, err := hc.service.StartDeckLint(c.Param("id"), username)
+	if err != nil {
+		if errors.Is(err, types.ErrLLMNotInitialized) {
+			return c.JSON(http.StatusServiceUnavailable, echo.Map{"message": "LLM service is not available"})
+		}
+		switch err.Error() {
+		case "deck not found":
+			return c.JSON(http.StatusNotFound, echo.Map{"message": "Deck not found"})
+		case "deck is already being linted":
+			return c.JSON(http.StatusConflict, echo.Map{"message": err.Error()})
+		}
+		hc.logger.Error("Failed to start lint job", "deck_id", c.Param("id"), "error", err)
+		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Failed to start lint job"})
+	}
+	return c.JSON(http.StatusAccepted, job)
+}
+
+// GetDeckLint returns the progress of the latest lint job of a deck
+// @Summary Get deck lint progress
+// @Description Get the status and progress of the latest lint job of the deck
+// @Tags Decks
+// @Produce json
+// @Param id path string true "Deck ID"
+// @Security BearerAuth
+// @Success 200 {object} types.LintJob
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /decks/lint/{id} [get]
+func (hc *MeowController) GetDeckLint(c echo.Context) error {
+	username, err := getUserIDFromContext(c)
+	if err != nil {
+		hc.logger.Error("Unauthorized access attempt", "error", err)
+		return c.JSON(http.StatusUnauthorized, echo.Map{"message": "unauthorized"})
+	}
+
+	job, err := hc.service.GetDeckLint(c.Param("id"), username)
+	if err != nil {
+		if err.Error() == "lint job not found" {
+			return c.JSON(http.StatusNotFound, echo.Map{"message": "Lint job not found"})
+		}
+		hc.logger.Error("Failed to retrieve lint job", "deck_id", c.Param("id"), "error", err)
+		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Failed to retrieve lint job"})
+	}
+	return c.JSON(http.StatusOK, job)
+}
+
+// CancelDeckLint stops the running lint job of a deck
+// @Summary Cancel deck lint
+// @Description Stop the running lint job of the deck. The findings so far are kept.
+// @Tags Decks
+// @Produce json
+// @Param id path string true "Deck ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /decks/lint/{id} [delete]
+func (hc *MeowController) CancelDeckLint(c echo.Context) error {
+	username, err := getUserIDFromContext(c)
+	if err != nil {
+		hc.logger.Error("Unauthorized access attempt", "error", err)
+		return c.JSON(http.StatusUnauthorized, echo.Map{"message": "unauthorized"})
+	}
+
+	if err := hc.service.CancelDeckLint(c.Param("id"), username); err != nil {
+		switch err.Error() {
+		case "lint job not found":
+			return c.JSON(http.StatusNotFound, echo.Map{"message": "Lint job not found"})
+		case "no lint job is running":
+			return c.JSON(http.StatusConflict, echo.Map{"message": err.Error()})
+		}
+		hc.logger.Error("Failed to cancel lint job", "deck_id", c.Param("id"), "error", err)
+		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Failed to cancel lint job"})
+	}
+	return c.JSON(http.StatusOK, echo.Map{"message": "Lint job cancelled"})
+}
+
+// GetLintFindings lists the lint findings of a deck
+// @Summary List deck lint findings
+// @Description List the issues the LLM found with the cards of the deck and the suggested rewrites, oldest first
+// @Tags Decks
+// @Produce json
+// @Param id path string true "Deck ID"
+// @Param status query string false "Only list findings with this status: pending, accepted or dismissed"
+// @Security BearerAuth
+// @Success 200 {array} types.LintFinding
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /decks/lint/{id}/findings [get]
+func (hc *MeowController) GetLintFindings(c echo.Context) error {
+	username, err := getUserIDFromContext(c)
+	if err != nil {
+		hc.logger.Error("Unauthorized access attempt", "error", err)
+		return c.JSON(http.StatusUnauthorized, echo.Map{"message": "unauthorized"})
+	}
+
+	findings, err := hc.service.GetLintFindings(c.Param("id"), username, c.QueryParam("status"))
+	if err != nil {
+		hc.logger.Error("Failed to retrieve lint findings", "deck_id", c.Param("id"), "error", err)
+		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Failed to retrieve lint findings"})
+	}
+	return c.JSON(http.StatusOK, findings)
+}
+
+// AcceptLintFinding rewrites a card with the suggestion of a finding
+// @Summary Accept a lint finding
+// @Description Rewrite the card with the suggested front and back of the finding, optionally edited, and record the change as a card revision
+// @Tags Cards
+// @Accept json
+// @Produce json
+// @Param id path string true "Finding ID"
+// @Param edit body types.LintFindingEdit false "Edits to the suggestion"
+// @Security BearerAuth
+// @Success 200 {object} types.Card
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /cards/lint/{id}/accept [post]
+func (hc *MeowController) AcceptLintFinding(c echo.Context) error {
+	username, err := getUserIDFromContext(c)
+	if err != nil {
+		hc.logger.Error("Failed to extract user ID from token", "error", err)
+		return c.JSON(http.StatusUnauthorized, echo.Map{"message": "unauthorized"})
+	}
+
+	var edit types.LintFindingEdit
+	if err := c.Bind(&edit); err != nil {
+		hc.logger.Error("Failed to bind finding edit", "error", err)
+		return c.JSON(http.StatusBadRequest, echo.Map{"message": "Invalid request format"})
+	}
+
+	card, err := hc.service.AcceptLintFinding(c.Param("id"), username, edit)
+	if err != nil {
+		return hc.lintFindingError(c, err)
+	}
+	return c.JSON(http.StatusOK, card)
+}
+
+// DismissLintFinding drops a finding without changing the card
+// @Summary Dismiss a lint finding
+// @Description Mark the finding as dismissed, leaving the card as it is
+// @Tags Cards
+// @Produce json
+// @Param id path string true "Finding ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /cards/lint/{id}/dismiss [post]
+func (hc *MeowController) DismissLintFinding(c echo.Context) error {
+	username, err := getUserIDFromContext(c)
+	if err != nil {
+		hc.logger.Error("Failed to extract user ID from token", "error", err)
+		return c.JSON(http.StatusUnauthorized, echo.Map{"message": "unauthorized"})
+	}
+
+	if err := hc.service.DismissLintFinding(c.Param("id"), username); err != nil {
+		return hc.lintFindingError(c, err)
+	}
+	return c.JSON(http.StatusOK, echo.Map{"message": "Finding dismissed"})
+}
+
+// GetCardRevisions lists the changes made to a card
+// @Summary List card revisions
+// @Description List the recorded changes to the front and back of a card, newest first
+// @Tags Cards
+// @Produce json
+// @Param id path string true "Card ID"
+// @Security BearerAuth
+// @Success 200 {array} types.CardRevision
+// @Failure 500 {object} map[string]string
+// @Router /cards/revisions/{id} [get]
+func (hc *MeowController) GetCardRevisions(c echo.Context) error {
+	revisions, err := hc.service.GetCardRevisions(c.Param("id"))
+	if err != nil {
+		hc.logger.Error("Failed to retrieve card revisions", "card_id", c.Param("id"), "error", err)
+		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Failed to retrieve card revisions"})
+	}
+	return c.JSON(http.StatusOK, revisions)
+}
+
+// lintFindingError responds to an error accepting or dismissing a finding
+func (hc *MeowController) lintFindingError(c echo.Context, err error) error {
+	switch err.Error() {
+	case "finding not found":
+		return c.JSON(http.StatusNotFound, echo.Map{"message": "Finding not found"})
+	case "card not found":
+		return c.JSON(http.StatusNotFound, echo.Map{"message": "Card not found"})
+	case "finding already resolved":
+		return c.JSON(http.StatusConflict, echo.Map{"message": err.Error()})
+	case "card front and back are required":
+		return c.JSON(http.StatusBadRequest, echo.Map{"message": err.Error()})
+	}
+	hc.logger.Error("Failed to resolve lint finding", "finding_id", c.Param("id"), "error", err)
+	return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Failed to resolve lint finding"})
+}
@@ -3,6 +3,9 @@
 package controller
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -18,34 +21,30 @@ type LLMRequest struct {
 }
 
 // @Summary Get LLM explanation for a card
-// @Description Get an AI-generated explanation for a flashcard based on the provided prompt
+// @Description Ask the LLM a question about a flashcard. The exchange is added to the user's explanation thread on the card and earlier turns are sent along as context.
 // @Tags Cards
 // @Accept json
 // @Produce json
+// @Param id path string true "Card ID"
 // @Param request body LLMRequest true "LLM Request"
 // @Security BearerAuth
-// @Success 200 {object} map[string]string
+// @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Failure 429 {object} map[string]string
 // @Failure 500 {object} map[string]string
-// @Router /cards/explain [post]
+// @Router /cards/explain/{id} [post]
 func (c *MeowController) ExplainCard(ctx echo.Context) error {
-	c.logger.Info("fff")
 	cardID := ctx.Param("id")
 	if cardID == "" {
 		return echo.NewHTTPError(http.StatusBadRequest, "Card ID is required")
 	}
 
-	// Get the card first to validate it exists and get its content
-	card, err := c.service.GetCardByID(cardID)
+	username, err := getUserIDFromContext(ctx)
 	if err != nil {
-		if err.Error() == "card not found" {
-			return ctx.JSON(http.StatusNotFound, echo.Map{"message": "Card not found"})
-		}
-		c.logger.Error("Failed to retrieve card", "error", err)
-		return ctx.JSON(http.StatusInternalServerError, echo.Map{"message": "Failed to retrieve card"})
+		c.logger.Error("Failed to extract user ID from token", "error", err)
+		return ctx.JSON(http.StatusUnauthorized, echo.Map{"message": "unauthorized"})
 	}
-	c.logger.Info("bbb")
 
 	var req LLMRequest
 	if err := ctx.Bind(&req); err != nil {
@@ -53,43 +52,224 @@ func (c *MeowController) ExplainCard(ctx echo.Context) error {
 		return ctx.JSON(http.StatusBadRequest, echo.Map{"message": "Invalid request format"})
 	}
 
-	// Format the prompt to include card context
-	formattedPrompt := fmt.Sprintf(`This is regarding the following flashcard:
-
-### Front
-%s
+	turn, err := c.service.ExplainCard(ctx.Request().Context(), cardID, username, req.Prompt)
+	if err != nil {
+		status, message := c.explainError(err)
+		return ctx.JSON(status, echo.Map{"message": message})
+	}
 
-### Back
-%s
+	return ctx.JSON(http.StatusOK, echo.Map{
+		"explanation": turn.Answer,
+		"turn":        turn,
+	})
+}
 
-Question: %s`, card.Front.Text, card.Back.Text, req.Prompt)
+// @Summary Stream an LLM explanation for a card
+// @Description Ask the LLM a question about a flashcard and stream the answer as Server-Sent Events: chunk events carry {"text"} as it is generated, then a done event carries the turn added to the thread, or an error event carries {"message"}. Browsers' EventSource cannot set headers, so the token may also be passed as the token query parameter.
+// @Tags Cards
+// @Produce text/event-stream
+// @Param id path string true "Card ID"
+// @Param prompt query string true "Question about the card"
+// @Param token query string false "JWT, for clients that cannot set the Authorization header"
+// @Security BearerAuth
+// @Success 200 {object} types.ExplanationTurn
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /cards/explain/{id}/stream [get]
+func (c *MeowController) StreamExplanation(ctx echo.Context) error {
+	cardID := ctx.Param("id")
+	prompt := ctx.QueryParam("prompt")
+	if prompt == "" {
+		return ctx.JSON(http.StatusBadRequest, echo.Map{"message": "Prompt is required"})
+	}
 
-	// Call the LLM service
-	response, err := c.service.GetExplanation(formattedPrompt)
+	username, err := getUserIDFromContext(ctx)
 	if err != nil {
-		c.logger.Error("Failed to get LLM explanation", "error", err)
-		if err == types.ErrLLMNotInitialized {
-			return ctx.JSON(http.StatusServiceUnavailable, echo.Map{"message": "LLM service is not available"})
+		c.logger.Error("Failed to extract user ID from token", "error", err)
+		return ctx.JSON(http.StatusUnauthorized, echo.Map{"message": "unauthorized"})
+	}
+
+	// The stream starts with the first chunk, so errors before it get a plain JSON response
+	res := ctx.Response()
+	started := false
+	send := func(event string, payload interface{}) error {
+		if !started {
+			res.Header().Set(echo.HeaderContentType, "text/event-stream")
+			res.Header().Set("Cache-Control", "no-cache")
+			res.Header().Set("Connection", "keep-alive")
+			res.Header().Set("X-Accel-Buffering", "no")
+			res.WriteHeader(http.StatusOK)
+			started = true
+		}
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return err
 		}
-		return ctx.JSON(http.StatusInternalServerError, echo.Map{"message": "Failed to generate explanation"})
+		if _, err := fmt.Fprintf(res, "event: %s\ndata: %s\n\n", event, data); err != nil {
+			return err
+		}
+		res.Flush()
+		return nil
 	}
 
-	return ctx.JSON(http.StatusOK, echo.Map{
-		"explanation": response,
+	turn, err := c.service.StreamExplainCard(ctx.Request().Context(), cardID, username, prompt, func(chunk string) error {
+		return send("chunk", echo.Map{"text": chunk})
 	})
+	if err != nil {
+		status, message := c.explainError(err)
+		if !started {
+			return ctx.JSON(status, echo.Map{"message": message})
+		}
+		send("error", echo.Map{"message": message})
+		return nil
+	}
+
+	send("done", turn)
+	return nil
+}
+
+// explainError maps an explanation error to the response status and message
+func (c *MeowController) explainError(err error) (int, string) {
+	switch {
+	case err.Error() == "card not found":
+		return http.StatusNotFound, "Card not found"
+	case errors.Is(err, types.ErrLLMNotInitialized):
+		return http.StatusServiceUnavailable, "LLM service is not available"
+	case errors.Is(err, types.ErrLLMQuotaExceeded):
+		return http.StatusTooManyRequests, "Daily LLM quota reached"
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout, "The LLM took too long to answer"
+	case errors.Is(err, context.Canceled):
+		return http.StatusServiceUnavailable, "The request was cancelled"
+	}
+	c.logger.Error("Failed to get LLM explanation", "error", err)
+	return http.StatusInternalServerError, "Failed to generate explanation"
+}
+
+// @Summary List explanation threads
+// @Description List the authenticated user's explanation threads, most recently active first
+// @Tags Cards
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} types.ExplanationThreadSummary
+// @Failure 500 {object} map[string]string
+// @Router /cards/explain/threads [get]
+func (c *MeowController) GetExplanationThreads(ctx echo.Context) error {
+	username, err := getUserIDFromContext(ctx)
+	if err != nil {
+		c.logger.Error("Failed to extract user ID from token", "error", err)
+		return ctx.JSON(http.StatusUnauthorized, echo.Map{"message": "unauthorized"})
+	}
+
+	threads, err := c.service.GetExplanationThreads(username)
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, echo.Map{"message": "Failed to retrieve threads"})
+	}
+	return ctx.JSON(http.StatusOK, threads)
+}
+
+// @Summary Get an explanation thread
+// @Description Get the authenticated user's explanation thread on a card, oldest turn first
+// @Tags Cards
+// @Produce json
+// @Param card_id path string true "Card ID"
+// @Security BearerAuth
+// @Success 200 {object} types.ExplanationThread
+// @Failure 500 {object} map[string]string
+// @Router /cards/explain/threads/{card_id} [get]
+func (c *MeowController) GetExplanationThread(ctx echo.Context) error {
+	username, err := getUserIDFromContext(ctx)
+	if err != nil {
+		c.logger.Error("Failed to extract user ID from token", "error", err)
+		return ctx.JSON(http.StatusUnauthorized, echo.Map{"message": "unauthorized"})
+	}
+
+	thread, err := c.service.GetExplanationThread(ctx.Param("card_id"), username)
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, echo.Map{"message": "Failed to retrieve thread"})
+	}
+	return ctx.JSON(http.StatusOK, thread)
+}
+
+// @Summary Delete an explanation thread
+// @Description Delete the authenticated user's explanation thread on a card
+// @Tags Cards
+// @Produce json
+// @Param card_id path string true "Card ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /cards/explain/threads/{card_id} [delete]
+func (c *MeowController) DeleteExplanationThread(ctx echo.Context) error {
+	username, err := getUserIDFromContext(ctx)
+	if err != nil {
+		c.logger.Error("Failed to extract user ID from token", "error", err)
+		return ctx.JSON(http.StatusUnauthorized, echo.Map{"message": "unauthorized"})
+	}
+
+	if err := c.service.DeleteExplanationThread(ctx.Param("card_id"), username); err != nil {
+		if err.Error() == "thread not found" {
+			return ctx.JSON(http.StatusNotFound, echo.Map{"message": "Thread not found"})
+		}
+		return ctx.JSON(http.StatusInternalServerError, echo.Map{"message": "Failed to delete thread"})
+	}
+	return ctx.JSON(http.StatusOK, echo.Map{"message": "Thread deleted"})
+}
+
+// PromoteExplanationRequest picks where a promoted answer goes
+type PromoteExplanationRequest struct {
+	Target string `json:"target" validate:"required,oneof=back notes"`
+}
+
+// @Summary Promote an explanation
+// @Description Append the answer of an explanation turn to the back or the notes of its card
+// @Tags Cards
+// @Accept json
+// @Produce json
+// @Param id path string true "Turn ID"
+// @Param request body PromoteExplanationRequest true "Where the answer goes"
+// @Security BearerAuth
+// @Success 200 {object} types.Card
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /cards/explain/turns/{id}/promote [post]
+func (c *MeowController) PromoteExplanation(ctx echo.Context) error {
+	username, err := getUserIDFromContext(ctx)
+	if err != nil {
+		c.logger.Error("Failed to extract user ID from token", "error", err)
+		return ctx.JSON(http.StatusUnauthorized, echo.Map{"message": "unauthorized"})
+	}
+
+	var req PromoteExplanationRequest
+	if err := ctx.Bind(&req); err != nil {
+		c.logger.Error("Failed to bind request", "error", err)
+		return ctx.JSON(http.StatusBadRequest, echo.Map{"message": "Invalid request format"})
+	}
+
+	card, err := c.service.PromoteExplanation(ctx.Param("id"), username, req.Target)
+	if err != nil {
+		switch err.Error() {
+		case "promote target must be back or notes":
+			return ctx.JSON(http.StatusBadRequest, echo.Map{"message": err.Error()})
+		case "explanation not found", "card not found":
+			return ctx.JSON(http.StatusNotFound, echo.Map{"message": "Explanation not found"})
+		}
+		return ctx.JSON(http.StatusInternalServerError, echo.Map{"message": "Failed to promote explanation"})
+	}
+	return ctx.JSON(http.StatusOK, card)
 }
 
 // @Summary Get LLM service status
-// @Description Check if the LLM service is available and properly initialized
+// @Description Check if the LLM service is available and properly initialized, and which provider and model are in use
 // @Tags Cards
 // @Produce json
-// @Param request body LLMRequest true "LLM Request"
 // @Security BearerAuth
-// @Success 200 {object} map[string]bool
+// @Success 200 {object} types.LLMStatus
 // @Router /cards/explain/status [get]
 func (c *MeowController) GetLLMStatus(ctx echo.Context) error {
-	isAvailable := c.service.IsLLMAvailable()
-	return ctx.JSON(http.StatusOK, map[string]bool{
-		"available": isAvailable,
-	})
+	return ctx.JSON(http.StatusOK, c.service.GetLLMStatus())
 }
@@ -0,0 +1,176 @@
+// internal/adapters/controller/prompt_template.go
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/robstave/meowmorize/internal/domain/types"
+)
+
+// PromptTemplateRequest carries the body of a prompt template
+type PromptTemplateRequest struct {
+	Body string `json:"body"` // Go text/template executed with types.PromptData
+}
+
+// GetPromptTemplates lists the prompt templates in use for the user
+// @Summary List prompt templates
+// @Description List the explain, hint, generate, grade, lint and translate prompt templates in use for the authenticated user: their own, else the admin default, else the built-in one
+// @Tags Users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} types.PromptTemplate
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /user/templates [get]
+func (hc *MeowController) GetPromptTemplates(c echo.Context) error {
+	username, err := getUserIDFromContext(c)
+	if err != nil {
+		hc.logger.Error("failed to get user from token", "error", err)
+		return c.JSON(http.StatusUnauthorized, echo.Map{"message": "unauthorized"})
+	}
+	return hc.listPromptTemplates(c, username)
+}
+
+// SavePromptTemplate saves a new version of the user's prompt template
+// @Summary Save a prompt template
+// @Description Save a new version of the authenticated user's prompt template of a kind. Templates are Go text/template documents with the variables .Front, .Back, .Deck and .Question, plus .Turns for explain, .Level and .Hints for hint, .Answer and .Alternates for grade, .Count, .Source and .CardFormat for generate, and .Source and .Language for translate.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param kind path string true "explain, hint, generate, grade, lint or translate"
+// @Param template body PromptTemplateRequest true "Template"
+// @Security BearerAuth
+// @Success 201 {object} types.PromptTemplate
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /user/templates/{kind} [put]
+func (hc *MeowController) SavePromptTemplate(c echo.Context) error {
+	username, err := getUserIDFromContext(c)
+	if err != nil {
+		hc.logger.Error("failed to get user from token", "error", err)
+		return c.JSON(http.StatusUnauthorized, echo.Map{"message": "unauthorized"})
+	}
+	return hc.savePromptTemplate(c, username)
+}
+
+// ResetPromptTemplate drops the user's prompt template
+// @Summary Reset a prompt template
+// @Description Stop using the authenticated user's prompt template of a kind, so the admin default is used again. Its versions are kept for the usage history.
+// @Tags Users
+// @Produce json
+// @Param kind path string true "explain, hint, generate, grade, lint or translate"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /user/templates/{kind} [delete]
+func (hc *MeowController) ResetPromptTemplate(c echo.Context) error {
+	username, err := getUserIDFromContext(c)
+	if err != nil {
+		hc.logger.Error("failed to get user from token", "error", err)
+		return c.JSON(http.StatusUnauthorized, echo.Map{"message": "unauthorized"})
+	}
+	return hc.resetPromptTemplate(c, username)
+}
+
+// AdminGetPromptTemplates lists the default prompt templates
+// @Summary List default prompt templates
+// @Description List the default explain, hint, generate, grade, lint and translate prompt templates, the built-in ones where no default was saved (admin only)
+// @Tags Users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} types.PromptTemplate
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/templates [get]
+func (hc *MeowController) AdminGetPromptTemplates(c echo.Context) error {
+	return hc.listPromptTemplates(c, "")
+}
+
+// AdminSavePromptTemplate saves a new version of a default prompt template
+// @Summary Save a default prompt template
+// @Description Save a new version of the default prompt template of a kind, used by everyone without their own (admin only)
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param kind path string true "explain, hint, generate, grade, lint or translate"
+// @Param template body PromptTemplateRequest true "Template"
+// @Security BearerAuth
+// @Success 201 {object} types.PromptTemplate
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/templates/{kind} [put]
+func (hc *MeowController) AdminSavePromptTemplate(c echo.Context) error {
+	return hc.savePromptTemplate(c, "")
+}
+
+// AdminResetPromptTemplate drops a default prompt template
+// @Summary Reset a default prompt template
+// @Description Stop using the default prompt template of a kind, so the built-in one is used again. Its versions are kept for the usage history (admin only)
+// @Tags Users
+// @Produce json
+// @Param kind path string true "explain, hint, generate, grade, lint or translate"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/templates/{kind} [delete]
+func (hc *MeowController) AdminResetPromptTemplate(c echo.Context) error {
+	return hc.resetPromptTemplate(c, "")
+}
+
+// listPromptTemplates responds with the templates in use for the user, the defaults for ""
+func (hc *MeowController) listPromptTemplates(c echo.Context, username string) error {
+	templates, err := hc.service.GetPromptTemplates(username)
+	if err != nil {
+		hc.logger.Error("failed to get prompt templates", "error", err)
+		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "failed to retrieve templates"})
+	}
+	return c.JSON(http.StatusOK, templates)
+}
+
+// savePromptTemplate saves the template of the request for the user, the default for ""
+func (hc *MeowController) savePromptTemplate(c echo.Context, username string) error {
+	var req PromptTemplateRequest
+	if err := c.Bind(&req); err != nil {
+		hc.logger.Error("failed to bind template request", "error", err)
+		return c.JSON(http.StatusBadRequest, echo.Map{"message": "invalid request payload"})
+	}
+
+	template, err := hc.service.SavePromptTemplate(username, c.Param("kind"), req.Body)
+	if err != nil {
+		if errors.Is(err, types.ErrInvalidTemplate) {
+			return c.JSON(http.StatusBadRequest, echo.Map{"message": err.Error()})
+		}
+		switch err.Error() {
+		case "unknown template kind", "template body is required":
+			return c.JSON(http.StatusBadRequest, echo.Map{"message": err.Error()})
+		}
+		hc.logger.Error("failed to save prompt template", "error", err)
+		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "failed to save template"})
+	}
+	return c.JSON(http.StatusCreated, template)
+}
+
+// resetPromptTemplate removes the user's template of the kind, the default for ""
+func (hc *MeowController) resetPromptTemplate(c echo.Context, username string) error {
+	if err := hc.service.ResetPromptTemplate(username, c.Param("kind")); err != nil {
+		switch err.Error() {
+		case "unknown template kind":
+			return c.JSON(http.StatusBadRequest, echo.Map{"message": err.Error()})
+		case "template not found":
+			return c.JSON(http.StatusNotFound, echo.Map{"message": "Template not found"})
+		}
+		hc.logger.Error("failed to reset prompt template", "error", err)
+		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "failed to reset template"})
+	}
+	return c.JSON(http.StatusOK, echo.Map{"message": "template reset"})
+}
@@ -2,7 +2,10 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/robstave/meowmorize/internal/domain/types"
@@ -12,7 +15,20 @@ import (
 type StartSessionRequest struct {
 	DeckID string              `json:"deck_id" validate:"required,uuid"`
 	Count  int                 `json:"count" validate:"min=1"`
-	Method types.SessionMethod `json:"method" validate:"required,oneof=Random Fails Skips Worst Stars Unrated Adjustedrandom"`
+	Method types.SessionMethod `json:"method" validate:"required,oneof=Random Fails Skips Worst Stars Unrated Adjustedrandom Retired Resurrect"`
+	// Mode selects self grading (default), typed answers graded by the server or typed answers graded by the LLM
+	Mode       types.SessionMode `json:"mode,omitempty" validate:"omitempty,oneof=SelfGraded Typed LLMGraded"`
+	Strictness types.Strictness  `json:"strictness,omitempty" validate:"omitempty,oneof=Exact Strict Normal Lenient"`
+	// RoundPolicy decides what happens after the last card of a round, Resort by default
+	RoundPolicy types.RoundPolicy `json:"round_policy,omitempty" validate:"omitempty,oneof=Resort OnlyMissed Shuffle Stop"`
+	// LearningSteps re-shows failed cards after that many other cards, e.g. [3, 10]
+	LearningSteps []int `json:"learning_steps,omitempty" validate:"omitempty,dive,min=1"`
+	// GraduateAfter is how many consecutive passes end learning for a card, 2 by default
+	GraduateAfter int `json:"graduate_after,omitempty" validate:"omitempty,min=1"`
+	// TimeLimitMinutes ends the session after that many minutes
+	TimeLimitMinutes int `json:"time_limit_minutes,omitempty" validate:"omitempty,min=1"`
+	// Goal ends the session after that many correct answers
+	Goal int `json:"goal,omitempty" validate:"omitempty,min=1"`
 }
 
 // StartSession handles the initiation of a new review session for a deck
@@ -46,7 +62,26 @@ func (hc *MeowController) StartSession(c echo.Context) error {
 	// e.g., if err := c.Validate(req); err != nil { ... }
 
 	// Start the session
-	if err := hc.service.StartSession(req.DeckID, req.Count, req.Method, userID); err != nil {
+	opts := types.SessionOptions{
+		Mode:          req.Mode,
+		Strictness:    req.Strictness,
+		RoundPolicy:   req.RoundPolicy,
+		LearningSteps: req.LearningSteps,
+		GraduateAfter: req.GraduateAfter,
+		TimeLimit:     time.Duration(req.TimeLimitMinutes) * time.Minute,
+		GoalCorrect:   req.Goal,
+	}
+	if err := hc.service.StartSession(req.DeckID, req.Count, req.Method, userID, opts); err != nil {
+		switch err.Error() {
+		case "learning steps must be positive":
+			return c.JSON(http.StatusBadRequest, echo.Map{
+				"message": "Learning steps must be positive",
+			})
+		case "session limits must not be negative":
+			return c.JSON(http.StatusBadRequest, echo.Map{
+				"message": "Time limit and goal must not be negative",
+			})
+		}
 		// You can handle specific errors if your service returns them
 		hc.logger.Error("Failed to start session", "error", err)
 		return c.JSON(http.StatusInternalServerError, echo.Map{
@@ -62,11 +97,15 @@ func (hc *MeowController) StartSession(c echo.Context) error {
 // GetNextCardResponse represents the response containing the next card ID
 type GetNextCardResponse struct {
 	CardID string `json:"card_id"`
+	// Complete is set instead of a card once the session's goal, time-box or rounds are done
+	Complete       bool                 `json:"complete,omitempty"`
+	CompleteReason types.CompleteReason `json:"complete_reason,omitempty"`
+	GoalMet        bool                 `json:"goal_met,omitempty"`
 }
 
 // GetNextCard retrieves the next card ID in the current session
 // @Summary Get the next card in the session
-// @Description Retrieve the ID of the next card to review in the current session
+// @Description Retrieve the ID of the next card to review in the current session. Once the session is complete, complete is set with the reason and whether the goal was met instead.
 // @Tags Sessions
 // @Produce  json
 // @Param deck_id query string true "Deck ID"
@@ -85,6 +124,20 @@ func (hc *MeowController) GetNextCard(c echo.Context) error {
 	}
 
 	cardID, err := hc.service.GetNextCard(deckID)
+	if errors.Is(err, types.ErrSessionComplete) {
+		stats, statsErr := hc.service.GetSessionStats(deckID)
+		if statsErr != nil {
+			hc.logger.Error("Failed to get session stats", "deck_id", deckID, "error", statsErr)
+			return c.JSON(http.StatusInternalServerError, echo.Map{
+				"message": "Failed to retrieve session statistics",
+			})
+		}
+		return c.JSON(http.StatusOK, GetNextCardResponse{
+			Complete:       true,
+			CompleteReason: stats.CompleteReason,
+			GoalMet:        stats.GoalMet,
+		})
+	}
 	if err != nil {
 		if err.Error() == "session does not exist for the given deck" {
 			hc.logger.Warn("Session not found for deck", "deck_id", deckID)
@@ -109,6 +162,147 @@ func (hc *MeowController) GetNextCard(c echo.Context) error {
 	})
 }
 
+// SubmitAnswerRequest represents the payload for answering a card in a typed session
+type SubmitAnswerRequest struct {
+	DeckID string `json:"deck_id" validate:"required"`
+	CardID string `json:"card_id" validate:"required"`
+	Answer string `json:"answer"`
+}
+
+// SubmitAnswer grades a typed answer for the current card of a typed session
+// @Summary Submit a typed answer
+// @Description Grade a typed answer against the back of the card and its accepted alternates, or have the LLM grade it in an LLMGraded session. The grade is recorded as a pass, fail or skip. Only the card last served can be answered. When the LLM cannot grade the answer, self_grade is set, nothing is recorded and the card can be graded with POST /cards/stats.
+// @Tags Sessions
+// @Accept  json
+// @Produce  json
+// @Param answer body SubmitAnswerRequest true "Typed answer"
+// @Security BearerAuth
+// @Success 200 {object} types.AnswerGrade
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /sessions/answer [post]
+func (hc *MeowController) SubmitAnswer(c echo.Context) error {
+	var req SubmitAnswerRequest
+	if err := c.Bind(&req); err != nil {
+		hc.logger.Error("Failed to bind submit answer request", "error", err)
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "Invalid request payload",
+		})
+	}
+	if req.DeckID == "" || req.CardID == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "Deck ID and Card ID are required",
+		})
+	}
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		hc.logger.Error("Failed to extract user id from token", "error", err)
+		return c.JSON(http.StatusUnauthorized, echo.Map{"message": "unauthorized"})
+	}
+
+	grade, err := hc.service.SubmitAnswer(c.Request().Context(), req.DeckID, req.CardID, req.Answer, userID)
+	if err != nil {
+		switch err.Error() {
+		case "session does not exist for the given deck":
+			return c.JSON(http.StatusNotFound, echo.Map{
+				"message": "Session not found for the given deck",
+			})
+		case "card not found", "card not found in session":
+			return c.JSON(http.StatusNotFound, echo.Map{
+				"message": "Card not found",
+			})
+		case "session is not in typed mode":
+			return c.JSON(http.StatusBadRequest, echo.Map{
+				"message": "Session is not in typed mode",
+			})
+		case "card is not being served":
+			return c.JSON(http.StatusBadRequest, echo.Map{
+				"message": "Card is not the one being served",
+			})
+		}
+		hc.logger.Error("Failed to grade answer", "deck_id", req.DeckID, "card_id", req.CardID, "error", err)
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "Failed to grade answer",
+		})
+	}
+
+	return c.JSON(http.StatusOK, grade)
+}
+
+// UndoReviewsRequest represents the payload for undoing session reviews
+type UndoReviewsRequest struct {
+	DeckID string `json:"deck_id" validate:"required"`
+	Count  int    `json:"count"` // Number of reviews to undo, defaults to 1
+}
+
+// UndoReviewsResponse reports how many reviews were undone and the resulting session statistics
+type UndoReviewsResponse struct {
+	Undone int                `json:"undone"`
+	Stats  types.SessionStats `json:"stats"`
+}
+
+// UndoReviews reverts the most recent reviews of the current session
+// @Summary Undo session reviews
+// @Description Revert the last N pass, fail or skip actions of the current session. Card counters and the session position are restored and the session log entries are marked as undone.
+// @Tags Sessions
+// @Accept  json
+// @Produce  json
+// @Param undo body UndoReviewsRequest true "Undo request"
+// @Security BearerAuth
+// @Success 200 {object} UndoReviewsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /sessions/undo [post]
+func (hc *MeowController) UndoReviews(c echo.Context) error {
+	var req UndoReviewsRequest
+	if err := c.Bind(&req); err != nil {
+		hc.logger.Error("Failed to bind undo request", "error", err)
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "Invalid request payload",
+		})
+	}
+	if req.DeckID == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "Deck ID is required",
+		})
+	}
+	if req.Count < 0 {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "Count must not be negative",
+		})
+	}
+
+	undone, err := hc.service.UndoReviews(req.DeckID, req.Count)
+	if err != nil {
+		if err.Error() == "session does not exist for the given deck" {
+			return c.JSON(http.StatusNotFound, echo.Map{
+				"message": "Session not found for the given deck",
+			})
+		}
+		hc.logger.Error("Failed to undo reviews", "deck_id", req.DeckID, "error", err)
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "Failed to undo reviews",
+		})
+	}
+
+	stats, err := hc.service.GetSessionStats(req.DeckID)
+	if err != nil {
+		hc.logger.Error("Failed to get session stats", "deck_id", req.DeckID, "error", err)
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "Failed to retrieve session statistics",
+		})
+	}
+
+	return c.JSON(http.StatusOK, UndoReviewsResponse{
+		Undone: undone,
+		Stats:  stats,
+	})
+}
+
 // ClearSession handles the termination of a review session for a deck
 // @Summary Clear a review session
 // @Description Terminate and clear the current review session for a specific deck
@@ -150,11 +344,19 @@ func (hc *MeowController) ClearSession(c echo.Context) error {
 
 // GetSessionStatsResponse represents the session statistics
 type GetSessionStatsResponse struct {
+	SessionID    string            `json:"session_id"`
 	TotalCards   int               `json:"total_cards"`
 	ViewedCount  int               `json:"viewed_count"`
 	Remaining    int               `json:"remaining"`
 	CurrentIndex int               `json:"current_index"`
 	CardStats    []types.CardStats `json:"card_stats"`
+
+	Correct        int                  `json:"correct"`
+	Goal           int                  `json:"goal,omitempty"`
+	EndsAt         *time.Time           `json:"ends_at,omitempty"`
+	Completed      bool                 `json:"completed"`
+	CompleteReason types.CompleteReason `json:"complete_reason,omitempty"`
+	GoalMet        bool                 `json:"goal_met"`
 }
 
 // GetSessionStats retrieves the statistics of the current session for a deck
@@ -192,11 +394,19 @@ func (hc *MeowController) GetSessionStats(c echo.Context) error {
 	}
 
 	return c.JSON(http.StatusOK, GetSessionStatsResponse{
+		SessionID:    stats.SessionID,
 		TotalCards:   stats.TotalCards,
 		ViewedCount:  stats.ViewedCount,
 		Remaining:    stats.Remaining,
 		CurrentIndex: stats.CurrentIndex,
 		CardStats:    stats.CardStats,
+
+		Correct:        stats.Correct,
+		Goal:           stats.Goal,
+		EndsAt:         stats.EndsAt,
+		Completed:      stats.Completed,
+		CompleteReason: stats.CompleteReason,
+		GoalMet:        stats.GoalMet,
 	})
 }
 
@@ -225,12 +435,71 @@ func (hc *MeowController) GetSessionLogs(c echo.Context) error {
 	return c.JSON(http.StatusOK, logs)
 }
 
+// maxSessionPageSize caps the page size of the session history endpoints
+const maxSessionPageSize = 100
+
+// parseSessionHistoryQuery reads the page, page_size, from and to query parameters.
+// Pages start at 1; from and to accept RFC 3339 timestamps or YYYY-MM-DD dates.
+func parseSessionHistoryQuery(c echo.Context, deckID string, defaultPageSize int) (types.SessionHistoryQuery, error) {
+	query := types.SessionHistoryQuery{DeckID: deckID, Limit: defaultPageSize}
+
+	page := 1
+	if value := c.QueryParam("page"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return query, errors.New("page must be a positive number")
+		}
+		page = n
+	}
+	if value := c.QueryParam("page_size"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > maxSessionPageSize {
+			return query, errors.New("page_size must be between 1 and 100")
+		}
+		query.Limit = n
+	}
+	query.Offset = (page - 1) * query.Limit
+
+	var err error
+	if query.From, err = parseHistoryTime(c.QueryParam("from"), false); err != nil {
+		return query, errors.New("from must be an RFC 3339 timestamp or a YYYY-MM-DD date")
+	}
+	if query.To, err = parseHistoryTime(c.QueryParam("to"), true); err != nil {
+		return query, errors.New("to must be an RFC 3339 timestamp or a YYYY-MM-DD date")
+	}
+	return query, nil
+}
+
+// parseHistoryTime parses a timestamp or a date. A date used as the end of a range
+// includes the whole day.
+func parseHistoryTime(value string, end bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
 // GetSessionLogIds retrieves distinct session log IDs for a given user (optionally filtered by deck).
 // @Summary Get session log IDs by user
-// @Description Retrieve session log IDs for a user, optionally filtered by deck ID
+// @Description Retrieve a page of session IDs for a user, newest first, optionally filtered by deck ID and date range.
+// @Description The total number of matching sessions is returned in the X-Total-Count header.
 // @Tags SessionLogs
 // @Produce json
 // @Param deck_id query string false "Deck ID"
+// @Param page query int false "Page number, starting at 1"
+// @Param page_size query int false "Sessions per page (default 20, max 100)"
+// @Param from query string false "Only sessions with activity at or after this time (RFC 3339 or YYYY-MM-DD)"
+// @Param to query string false "Only sessions with activity before this time (RFC 3339, or through this YYYY-MM-DD date)"
 // @Security BearerAuth
 // @Success 200 {array} string
 // @Failure 400 {object} map[string]string
@@ -249,20 +518,34 @@ func (hc *MeowController) GetSessionLogIds(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, echo.Map{"message": "User ID is required"})
 	}
 
-	ids, err := hc.service.GetSessionLogIdsByUser(userID, deckID)
+	query, err := parseSessionHistoryQuery(c, deckID, 20)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"message": err.Error()})
+	}
+
+	ids, total, err := hc.service.GetSessionLogIdsByUser(userID, query)
 	if err != nil {
 		hc.logger.Error("Failed to get session log IDs", "user_id", userID, "error", err)
 		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Failed to get session log IDs"})
 	}
+	if ids == nil {
+		ids = []string{}
+	}
+	c.Response().Header().Set("X-Total-Count", strconv.FormatInt(total, 10))
 	return c.JSON(http.StatusOK, ids)
 }
 
 // GetSessionOverview returns an overview of recent sessions for a deck
 // @Summary Get session overview
-// @Description Get recent session stats (up to 3 sessions)
+// @Description Get a page of session stats for a deck, newest first (3 sessions per page by default).
+// @Description The total number of matching sessions is returned in the X-Total-Count header.
 // @Tags Sessions
 // @Produce json
 // @Param id path string true "Deck ID"
+// @Param page query int false "Page number, starting at 1"
+// @Param page_size query int false "Sessions per page (default 3, max 100)"
+// @Param from query string false "Only sessions with activity at or after this time (RFC 3339 or YYYY-MM-DD)"
+// @Param to query string false "Only sessions with activity before this time (RFC 3339, or through this YYYY-MM-DD date)"
 // @Security BearerAuth
 // @Success 200 {array} types.SessionOverview
 // @Failure 400 {object} map[string]string
@@ -282,12 +565,18 @@ func (hc *MeowController) GetSessionOverview(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, echo.Map{"message": "Deck ID required"})
 	}
 
-	sessionOverview, err := hc.service.GetSessionOverview(userID, deckID)
+	query, err := parseSessionHistoryQuery(c, deckID, 3)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"message": err.Error()})
+	}
+
+	sessionOverview, total, err := hc.service.GetSessionOverview(userID, query)
 	if err != nil {
 		hc.logger.Error("Failed to retrieve session overview", "deck_id", deckID, "error", err)
 		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Failed to retrieve session overview"})
 	}
 
+	c.Response().Header().Set("X-Total-Count", strconv.FormatInt(total, 10))
 	if sessionOverview == nil {
 		// Return empty array if no sessions found
 
@@ -296,3 +585,37 @@ func (hc *MeowController) GetSessionOverview(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, sessionOverview)
 }
+
+// GetSessionReport returns the full breakdown of a session
+// @Summary Get session report
+// @Description Get every card's attempts, first-try and final result, time spent and star changes for a session,
+// @Description with deck-level deltas against the previous session of the same deck
+// @Tags Sessions
+// @Produce json
+// @Param session_id path string true "Session ID"
+// @Security BearerAuth
+// @Success 200 {object} types.SessionReport
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /sessions/report/{session_id} [get]
+func (hc *MeowController) GetSessionReport(c echo.Context) error {
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		hc.logger.Error("Unauthorized access attempt", "error", err)
+		return c.JSON(http.StatusUnauthorized, echo.Map{"message": "unauthorized"})
+	}
+
+	sessionID := c.Param("session_id")
+	report, err := hc.service.GetSessionReport(sessionID, userID)
+	if err != nil {
+		if err.Error() == "session not found" {
+			return c.JSON(http.StatusNotFound, echo.Map{"message": "Session not found"})
+		}
+		hc.logger.Error("Failed to build session report", "session_id", sessionID, "error", err)
+		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Failed to build session report"})
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
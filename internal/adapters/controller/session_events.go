@@ -0,0 +1,86 @@
+// internal/adapters/controller/session_events.go
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// sseKeepAlive is how often a comment is sent so proxies keep an idle stream open
+const sseKeepAlive = 25 * time.Second
+
+// StreamSessionEvents streams the events of a session as Server-Sent Events
+// @Summary Follow a session live
+// @Description Stream session events (snapshot, card_served, card_graded, reshuffle, reviews_undone, session_complete) with the updated session statistics. Only the user who started the session can follow it. Browsers' EventSource cannot set headers, so the token may also be passed as the token query parameter.
+// @Tags Sessions
+// @Produce text/event-stream
+// @Param session_id path string true "Session ID"
+// @Param token query string false "JWT, for clients that cannot set the Authorization header"
+// @Security BearerAuth
+// @Success 200 {object} types.SessionEvent
+// @Failure 404 {object} map[string]string
+// @Router /sessions/{session_id}/events [get]
+func (hc *MeowController) StreamSessionEvents(c echo.Context) error {
+	sessionID := c.Param("session_id")
+
+	username, err := getUserIDFromContext(c)
+	if err != nil {
+		hc.logger.Error("Unauthorized access attempt", "error", err)
+		return c.JSON(http.StatusUnauthorized, echo.Map{"message": "unauthorized"})
+	}
+
+	events, unsubscribe, err := hc.service.SubscribeSessionEvents(sessionID, username)
+	if err != nil {
+		if err.Error() == "session not found" {
+			return c.JSON(http.StatusNotFound, echo.Map{
+				"message": "Session not found",
+			})
+		}
+		hc.logger.Error("Failed to subscribe to session events", "session_id", sessionID, "error", err)
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "Failed to follow session",
+		})
+	}
+	defer unsubscribe()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set("Cache-Control", "no-cache")
+	res.Header().Set("Connection", "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+	res.Flush()
+
+	keepAlive := time.NewTicker(sseKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-c.Request().Context().Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				// The session was cleared or replaced
+				return nil
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				hc.logger.Error("Failed to encode session event", "session_id", sessionID, "error", err)
+				continue
+			}
+			if _, err := fmt.Fprintf(res, "event: %s\ndata: %s\n\n", event.Type, data); err != nil {
+				return nil
+			}
+			res.Flush()
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(res, ": keep-alive\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/robstave/meowmorize/internal/domain/types"
+)
+
+// TranslateDeckRequest names the language to translate a deck to
+type TranslateDeckRequest struct {
+	Language string `json:"language" validate:"required"` // E.g. "Spanish"
+}
+
+// TranslateDeck clones a deck with its cards translated by the LLM
+// @Summary Translate a deck
+// @Description Clone the deck into a new deck with the front, back and hint of every card translated to the language by the LLM. Markdown formatting is kept and code is left untouched. Each translated card links to its source card through source_card_id and is flagged translation_stale when the source's front or back is edited later. Nothing is created unless every card translates.
+// @Tags Decks
+// @Accept json
+// @Produce json
+// @Param id path string true "Deck ID"
+// @Param request body TranslateDeckRequest true "Target language"
+// @Security BearerAuth
+// @Success 201 {object} types.Deck
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /decks/translate/{id} [post]
+func (hc *MeowController) TranslateDeck(c echo.Context) error {
+	username, err := getUserIDFromContext(c)
+	if err != nil {
+		hc.logger.Error("Unauthorized access attempt", "error", err)
+		return c.JSON(http.StatusUnauthorized, echo.Map{"message": "unauthorized"})
+	}
+
+	var req TranslateDeckRequest
+	if err := c.Bind(&req); err != nil {
+		hc.logger.Error("Failed to bind translate request", "error", err)
+		return c.JSON(http.StatusBadRequest, echo.Map{"message": "Invalid request format"})
+	}
+
+	deck, err := hc.service.TranslateDeck(c.Request().Context(), c.Param("id"), username, req.Language)
+	if err != nil {
+		switch err.Error() {
+		case "deck not found":
+			return c.JSON(http.StatusNotFound, echo.Map{"message": "Deck not found"})
+		case "target language is required":
+			return c.JSON(http.StatusBadRequest, echo.Map{"message": "Target language is required"})
+		}
+		return hc.translateError(c, err)
+	}
+	return c.JSON(http.StatusCreated, deck)
+}
+
+// RetranslateCard translates a card again from its source card
+// @Summary Retranslate a card
+// @Description Translate the card again from the card it was translated from, clearing its translation_stale flag. The change is recorded as a card revision.
+// @Tags Cards
+// @Produce json
+// @Param id path string true "Card ID"
+// @Security BearerAuth
+// @Success 200 {object} types.Card
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /cards/translate/{id} [post]
+func (hc *MeowController) RetranslateCard(c echo.Context) error {
+	username, err := getUserIDFromContext(c)
+	if err != nil {
+		hc.logger.Error("Failed to extract user ID from token", "error", err)
+		return c.JSON(http.StatusUnauthorized, echo.Map{"message": "unauthorized"})
+	}
+
+	card, err := hc.service.RetranslateCard(c.Request().Context(), c.Param("id"), username)
+	if err != nil {
+		switch err.Error() {
+		case "card not found":
+			return c.JSON(http.StatusNotFound, echo.Map{"message": "Card not found"})
+		case "source card not found":
+			return c.JSON(http.StatusNotFound, echo.Map{"message": "Source card not found"})
+		case "card is not a translation":
+			return c.JSON(http.StatusBadRequest, echo.Map{"message": "Card is not a translation"})
+		}
+		return hc.translateError(c, err)
+	}
+	return c.JSON(http.StatusOK, card)
+}
+
+// translateError responds to an LLM error while translating
+func (hc *MeowController) translateError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, types.ErrLLMNotInitialized):
+		return c.JSON(http.StatusServiceUnavailable, echo.Map{"message": "LLM service is not available"})
+	case errors.Is(err, types.ErrLLMQuotaExceeded):
+		return c.JSON(http.StatusTooManyRequests, echo.Map{"message": "Daily LLM quota reached"})
+	case errors.Is(err, types.ErrTranslationFailed):
+		return c.JSON(http.StatusBadGateway, echo.Map{"message": err.Error()})
+	case errors.Is(err, context.DeadlineExceeded):
+		return c.JSON(http.StatusGatewayTimeout, echo.Map{"message": "The LLM took too long to answer"})
+	}
+	hc.logger.Error("Failed to translate", "id", c.Param("id"), "error", err)
+	return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Failed to translate"})
+}
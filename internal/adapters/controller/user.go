@@ -2,6 +2,7 @@ package controller
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
@@ -105,6 +106,42 @@ func (hc *MeowController) AdminDeleteUser(c echo.Context) error {
 	return c.JSON(http.StatusOK, echo.Map{"message": "user deleted"})
 }
 
+// LLMQuotaRequest represents the payload for changing a user's daily LLM quota
+type LLMQuotaRequest struct {
+	Quota int `json:"quota"` // Requests per day, 0 is unlimited
+}
+
+// AdminSetLLMQuota changes how many LLM requests a user may make per day
+// @Summary Set a user's LLM quota
+// @Description Set how many LLM requests a user may make per local day, 0 for unlimited (admin only)
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param quota body LLMQuotaRequest true "New quota"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/users/{id}/llm-quota [put]
+func (hc *MeowController) AdminSetLLMQuota(c echo.Context) error {
+	var req LLMQuotaRequest
+	if err := c.Bind(&req); err != nil {
+		hc.logger.Error("failed to bind quota request", "error", err)
+		return c.JSON(http.StatusBadRequest, echo.Map{"message": "invalid request payload"})
+	}
+
+	if err := hc.service.SetLLMDailyQuota(c.Param("id"), req.Quota); err != nil {
+		if err.Error() == "quota must not be negative" {
+			return c.JSON(http.StatusBadRequest, echo.Map{"message": err.Error()})
+		}
+		hc.logger.Error("failed to update LLM quota", "error", err)
+		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "failed to update quota"})
+	}
+	return c.JSON(http.StatusOK, echo.Map{"message": "quota updated"})
+}
+
 // ChangePasswordRequest represents the payload for updating a user's password
 type ChangePasswordRequest struct {
 	Password string `json:"password"`
@@ -143,3 +180,120 @@ func (hc *MeowController) ChangePassword(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, echo.Map{"message": "password updated"})
 }
+
+// UserSettingsRequest represents the payload for updating a user's settings.
+// Fields left out are not changed.
+type UserSettingsRequest struct {
+	Timezone         *string `json:"timezone,omitempty"`           // IANA zone, e.g. "Europe/Oslo"
+	StreakFreezes    *int    `json:"streak_freezes,omitempty"`     // Missed days per month that keep a streak alive
+	LogRetentionDays *int    `json:"log_retention_days,omitempty"` // Days raw session logs are kept, 0 keeps them forever
+	LeechThreshold   *int    `json:"leech_threshold,omitempty"`    // Lapses that make a card a leech
+	LeechAction      *string `json:"leech_action,omitempty"`       // "flag" or "retire"
+}
+
+// UpdateUserSettings allows a logged in user to change their timezone, streak freezes, log retention and leech handling
+// @Summary Update user settings
+// @Description Update the authenticated user's timezone, monthly streak freeze allowance, session log retention and leech handling
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param settings body UserSettingsRequest true "New settings"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /user/settings [put]
+func (hc *MeowController) UpdateUserSettings(c echo.Context) error {
+	var req UserSettingsRequest
+	if err := c.Bind(&req); err != nil {
+		hc.logger.Error("failed to bind settings request", "error", err)
+		return c.JSON(http.StatusBadRequest, echo.Map{"message": "invalid request payload"})
+	}
+
+	username, err := getUserIDFromContext(c)
+	if err != nil {
+		hc.logger.Error("failed to get user from token", "error", err)
+		return c.JSON(http.StatusUnauthorized, echo.Map{"message": "unauthorized"})
+	}
+
+	settings := types.UserSettings{
+		Timezone:         req.Timezone,
+		StreakFreezes:    req.StreakFreezes,
+		LogRetentionDays: req.LogRetentionDays,
+		LeechThreshold:   req.LeechThreshold,
+		LeechAction:      req.LeechAction,
+	}
+	if err := hc.service.UpdateUserSettings(username, settings); err != nil {
+		switch err.Error() {
+		case "invalid timezone", "streak freezes must not be negative", "log retention must not be negative",
+			"leech threshold must be positive", "leech action must be flag or retire":
+			return c.JSON(http.StatusBadRequest, echo.Map{"message": err.Error()})
+		}
+		hc.logger.Error("failed to update settings", "error", err)
+		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "failed to update settings"})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"message": "settings updated"})
+}
+
+// GetActivityCalendar returns the user's daily review counts and streaks
+// @Summary Get study calendar
+// @Description Get the authenticated user's reviews, passes and fails per local day, plus current and longest streaks
+// @Tags Users
+// @Produce json
+// @Security BearerAuth
+// @Param deck_id query string false "Only count reviews of this deck"
+// @Param days query int false "Number of days to return, ending today (default 365, max 3660)"
+// @Success 200 {object} types.ActivityCalendar
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /user/activity [get]
+func (hc *MeowController) GetActivityCalendar(c echo.Context) error {
+	username, err := getUserIDFromContext(c)
+	if err != nil {
+		hc.logger.Error("failed to get user from token", "error", err)
+		return c.JSON(http.StatusUnauthorized, echo.Map{"message": "unauthorized"})
+	}
+
+	days := 365
+	if value := c.QueryParam("days"); value != "" {
+		days, err = strconv.Atoi(value)
+		if err != nil || days < 1 || days > 3660 {
+			return c.JSON(http.StatusBadRequest, echo.Map{"message": "days must be between 1 and 3660"})
+		}
+	}
+
+	calendar, err := hc.service.GetActivityCalendar(username, c.QueryParam("deck_id"), days)
+	if err != nil {
+		hc.logger.Error("failed to build activity calendar", "error", err)
+		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "failed to retrieve activity"})
+	}
+	return c.JSON(http.StatusOK, calendar)
+}
+
+// GetLLMUsage returns the user's LLM usage of today
+// @Summary Get LLM usage
+// @Description Get the authenticated user's LLM requests, cached answers and estimated tokens of their current local day, with the daily quota and what is left of it (-1 when unlimited)
+// @Tags Users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} types.LLMUsageSummary
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /user/llm-usage [get]
+func (hc *MeowController) GetLLMUsage(c echo.Context) error {
+	username, err := getUserIDFromContext(c)
+	if err != nil {
+		hc.logger.Error("failed to get user from token", "error", err)
+		return c.JSON(http.StatusUnauthorized, echo.Map{"message": "unauthorized"})
+	}
+
+	summary, err := hc.service.GetLLMUsage(username)
+	if err != nil {
+		hc.logger.Error("failed to get LLM usage", "error", err)
+		return c.JSON(http.StatusInternalServerError, echo.Map{"message": "failed to retrieve LLM usage"})
+	}
+	return c.JSON(http.StatusOK, summary)
+}
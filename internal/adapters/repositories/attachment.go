@@ -0,0 +1,156 @@
+// internal/adapters/repositories/attachment.go
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/robstave/meowmorize/internal/domain/types"
+	"gorm.io/gorm"
+)
+
+// AttachmentRepository stores attachment metadata and content.
+// Content is stored on disk, addressed by the attachment ID (its SHA-256).
+type AttachmentRepository interface {
+	// SaveAttachment records the attachment and writes its content if not already stored.
+	// The uploading user is added to the attachment's owners.
+	SaveAttachment(attachment types.Attachment, content []byte) error
+	GetAttachmentByID(id string) (*types.Attachment, error)
+	// IsAttachmentOwner reports whether the user uploaded the attachment
+	IsAttachmentOwner(id string, userID string) (bool, error)
+	// OpenAttachment opens the stored content for reading.
+	OpenAttachment(id string) (io.ReadSeekCloser, error)
+	GetAllAttachments() ([]types.Attachment, error)
+	// DeleteAttachment removes both the metadata and the stored content.
+	DeleteAttachment(id string) error
+}
+
+// AttachmentRepositorySQLite keeps metadata in SQLite and content in a local directory.
+type AttachmentRepositorySQLite struct {
+	db  *gorm.DB
+	dir string
+}
+
+// NewAttachmentRepositorySQLite creates a new attachment repository storing content under dir.
+func NewAttachmentRepositorySQLite(db *gorm.DB, dir string) (AttachmentRepository, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create attachment directory: %w", err)
+	}
+	return &AttachmentRepositorySQLite{db: db, dir: dir}, nil
+}
+
+// contentPath shards content into sub-directories by the first two characters of the ID.
+func (r *AttachmentRepositorySQLite) contentPath(id string) (string, error) {
+	if len(id) < 3 || filepath.Base(id) != id {
+		return "", fmt.Errorf("invalid attachment ID %q", id)
+	}
+	return filepath.Join(r.dir, id[:2], id), nil
+}
+
+func (r *AttachmentRepositorySQLite) SaveAttachment(attachment types.Attachment, content []byte) error {
+	path, err := r.contentPath(attachment.ID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		// Write to a temporary file first so a partial write never shows up under the final name.
+		tmp, err := os.CreateTemp(filepath.Dir(path), attachment.ID+".tmp-*")
+		if err != nil {
+			return err
+		}
+		if _, err := tmp.Write(content); err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+			return err
+		}
+		if err := tmp.Close(); err != nil {
+			os.Remove(tmp.Name())
+			return err
+		}
+		if err := os.Rename(tmp.Name(), path); err != nil {
+			os.Remove(tmp.Name())
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	// The same content may be uploaded more than once; keep the first record
+	// and add the uploader to its owners.
+	owner := types.AttachmentOwner{AttachmentID: attachment.ID, UserID: attachment.UserID, CreatedAt: attachment.CreatedAt}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ?", attachment.ID).FirstOrCreate(&attachment).Error; err != nil {
+			return err
+		}
+		return tx.Where("attachment_id = ? AND user_id = ?", owner.AttachmentID, owner.UserID).FirstOrCreate(&owner).Error
+	})
+}
+
+func (r *AttachmentRepositorySQLite) GetAttachmentByID(id string) (*types.Attachment, error) {
+	var attachment types.Attachment
+	if err := r.db.First(&attachment, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+func (r *AttachmentRepositorySQLite) IsAttachmentOwner(id string, userID string) (bool, error) {
+	var count int64
+	err := r.db.Model(&types.AttachmentOwner{}).Where("attachment_id = ? AND user_id = ?", id, userID).Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return true, nil
+	}
+
+	// Attachments uploaded before owners were tracked only record the first uploader
+	err = r.db.Model(&types.Attachment{}).Where("id = ? AND user_id = ?", id, userID).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *AttachmentRepositorySQLite) OpenAttachment(id string) (io.ReadSeekCloser, error) {
+	path, err := r.contentPath(id)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(path)
+}
+
+func (r *AttachmentRepositorySQLite) GetAllAttachments() ([]types.Attachment, error) {
+	var attachments []types.Attachment
+	if err := r.db.Find(&attachments).Error; err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+func (r *AttachmentRepositorySQLite) DeleteAttachment(id string) error {
+	path, err := r.contentPath(id)
+	if err != nil {
+		return err
+	}
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&types.AttachmentOwner{}, "attachment_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&types.Attachment{}, "id = ?", id).Error
+	})
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+// repositories/attachment_test.go
+package repositories
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	th "github.com/robstave/meowmorize/internal/adapters/repositories/repositories_test"
+	"github.com/robstave/meowmorize/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func initializeAttachmentRepository(t *testing.T) (AttachmentRepository, string) {
+	db := th.SetupTestDB(t)
+	dir := t.TempDir()
+	attachmentRepo, err := NewAttachmentRepositorySQLite(db, dir)
+	assert.NoError(t, err)
+	return attachmentRepo, dir
+}
+
+func TestAttachmentRepositorySQLite_SaveAndOpen(t *testing.T) {
+	attachmentRepo, dir := initializeAttachmentRepository(t)
+	attachment := types.Attachment{ID: "abcdef0123", UserID: "meow", ContentType: "image/png", Kind: types.ImageAttachment, Size: 5}
+
+	err := attachmentRepo.SaveAttachment(attachment, []byte("hello"))
+	assert.NoError(t, err)
+
+	// Saving the same content again is a no-op
+	err = attachmentRepo.SaveAttachment(attachment, []byte("hello"))
+	assert.NoError(t, err)
+
+	_, err = os.Stat(filepath.Join(dir, "ab", "abcdef0123"))
+	assert.NoError(t, err)
+
+	stored, err := attachmentRepo.GetAttachmentByID("abcdef0123")
+	assert.NoError(t, err)
+	assert.NotNil(t, stored)
+	assert.Equal(t, "image/png", stored.ContentType)
+
+	content, err := attachmentRepo.OpenAttachment("abcdef0123")
+	assert.NoError(t, err)
+	data, err := io.ReadAll(content)
+	content.Close()
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+}
+
+func TestAttachmentRepositorySQLite_Owners(t *testing.T) {
+	attachmentRepo, _ := initializeAttachmentRepository(t)
+	attachment := types.Attachment{ID: "abcdef0123", UserID: "meow", ContentType: "image/png", Kind: types.ImageAttachment}
+	assert.NoError(t, attachmentRepo.SaveAttachment(attachment, []byte("hello")))
+
+	// Another user uploading the same content shares the attachment
+	attachment.UserID = "purr"
+	assert.NoError(t, attachmentRepo.SaveAttachment(attachment, []byte("hello")))
+
+	for _, userID := range []string{"meow", "purr"} {
+		owner, err := attachmentRepo.IsAttachmentOwner("abcdef0123", userID)
+		assert.NoError(t, err)
+		assert.True(t, owner, userID)
+	}
+
+	owner, err := attachmentRepo.IsAttachmentOwner("abcdef0123", "hiss")
+	assert.NoError(t, err)
+	assert.False(t, owner)
+
+	stored, err := attachmentRepo.GetAttachmentByID("abcdef0123")
+	assert.NoError(t, err)
+	assert.Equal(t, "meow", stored.UserID)
+
+	assert.NoError(t, attachmentRepo.DeleteAttachment("abcdef0123"))
+	owner, err = attachmentRepo.IsAttachmentOwner("abcdef0123", "purr")
+	assert.NoError(t, err)
+	assert.False(t, owner)
+}
+
+func TestAttachmentRepositorySQLite_Delete(t *testing.T) {
+	attachmentRepo, dir := initializeAttachmentRepository(t)
+	attachment := types.Attachment{ID: "abcdef0123", UserID: "meow", ContentType: "audio/mpeg", Kind: types.AudioAttachment}
+	assert.NoError(t, attachmentRepo.SaveAttachment(attachment, []byte("sound")))
+
+	err := attachmentRepo.DeleteAttachment("abcdef0123")
+	assert.NoError(t, err)
+
+	stored, err := attachmentRepo.GetAttachmentByID("abcdef0123")
+	assert.NoError(t, err)
+	assert.Nil(t, stored)
+
+	_, err = os.Stat(filepath.Join(dir, "ab", "abcdef0123"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestAttachmentRepositorySQLite_InvalidID(t *testing.T) {
+	attachmentRepo, _ := initializeAttachmentRepository(t)
+
+	_, err := attachmentRepo.OpenAttachment("../../etc/passwd")
+	assert.Error(t, err)
+}
@@ -18,6 +18,14 @@ type CardRepository interface {
 	DeleteCardByID(cardID string) error
 	CloneCardToDeck(cardID string, targetDeckID string) (*types.Card, error)
 	CountDeckAssociations(cardID string) (int, error)
+	AddRevision(revision types.CardRevision) error
+	// GetRevisions returns the revisions of the card, newest first
+	GetRevisions(cardID string) ([]types.CardRevision, error)
+	// GetHints returns the cached hints of the card with the fingerprint, by level
+	GetHints(cardID string, fingerprint string) ([]types.CardHint, error)
+	AddHint(hint types.CardHint) error
+	// MarkTranslationsStale flags the cards translated from the card as needing re-translation
+	MarkTranslationsStale(sourceCardID string) error
 }
 
 type CardRepositorySQLite struct {
@@ -121,3 +129,31 @@ func (r *CardRepositorySQLite) CountDeckAssociations(cardID string) (int, error)
 	count := r.db.Model(&card).Association("Decks").Count()
 	return int(count), nil
 }
+
+func (r *CardRepositorySQLite) AddRevision(revision types.CardRevision) error {
+	return r.db.Create(&revision).Error
+}
+
+func (r *CardRepositorySQLite) GetRevisions(cardID string) ([]types.CardRevision, error) {
+	var revisions []types.CardRevision
+	if err := r.db.Where("card_id = ?", cardID).Order("created_at DESC").Find(&revisions).Error; err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+func (r *CardRepositorySQLite) GetHints(cardID string, fingerprint string) ([]types.CardHint, error) {
+	var hints []types.CardHint
+	if err := r.db.Where("card_id = ? AND fingerprint = ?", cardID, fingerprint).Order("level ASC").Find(&hints).Error; err != nil {
+		return nil, err
+	}
+	return hints, nil
+}
+
+func (r *CardRepositorySQLite) AddHint(hint types.CardHint) error {
+	return r.db.Create(&hint).Error
+}
+
+func (r *CardRepositorySQLite) MarkTranslationsStale(sourceCardID string) error {
+	return r.db.Model(&types.Card{}).Where("source_card_id = ?", sourceCardID).Update("translation_stale", true).Error
+}
@@ -0,0 +1,73 @@
+// internal/adapters/repositories/card_draft.go
+package repositories
+
+import (
+	"errors"
+
+	"github.com/robstave/meowmorize/internal/domain/types"
+	"gorm.io/gorm"
+)
+
+// CardDraftRepository stores the generated cards waiting for review
+type CardDraftRepository interface {
+	CreateDrafts(drafts []types.CardDraft) error
+	// GetDraftsByUser lists the user's drafts, oldest first, optionally for one deck
+	GetDraftsByUser(userID string, deckID string) ([]types.CardDraft, error)
+	// GetAllDrafts lists every user's drafts
+	GetAllDrafts() ([]types.CardDraft, error)
+	GetDraftByID(id string) (*types.CardDraft, error)
+	DeleteDraft(id string) error
+}
+
+// CardDraftRepositorySQLite implements CardDraftRepository using SQLite
+type CardDraftRepositorySQLite struct {
+	db *gorm.DB
+}
+
+// NewCardDraftRepositorySQLite creates a new card draft repository
+func NewCardDraftRepositorySQLite(db *gorm.DB) CardDraftRepository {
+	return &CardDraftRepositorySQLite{db: db}
+}
+
+func (r *CardDraftRepositorySQLite) CreateDrafts(drafts []types.CardDraft) error {
+	if len(drafts) == 0 {
+		return nil
+	}
+	return r.db.Create(&drafts).Error
+}
+
+func (r *CardDraftRepositorySQLite) GetDraftsByUser(userID string, deckID string) ([]types.CardDraft, error) {
+	query := r.db.Where("user_id = ?", userID)
+	if deckID != "" {
+		query = query.Where("deck_id = ?", deckID)
+	}
+
+	var drafts []types.CardDraft
+	if err := query.Order("created_at ASC, id ASC").Find(&drafts).Error; err != nil {
+		return nil, err
+	}
+	return drafts, nil
+}
+
+func (r *CardDraftRepositorySQLite) GetAllDrafts() ([]types.CardDraft, error) {
+	var drafts []types.CardDraft
+	if err := r.db.Find(&drafts).Error; err != nil {
+		return nil, err
+	}
+	return drafts, nil
+}
+
+func (r *CardDraftRepositorySQLite) GetDraftByID(id string) (*types.CardDraft, error) {
+	var draft types.CardDraft
+	if err := r.db.First(&draft, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &draft, nil
+}
+
+func (r *CardDraftRepositorySQLite) DeleteDraft(id string) error {
+	return r.db.Delete(&types.CardDraft{}, "id = ?", id).Error
+}
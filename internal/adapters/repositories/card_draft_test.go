@@ -0,0 +1,47 @@
+// repositories/card_draft_test.go
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	th "github.com/robstave/meowmorize/internal/adapters/repositories/repositories_test"
+	"github.com/robstave/meowmorize/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCardDraftRepositorySQLite(t *testing.T) {
+	db := th.SetupTestDB(t)
+	repo := NewCardDraftRepositorySQLite(db)
+
+	now := time.Now()
+	drafts := []types.CardDraft{
+		{ID: "d1", UserID: "meow", DeckID: "deck1", Front: "Q1", Back: "A1", CreatedAt: now},
+		{ID: "d2", UserID: "meow", DeckID: "deck2", Front: "Q2", Back: "A2", CreatedAt: now.Add(time.Second)},
+		{ID: "d3", UserID: "other", DeckID: "deck1", Front: "Q3", Back: "A3", CreatedAt: now},
+	}
+	assert.NoError(t, repo.CreateDrafts(drafts))
+
+	got, err := repo.GetDraftsByUser("meow", "")
+	assert.NoError(t, err)
+	assert.Len(t, got, 2)
+	assert.Equal(t, "d1", got[0].ID)
+
+	got, err = repo.GetDraftsByUser("meow", "deck2")
+	assert.NoError(t, err)
+	assert.Len(t, got, 1)
+	assert.Equal(t, "d2", got[0].ID)
+
+	got, err = repo.GetAllDrafts()
+	assert.NoError(t, err)
+	assert.Len(t, got, 3)
+
+	assert.NoError(t, repo.DeleteDraft("d1"))
+	draft, err := repo.GetDraftByID("d1")
+	assert.NoError(t, err)
+	assert.Nil(t, draft)
+
+	draft, err = repo.GetDraftByID("d3")
+	assert.NoError(t, err)
+	assert.Equal(t, "Q3", draft.Front)
+}
@@ -3,8 +3,10 @@ package repositories
 
 import (
 	"testing"
+	"time"
 
 	th "github.com/robstave/meowmorize/internal/adapters/repositories/repositories_test"
+	"github.com/robstave/meowmorize/internal/domain/types"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
 )
@@ -34,3 +36,53 @@ func TestCardRepositorySQLite_GetCardByID_NotFound(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Nil(t, card)
 }
+
+func TestCardRepositorySQLite_Revisions(t *testing.T) {
+	cardRepo, db := initializeCardRepository(t)
+	_, card := th.SeedTestData(t, db)
+
+	base := time.Now().Add(-time.Hour)
+	assert.NoError(t, cardRepo.AddRevision(types.CardRevision{ID: "r1", CardID: card.ID, FrontFrom: "a", FrontTo: "b", CreatedAt: base}))
+	assert.NoError(t, cardRepo.AddRevision(types.CardRevision{ID: "r2", CardID: card.ID, FrontFrom: "b", FrontTo: "c", CreatedAt: base.Add(time.Minute)}))
+	assert.NoError(t, cardRepo.AddRevision(types.CardRevision{ID: "r3", CardID: "other", CreatedAt: base}))
+
+	revisions, err := cardRepo.GetRevisions(card.ID)
+	assert.NoError(t, err)
+	if assert.Len(t, revisions, 2) {
+		assert.Equal(t, "r2", revisions[0].ID)
+		assert.Equal(t, "c", revisions[0].FrontTo)
+	}
+}
+
+func TestCardRepositorySQLite_Hints(t *testing.T) {
+	cardRepo, db := initializeCardRepository(t)
+	_, card := th.SeedTestData(t, db)
+
+	assert.NoError(t, cardRepo.AddHint(types.CardHint{ID: "h2", CardID: card.ID, Fingerprint: "f", Level: 2, Text: "closer"}))
+	assert.NoError(t, cardRepo.AddHint(types.CardHint{ID: "h1", CardID: card.ID, Fingerprint: "f", Level: 1, Text: "vague"}))
+	assert.NoError(t, cardRepo.AddHint(types.CardHint{ID: "h0", CardID: card.ID, Fingerprint: "old", Level: 1, Text: "stale"}))
+
+	hints, err := cardRepo.GetHints(card.ID, "f")
+	assert.NoError(t, err)
+	if assert.Len(t, hints, 2) {
+		assert.Equal(t, "vague", hints[0].Text)
+		assert.Equal(t, "closer", hints[1].Text)
+	}
+}
+
+func TestCardRepositorySQLite_MarkTranslationsStale(t *testing.T) {
+	cardRepo, db := initializeCardRepository(t)
+	_, card := th.SeedTestData(t, db)
+
+	assert.NoError(t, cardRepo.CreateCard(types.Card{ID: "es", SourceCardID: card.ID, Language: "Spanish"}))
+	assert.NoError(t, cardRepo.CreateCard(types.Card{ID: "other"}))
+
+	assert.NoError(t, cardRepo.MarkTranslationsStale(card.ID))
+
+	translated, err := cardRepo.GetCardByID("es")
+	assert.NoError(t, err)
+	assert.True(t, translated.TranslationStale)
+	other, err := cardRepo.GetCardByID("other")
+	assert.NoError(t, err)
+	assert.False(t, other.TranslationStale)
+}
@@ -0,0 +1,100 @@
+// internal/adapters/repositories/explanation.go
+package repositories
+
+import (
+	"errors"
+
+	"github.com/robstave/meowmorize/internal/domain/types"
+	"gorm.io/gorm"
+)
+
+// ExplanationRepository stores the explanation threads of users on cards
+type ExplanationRepository interface {
+	AddTurn(turn types.ExplanationTurn) error
+	// GetThread returns the user's turns on the card, oldest first
+	GetThread(cardID string, userID string) ([]types.ExplanationTurn, error)
+	// GetThreadsByUser summarises the user's threads, most recently active first
+	GetThreadsByUser(userID string) ([]types.ExplanationThreadSummary, error)
+	GetTurnByID(id string) (*types.ExplanationTurn, error)
+	// DeleteThread removes the user's turns on the card and returns how many there were
+	DeleteThread(cardID string, userID string) (int64, error)
+}
+
+// ExplanationRepositorySQLite implements ExplanationRepository using SQLite
+type ExplanationRepositorySQLite struct {
+	db *gorm.DB
+}
+
+// NewExplanationRepositorySQLite creates a new explanation repository
+func NewExplanationRepositorySQLite(db *gorm.DB) ExplanationRepository {
+	return &ExplanationRepositorySQLite{db: db}
+}
+
+func (r *ExplanationRepositorySQLite) AddTurn(turn types.ExplanationTurn) error {
+	return r.db.Create(&turn).Error
+}
+
+func (r *ExplanationRepositorySQLite) GetThread(cardID string, userID string) ([]types.ExplanationTurn, error) {
+	var turns []types.ExplanationTurn
+	err := r.db.Where("card_id = ? AND user_id = ?", cardID, userID).
+		Order("created_at ASC, id ASC").
+		Find(&turns).Error
+	if err != nil {
+		return nil, err
+	}
+	return turns, nil
+}
+
+func (r *ExplanationRepositorySQLite) GetThreadsByUser(userID string) ([]types.ExplanationThreadSummary, error) {
+	var rows []struct {
+		CardID    string
+		Front     string
+		Turns     int
+		UpdatedAt string
+	}
+	err := r.db.Table("explanation_turns AS e").
+		Select("e.card_id, COALESCE(c.front_text, '') AS front, COUNT(*) AS turns, MAX(e.created_at) AS updated_at").
+		Joins("LEFT JOIN cards AS c ON c.id = e.card_id").
+		Where("e.user_id = ?", userID).
+		Group("e.card_id").
+		Order("updated_at DESC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]types.ExplanationThreadSummary, len(rows))
+	for i, row := range rows {
+		summaries[i] = types.ExplanationThreadSummary{CardID: row.CardID, Front: row.Front, Turns: row.Turns}
+		// Aggregates come back as text from SQLite
+		if updatedAt, err := parseSQLiteTime(row.UpdatedAt); err == nil {
+			summaries[i].UpdatedAt = updatedAt
+		}
+
+		var last types.ExplanationTurn
+		err := r.db.Where("card_id = ? AND user_id = ?", row.CardID, userID).
+			Order("created_at DESC, id DESC").
+			First(&last).Error
+		if err != nil {
+			return nil, err
+		}
+		summaries[i].LastQuestion = last.Question
+	}
+	return summaries, nil
+}
+
+func (r *ExplanationRepositorySQLite) GetTurnByID(id string) (*types.ExplanationTurn, error) {
+	var turn types.ExplanationTurn
+	if err := r.db.First(&turn, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &turn, nil
+}
+
+func (r *ExplanationRepositorySQLite) DeleteThread(cardID string, userID string) (int64, error) {
+	result := r.db.Where("card_id = ? AND user_id = ?", cardID, userID).Delete(&types.ExplanationTurn{})
+	return result.RowsAffected, result.Error
+}
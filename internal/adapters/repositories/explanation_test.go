@@ -0,0 +1,55 @@
+// repositories/explanation_test.go
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	th "github.com/robstave/meowmorize/internal/adapters/repositories/repositories_test"
+	"github.com/robstave/meowmorize/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExplanationRepositorySQLite(t *testing.T) {
+	db := th.SetupTestDB(t)
+	_, card := th.SeedTestData(t, db)
+	repo := NewExplanationRepositorySQLite(db)
+
+	base := time.Now().Add(-time.Hour)
+	turns := []types.ExplanationTurn{
+		{ID: "t1", CardID: card.ID, UserID: "meow", Question: "Why?", Answer: "Because", CreatedAt: base},
+		{ID: "t2", CardID: card.ID, UserID: "meow", Question: "Really?", Answer: "Yes", CreatedAt: base.Add(time.Minute)},
+		{ID: "t3", CardID: "card2", UserID: "meow", Question: "How?", Answer: "Like so", CreatedAt: base.Add(2 * time.Minute)},
+		{ID: "t4", CardID: card.ID, UserID: "other", Question: "Who?", Answer: "Me", CreatedAt: base},
+	}
+	for _, turn := range turns {
+		assert.NoError(t, repo.AddTurn(turn))
+	}
+
+	thread, err := repo.GetThread(card.ID, "meow")
+	assert.NoError(t, err)
+	assert.Len(t, thread, 2)
+	assert.Equal(t, "t1", thread[0].ID)
+
+	threads, err := repo.GetThreadsByUser("meow")
+	assert.NoError(t, err)
+	assert.Len(t, threads, 2)
+	assert.Equal(t, "card2", threads[0].CardID)
+	assert.Equal(t, "", threads[0].Front) // The card no longer exists
+	assert.Equal(t, card.ID, threads[1].CardID)
+	assert.Equal(t, "Front Text", threads[1].Front)
+	assert.Equal(t, 2, threads[1].Turns)
+	assert.Equal(t, "Really?", threads[1].LastQuestion)
+	assert.WithinDuration(t, base.Add(time.Minute), threads[1].UpdatedAt, time.Second)
+
+	turn, err := repo.GetTurnByID("t4")
+	assert.NoError(t, err)
+	assert.Equal(t, "other", turn.UserID)
+
+	deleted, err := repo.DeleteThread(card.ID, "meow")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+	turn, err = repo.GetTurnByID("t1")
+	assert.NoError(t, err)
+	assert.Nil(t, turn)
+}
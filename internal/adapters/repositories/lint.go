@@ -0,0 +1,128 @@
+// internal/adapters/repositories/lint.go
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/robstave/meowmorize/internal/domain/types"
+	"gorm.io/gorm"
+)
+
+// LintRepository stores the deck lint jobs and the findings they produce
+type LintRepository interface {
+	CreateJob(job types.LintJob) error
+	UpdateJob(job types.LintJob) error
+	GetJobByID(id string) (*types.LintJob, error)
+	// GetLatestJob returns the user's most recent job on the deck, nil when there is none
+	GetLatestJob(deckID string, userID string) (*types.LintJob, error)
+	// FailRunningJobs marks the jobs left running, e.g. by a restart, as failed
+	FailRunningJobs(message string) (int64, error)
+
+	AddFinding(finding types.LintFinding) error
+	GetFindingByID(id string) (*types.LintFinding, error)
+	// GetFindingsByDeck returns the user's findings on the deck, oldest first, optionally
+	// only those with the status
+	GetFindingsByDeck(deckID string, userID string, status string) ([]types.LintFinding, error)
+	// GetPendingFindings returns every user's findings nobody acted on yet
+	GetPendingFindings() ([]types.LintFinding, error)
+	UpdateFindingStatus(id string, status string) error
+	// DeletePendingFindings removes the findings on the deck nobody acted on yet
+	DeletePendingFindings(deckID string, userID string) error
+}
+
+// LintRepositorySQLite implements LintRepository using SQLite
+type LintRepositorySQLite struct {
+	db *gorm.DB
+}
+
+// NewLintRepositorySQLite creates a new lint repository
+func NewLintRepositorySQLite(db *gorm.DB) LintRepository {
+	return &LintRepositorySQLite{db: db}
+}
+
+func (r *LintRepositorySQLite) CreateJob(job types.LintJob) error {
+	return r.db.Create(&job).Error
+}
+
+func (r *LintRepositorySQLite) UpdateJob(job types.LintJob) error {
+	return r.db.Save(&job).Error
+}
+
+func (r *LintRepositorySQLite) GetJobByID(id string) (*types.LintJob, error) {
+	var job types.LintJob
+	err := r.db.Where("id = ?", id).First(&job).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *LintRepositorySQLite) GetLatestJob(deckID string, userID string) (*types.LintJob, error) {
+	var job types.LintJob
+	err := r.db.Where("deck_id = ? AND user_id = ?", deckID, userID).
+		Order("started_at DESC").
+		First(&job).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *LintRepositorySQLite) FailRunningJobs(message string) (int64, error) {
+	result := r.db.Model(&types.LintJob{}).
+		Where("status = ?", types.LintRunning).
+		Updates(map[string]interface{}{"status": types.LintFailed, "error": message, "finished_at": time.Now()})
+	return result.RowsAffected, result.Error
+}
+
+func (r *LintRepositorySQLite) AddFinding(finding types.LintFinding) error {
+	return r.db.Create(&finding).Error
+}
+
+func (r *LintRepositorySQLite) GetFindingByID(id string) (*types.LintFinding, error) {
+	var finding types.LintFinding
+	err := r.db.Where("id = ?", id).First(&finding).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &finding, nil
+}
+
+func (r *LintRepositorySQLite) GetFindingsByDeck(deckID string, userID string, status string) ([]types.LintFinding, error) {
+	query := r.db.Where("deck_id = ? AND user_id = ?", deckID, userID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var findings []types.LintFinding
+	if err := query.Order("created_at ASC").Find(&findings).Error; err != nil {
+		return nil, err
+	}
+	return findings, nil
+}
+
+func (r *LintRepositorySQLite) GetPendingFindings() ([]types.LintFinding, error) {
+	var findings []types.LintFinding
+	if err := r.db.Where("status = ?", types.FindingPending).Find(&findings).Error; err != nil {
+		return nil, err
+	}
+	return findings, nil
+}
+
+func (r *LintRepositorySQLite) UpdateFindingStatus(id string, status string) error {
+	return r.db.Model(&types.LintFinding{}).Where("id = ?", id).Update("status", status).Error
+}
+
+func (r *LintRepositorySQLite) DeletePendingFindings(deckID string, userID string) error {
+	return r.db.Where("deck_id = ? AND user_id = ? AND status = ?", deckID, userID, types.FindingPending).
+		Delete(&types.LintFinding{}).Error
+}
@@ -0,0 +1,97 @@
+// repositories/lint_test.go
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	th "github.com/robstave/meowmorize/internal/adapters/repositories/repositories_test"
+	"github.com/robstave/meowmorize/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintRepositorySQLite_Jobs(t *testing.T) {
+	db := th.SetupTestDB(t)
+	repo := NewLintRepositorySQLite(db)
+
+	job, err := repo.GetLatestJob("deck1", "meow")
+	assert.NoError(t, err)
+	assert.Nil(t, job)
+
+	base := time.Now().Add(-time.Hour)
+	assert.NoError(t, repo.CreateJob(types.LintJob{ID: "j1", DeckID: "deck1", UserID: "meow", Status: types.LintDone, StartedAt: base}))
+	assert.NoError(t, repo.CreateJob(types.LintJob{ID: "j2", DeckID: "deck1", UserID: "meow", Status: types.LintRunning, Total: 3, StartedAt: base.Add(time.Minute)}))
+
+	job, err = repo.GetLatestJob("deck1", "meow")
+	assert.NoError(t, err)
+	if assert.NotNil(t, job) {
+		assert.Equal(t, "j2", job.ID)
+		job.Processed = 2
+		assert.NoError(t, repo.UpdateJob(*job))
+	}
+
+	job, err = repo.GetJobByID("j2")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, job.Processed)
+
+	failed, err := repo.FailRunningJobs("interrupted")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), failed)
+
+	job, err = repo.GetJobByID("j2")
+	assert.NoError(t, err)
+	assert.Equal(t, types.LintFailed, job.Status)
+	assert.Equal(t, "interrupted", job.Error)
+	assert.False(t, job.FinishedAt.IsZero())
+
+	job, err = repo.GetJobByID("missing")
+	assert.NoError(t, err)
+	assert.Nil(t, job)
+}
+
+func TestLintRepositorySQLite_Findings(t *testing.T) {
+	db := th.SetupTestDB(t)
+	repo := NewLintRepositorySQLite(db)
+
+	base := time.Now().Add(-time.Hour)
+	findings := []types.LintFinding{
+		{ID: "f1", DeckID: "deck1", CardID: "c1", UserID: "meow", Status: types.FindingPending, CreatedAt: base,
+			Issues: []types.LintIssue{{Category: types.LintAmbiguity, Message: "Which cat?"}}},
+		{ID: "f2", DeckID: "deck1", CardID: "c2", UserID: "meow", Status: types.FindingPending, CreatedAt: base.Add(time.Minute)},
+		{ID: "f3", DeckID: "deck2", CardID: "c3", UserID: "meow", Status: types.FindingPending, CreatedAt: base},
+	}
+	for _, finding := range findings {
+		assert.NoError(t, repo.AddFinding(finding))
+	}
+
+	finding, err := repo.GetFindingByID("f1")
+	assert.NoError(t, err)
+	assert.Equal(t, []types.LintIssue{{Category: types.LintAmbiguity, Message: "Which cat?"}}, finding.Issues)
+
+	assert.NoError(t, repo.UpdateFindingStatus("f1", types.FindingAccepted))
+
+	deckFindings, err := repo.GetFindingsByDeck("deck1", "meow", "")
+	assert.NoError(t, err)
+	assert.Len(t, deckFindings, 2)
+
+	pending, err := repo.GetPendingFindings()
+	assert.NoError(t, err)
+	assert.Len(t, pending, 2)
+
+	deckFindings, err = repo.GetFindingsByDeck("deck1", "meow", types.FindingPending)
+	assert.NoError(t, err)
+	if assert.Len(t, deckFindings, 1) {
+		assert.Equal(t, "f2", deckFindings[0].ID)
+	}
+
+	// Only the pending findings of the deck go
+	assert.NoError(t, repo.DeletePendingFindings("deck1", "meow"))
+	deckFindings, err = repo.GetFindingsByDeck("deck1", "meow", "")
+	assert.NoError(t, err)
+	if assert.Len(t, deckFindings, 1) {
+		assert.Equal(t, "f1", deckFindings[0].ID)
+	}
+	deckFindings, err = repo.GetFindingsByDeck("deck2", "meow", "")
+	assert.NoError(t, err)
+	assert.Len(t, deckFindings, 1)
+}
@@ -3,6 +3,8 @@ package repositories
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	types "github.com/robstave/meowmorize/internal/domain/types"
 
@@ -10,24 +12,112 @@ import (
 	"github.com/tmc/langchaingo/llms/googleai"
 )
 
+// LLM providers that can be selected by configuration
+const (
+	LLMProviderGoogleAI = "googleai"
+	LLMProviderOpenAI   = "openai"
+	LLMProviderOllama   = "ollama"
+)
+
 // LLMRepository defines the interface for LLM interactions
 type LLMRepository interface {
 	RunPrompt(ctx context.Context, prompt string) (string, error)
+	// StreamPrompt calls onChunk with each piece of the response as it arrives and returns
+	// the whole response. An error from onChunk stops the stream.
+	StreamPrompt(ctx context.Context, prompt string, onChunk func(chunk string) error) (string, error)
+	// Available reports whether prompts can be sent, without sending one
+	Available() bool
+	Provider() string
+	Model() string
+}
+
+// LLMConfig selects and configures the LLM provider. Empty fields fall back to the
+// provider's defaults.
+type LLMConfig struct {
+	Provider string
+	Model    string
+	BaseURL  string
+	APIKey   string
+	Timeout  time.Duration // Bounds every prompt, defaultLLMTimeout when zero
+}
+
+// defaultLLMTimeout bounds a prompt when no timeout is configured
+const defaultLLMTimeout = 2 * time.Minute
+
+// llmProviders is the registry of the LLM backends by provider name
+var llmProviders = map[string]func(config LLMConfig) (LLMRepository, error){
+	LLMProviderGoogleAI: func(config LLMConfig) (LLMRepository, error) {
+		return NewLLMRepositoryLangChain(config.APIKey, config.Model)
+	},
+	LLMProviderOpenAI: func(config LLMConfig) (LLMRepository, error) {
+		return NewLLMRepositoryOpenAI(config.BaseURL, config.APIKey, config.Model), nil
+	},
+	LLMProviderOllama: func(config LLMConfig) (LLMRepository, error) {
+		return NewLLMRepositoryOllama(config.BaseURL, config.Model), nil
+	},
+}
+
+// NewLLMRepository creates the LLM repository of the configured provider, Google AI by default.
+// Every prompt is bounded by the configured timeout on top of the caller's context.
+func NewLLMRepository(config LLMConfig) (LLMRepository, error) {
+	if config.Provider == "" {
+		config.Provider = LLMProviderGoogleAI
+	}
+	newRepository, ok := llmProviders[config.Provider]
+	if !ok {
+		return nil, fmt.Errorf("unknown LLM provider %q", config.Provider)
+	}
+	repo, err := newRepository(config)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = defaultLLMTimeout
+	}
+	return &timeoutLLMRepository{LLMRepository: repo, timeout: timeout}, nil
+}
+
+// timeoutLLMRepository bounds every prompt of the wrapped repository
+type timeoutLLMRepository struct {
+	LLMRepository
+	timeout time.Duration
+}
+
+func (r *timeoutLLMRepository) RunPrompt(ctx context.Context, prompt string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.LLMRepository.RunPrompt(ctx, prompt)
+}
+
+func (r *timeoutLLMRepository) StreamPrompt(ctx context.Context, prompt string, onChunk func(chunk string) error) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.LLMRepository.StreamPrompt(ctx, prompt, onChunk)
 }
 
 // LLMRepositoryLangChain implements LLMRepository using LangChain
 type LLMRepositoryLangChain struct {
 	llm     llms.LLM
+	model   string
 	enabled bool
 }
 
+// defaultGoogleAIModel is used when no Google AI model is configured
+const defaultGoogleAIModel = "gemini-pro"
+
 // NewLLMRepositoryLangChain creates a new LLM repository instance
 func NewLLMRepositoryLangChain(apiKey string, model string) (LLMRepository, error) {
+	if model == "" {
+		model = defaultGoogleAIModel
+	}
 
 	if apiKey == "" {
 		// Return a disabled repository instead of an error
 		return &LLMRepositoryLangChain{
 			llm:     nil,
+			model:   model,
 			enabled: false,
 		}, nil
 	}
@@ -42,6 +132,7 @@ func NewLLMRepositoryLangChain(apiKey string, model string) (LLMRepository, erro
 
 	return &LLMRepositoryLangChain{
 		llm:     llm,
+		model:   model,
 		enabled: true,
 	}, nil
 }
@@ -54,3 +145,29 @@ func (r *LLMRepositoryLangChain) RunPrompt(ctx context.Context, prompt string) (
 	}
 	return llms.GenerateFromSinglePrompt(ctx, r.llm, prompt)
 }
+
+// StreamPrompt sends a prompt to the LLM and passes the response on as it is generated
+func (r *LLMRepositoryLangChain) StreamPrompt(ctx context.Context, prompt string, onChunk func(chunk string) error) (string, error) {
+	if !r.enabled {
+		return "", types.ErrLLMNotInitialized
+	}
+	return llms.GenerateFromSinglePrompt(ctx, r.llm, prompt,
+		llms.WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
+			return onChunk(string(chunk))
+		}))
+}
+
+// Available reports whether an API key is configured
+func (r *LLMRepositoryLangChain) Available() bool {
+	return r.enabled
+}
+
+// Provider returns the name of the LLM provider
+func (r *LLMRepositoryLangChain) Provider() string {
+	return LLMProviderGoogleAI
+}
+
+// Model returns the model prompts are sent to
+func (r *LLMRepositoryLangChain) Model() string {
+	return r.model
+}
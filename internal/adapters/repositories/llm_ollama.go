@@ -0,0 +1,146 @@
+// internal/adapters/repositories/llm_ollama.go
+package repositories
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Ollama defaults
+const (
+	defaultOllamaBaseURL = "http://localhost:11434"
+	defaultOllamaModel   = "llama3"
+)
+
+// LLMRepositoryOllama implements LLMRepository against the Ollama generate API
+type LLMRepositoryOllama struct {
+	client  *http.Client
+	baseURL string
+	model   string
+}
+
+// NewLLMRepositoryOllama creates an LLM repository for an Ollama server
+func NewLLMRepositoryOllama(baseURL string, model string) LLMRepository {
+	if baseURL == "" {
+		baseURL = defaultOllamaBaseURL
+	}
+	if model == "" {
+		model = defaultOllamaModel
+	}
+	return &LLMRepositoryOllama{
+		client:  http.DefaultClient,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		model:   model,
+	}
+}
+
+type ollamaGenerateRequest struct {
+	Model  string `json:"model"`
+	Prompt string `json:"prompt"`
+	Stream bool   `json:"stream"`
+}
+
+type ollamaGenerateResponse struct {
+	Response string `json:"response"`
+	Done     bool   `json:"done"`
+	Error    string `json:"error"`
+}
+
+// RunPrompt sends the prompt and waits for the whole response
+func (r *LLMRepositoryOllama) RunPrompt(ctx context.Context, prompt string) (string, error) {
+	resp, err := r.generate(ctx, prompt, false)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var generated ollamaGenerateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&generated); err != nil {
+		return "", fmt.Errorf("ollama: decoding response: %w", err)
+	}
+	return generated.Response, nil
+}
+
+// StreamPrompt sends the prompt and reads the response objects as they are generated
+func (r *LLMRepositoryOllama) StreamPrompt(ctx context.Context, prompt string, onChunk func(chunk string) error) (string, error) {
+	resp, err := r.generate(ctx, prompt, true)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var response strings.Builder
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var generated ollamaGenerateResponse
+		if err := decoder.Decode(&generated); err != nil {
+			if errors.Is(err, io.EOF) {
+				return response.String(), nil
+			}
+			return "", fmt.Errorf("ollama: decoding stream: %w", err)
+		}
+		if generated.Error != "" {
+			return "", fmt.Errorf("ollama: %s", generated.Error)
+		}
+
+		if generated.Response != "" {
+			response.WriteString(generated.Response)
+			if err := onChunk(generated.Response); err != nil {
+				return "", err
+			}
+		}
+		if generated.Done {
+			return response.String(), nil
+		}
+	}
+}
+
+// generate posts the prompt and returns the response once its status is OK
+func (r *LLMRepositoryOllama) generate(ctx context.Context, prompt string, stream bool) (*http.Response, error) {
+	body, err := json.Marshal(ollamaGenerateRequest{Model: r.model, Prompt: prompt, Stream: stream})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.baseURL+"/api/generate", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		var generated ollamaGenerateResponse
+		if err := json.NewDecoder(resp.Body).Decode(&generated); err == nil && generated.Error != "" {
+			return nil, fmt.Errorf("ollama: %s: %s", resp.Status, generated.Error)
+		}
+		return nil, fmt.Errorf("ollama: %s", resp.Status)
+	}
+	return resp, nil
+}
+
+// Available always reports true since Ollama needs no credentials. Whether the server is
+// up only shows once a prompt is sent.
+func (r *LLMRepositoryOllama) Available() bool {
+	return true
+}
+
+// Provider returns the name of the LLM provider
+func (r *LLMRepositoryOllama) Provider() string {
+	return LLMProviderOllama
+}
+
+// Model returns the model prompts are sent to
+func (r *LLMRepositoryOllama) Model() string {
+	return r.model
+}
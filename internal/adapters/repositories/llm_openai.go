@@ -0,0 +1,186 @@
+// internal/adapters/repositories/llm_openai.go
+package repositories
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	types "github.com/robstave/meowmorize/internal/domain/types"
+)
+
+// OpenAI defaults; local servers such as llama.cpp or vLLM are reached by pointing the
+// base URL at them, e.g. http://localhost:8080/v1
+const (
+	defaultOpenAIBaseURL = "https://api.openai.com/v1"
+	defaultOpenAIModel   = "gpt-4o-mini"
+)
+
+// LLMRepositoryOpenAI implements LLMRepository against an OpenAI-compatible chat completions API
+type LLMRepositoryOpenAI struct {
+	client  *http.Client
+	baseURL string
+	apiKey  string
+	model   string
+}
+
+// NewLLMRepositoryOpenAI creates an LLM repository for an OpenAI-compatible server. The API
+// key may be empty for local servers that do not check it.
+func NewLLMRepositoryOpenAI(baseURL string, apiKey string, model string) LLMRepository {
+	if baseURL == "" {
+		baseURL = defaultOpenAIBaseURL
+	}
+	if model == "" {
+		model = defaultOpenAIModel
+	}
+	return &LLMRepositoryOpenAI{
+		client:  http.DefaultClient,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		model:   model,
+	}
+}
+
+type openAIMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type openAIChatRequest struct {
+	Model    string          `json:"model"`
+	Messages []openAIMessage `json:"messages"`
+	Stream   bool            `json:"stream,omitempty"`
+}
+
+type openAIChatResponse struct {
+	Choices []struct {
+		Message openAIMessage `json:"message"`
+		Delta   openAIMessage `json:"delta"` // Set instead of Message when streaming
+	} `json:"choices"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// RunPrompt sends the prompt as a single user message and returns the first choice
+func (r *LLMRepositoryOpenAI) RunPrompt(ctx context.Context, prompt string) (string, error) {
+	resp, err := r.chatCompletions(ctx, prompt, false)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var completion openAIChatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&completion); err != nil {
+		return "", fmt.Errorf("openai: decoding response: %w", err)
+	}
+	if len(completion.Choices) == 0 {
+		return "", errors.New("openai: no choices in response")
+	}
+	return completion.Choices[0].Message.Content, nil
+}
+
+// StreamPrompt sends the prompt as a single user message and reads the server-sent
+// events of the first choice as they arrive
+func (r *LLMRepositoryOpenAI) StreamPrompt(ctx context.Context, prompt string, onChunk func(chunk string) error) (string, error) {
+	resp, err := r.chatCompletions(ctx, prompt, true)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var response strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64<<10), 1<<20)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data:")
+		if !ok {
+			continue // Blank separators, comments and other fields
+		}
+		data = strings.TrimSpace(data)
+		if data == "[DONE]" {
+			return response.String(), nil
+		}
+
+		var event openAIChatResponse
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			return "", fmt.Errorf("openai: decoding stream: %w", err)
+		}
+		if event.Error != nil {
+			return "", fmt.Errorf("openai: %s", event.Error.Message)
+		}
+		if len(event.Choices) == 0 || event.Choices[0].Delta.Content == "" {
+			continue
+		}
+
+		chunk := event.Choices[0].Delta.Content
+		response.WriteString(chunk)
+		if err := onChunk(chunk); err != nil {
+			return "", err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return response.String(), nil
+}
+
+// chatCompletions posts the prompt and returns the response once its status is OK
+func (r *LLMRepositoryOpenAI) chatCompletions(ctx context.Context, prompt string, stream bool) (*http.Response, error) {
+	if !r.Available() {
+		return nil, types.ErrLLMNotInitialized
+	}
+
+	body, err := json.Marshal(openAIChatRequest{
+		Model:    r.model,
+		Messages: []openAIMessage{{Role: "user", Content: prompt}},
+		Stream:   stream,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.baseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if r.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+r.apiKey)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		var completion openAIChatResponse
+		if err := json.NewDecoder(resp.Body).Decode(&completion); err == nil && completion.Error != nil && completion.Error.Message != "" {
+			return nil, fmt.Errorf("openai: %s: %s", resp.Status, completion.Error.Message)
+		}
+		return nil, fmt.Errorf("openai: %s", resp.Status)
+	}
+	return resp, nil
+}
+
+// Available reports whether the server can be used: the OpenAI API itself needs a key,
+// other OpenAI-compatible servers are assumed to be reachable
+func (r *LLMRepositoryOpenAI) Available() bool {
+	return r.baseURL != defaultOpenAIBaseURL || r.apiKey != ""
+}
+
+// Provider returns the name of the LLM provider
+func (r *LLMRepositoryOpenAI) Provider() string {
+	return LLMProviderOpenAI
+}
+
+// Model returns the model prompts are sent to
+func (r *LLMRepositoryOpenAI) Model() string {
+	return r.model
+}
@@ -0,0 +1,174 @@
+// repositories/llm_test.go
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/robstave/meowmorize/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLLMRepository(t *testing.T) {
+	repo, err := NewLLMRepository(LLMConfig{})
+	assert.NoError(t, err)
+	assert.Equal(t, LLMProviderGoogleAI, repo.Provider())
+	assert.Equal(t, defaultGoogleAIModel, repo.Model())
+
+	// Without an API key Google AI is disabled
+	assert.False(t, repo.Available())
+	_, err = repo.RunPrompt(context.Background(), "test")
+	assert.Equal(t, types.ErrLLMNotInitialized, err)
+
+	repo, err = NewLLMRepository(LLMConfig{Provider: LLMProviderOllama, Model: "mistral"})
+	assert.NoError(t, err)
+	assert.Equal(t, LLMProviderOllama, repo.Provider())
+	assert.Equal(t, "mistral", repo.Model())
+	assert.True(t, repo.Available())
+
+	_, err = NewLLMRepository(LLMConfig{Provider: "nope"})
+	assert.Error(t, err)
+}
+
+func TestLLMRepositoryOpenAI_RunPrompt(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/chat/completions", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+
+		var req openAIChatRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "local-model", req.Model)
+		assert.Equal(t, []openAIMessage{{Role: "user", Content: "explain"}}, req.Messages)
+
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"because"}}]}`))
+	}))
+	defer server.Close()
+
+	repo := NewLLMRepositoryOpenAI(server.URL+"/v1/", "secret", "local-model")
+	resp, err := repo.RunPrompt(context.Background(), "explain")
+	assert.NoError(t, err)
+	assert.Equal(t, "because", resp)
+}
+
+func TestLLMRepositoryOpenAI_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":{"message":"bad key"}}`))
+	}))
+	defer server.Close()
+
+	repo := NewLLMRepositoryOpenAI(server.URL, "", "")
+	assert.Equal(t, defaultOpenAIModel, repo.Model())
+	_, err := repo.RunPrompt(context.Background(), "explain")
+	assert.ErrorContains(t, err, "bad key")
+
+	// The hosted API is disabled without a key
+	_, err = NewLLMRepositoryOpenAI("", "", "").RunPrompt(context.Background(), "explain")
+	assert.Equal(t, types.ErrLLMNotInitialized, err)
+}
+
+func TestLLMRepositoryOllama_RunPrompt(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/generate", r.URL.Path)
+
+		var req ollamaGenerateRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, ollamaGenerateRequest{Model: defaultOllamaModel, Prompt: "explain"}, req)
+
+		w.Write([]byte(`{"response":"because","done":true}`))
+	}))
+	defer server.Close()
+
+	repo := NewLLMRepositoryOllama(server.URL, "")
+	resp, err := repo.RunPrompt(context.Background(), "explain")
+	assert.NoError(t, err)
+	assert.Equal(t, "because", resp)
+}
+
+func TestLLMRepositoryOllama_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":"model \"llama3\" not found"}`))
+	}))
+	defer server.Close()
+
+	_, err := NewLLMRepositoryOllama(server.URL, "").RunPrompt(context.Background(), "explain")
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestLLMRepositoryOpenAI_StreamPrompt(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req openAIChatRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.True(t, req.Stream)
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write([]byte(": ping\n\n"))
+		w.Write([]byte(`data: {"choices":[{"delta":{"role":"assistant"}}]}` + "\n\n"))
+		w.Write([]byte(`data: {"choices":[{"delta":{"content":"be"}}]}` + "\n\n"))
+		w.Write([]byte(`data: {"choices":[{"delta":{"content":"cause"}}]}` + "\n\n"))
+		w.Write([]byte("data: [DONE]\n\n"))
+	}))
+	defer server.Close()
+
+	var chunks []string
+	resp, err := NewLLMRepositoryOpenAI(server.URL, "", "").StreamPrompt(context.Background(), "explain", func(chunk string) error {
+		chunks = append(chunks, chunk)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "because", resp)
+	assert.Equal(t, []string{"be", "cause"}, chunks)
+}
+
+func TestLLMRepositoryOllama_StreamPrompt(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ollamaGenerateRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.True(t, req.Stream)
+
+		w.Write([]byte(`{"response":"be","done":false}` + "\n"))
+		w.Write([]byte(`{"response":"cause","done":false}` + "\n"))
+		w.Write([]byte(`{"response":"","done":true}` + "\n"))
+	}))
+	defer server.Close()
+
+	var chunks []string
+	resp, err := NewLLMRepositoryOllama(server.URL, "").StreamPrompt(context.Background(), "explain", func(chunk string) error {
+		chunks = append(chunks, chunk)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "because", resp)
+	assert.Equal(t, []string{"be", "cause"}, chunks)
+
+	// An error from the callback stops the stream
+	stop := errors.New("client went away")
+	_, err = NewLLMRepositoryOllama(server.URL, "").StreamPrompt(context.Background(), "explain", func(string) error { return stop })
+	assert.ErrorIs(t, err, stop)
+}
+
+func TestNewLLMRepository_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	repo, err := NewLLMRepository(LLMConfig{Provider: LLMProviderOllama, BaseURL: server.URL, Timeout: 50 * time.Millisecond})
+	assert.NoError(t, err)
+
+	_, err = repo.RunPrompt(context.Background(), "explain")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	_, err = repo.StreamPrompt(context.Background(), "explain", func(string) error { return nil })
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,77 @@
+// internal/adapters/repositories/llm_usage.go
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/robstave/meowmorize/internal/domain/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LLMUsageRepository keeps the ledger of LLM requests and the cache of LLM responses
+type LLMUsageRepository interface {
+	RecordUsage(usage types.LLMUsage) error
+	// GetUsageByDay returns the user's ledger entries of a local day, oldest first
+	GetUsageByDay(userID string, day string) ([]types.LLMUsage, error)
+	// CountRequests counts the user's requests of a local day that were sent to the LLM
+	CountRequests(userID string, day string) (int64, error)
+	// GetCachedResponse returns the response cached for the hash since the given time,
+	// nil when there is none
+	GetCachedResponse(hash string, since time.Time) (*types.LLMCacheEntry, error)
+	// CacheResponse stores the response, replacing an older one for the same hash
+	CacheResponse(entry types.LLMCacheEntry) error
+}
+
+// LLMUsageRepositorySQLite implements LLMUsageRepository using SQLite
+type LLMUsageRepositorySQLite struct {
+	db *gorm.DB
+}
+
+// NewLLMUsageRepositorySQLite creates a new LLM usage repository
+func NewLLMUsageRepositorySQLite(db *gorm.DB) LLMUsageRepository {
+	return &LLMUsageRepositorySQLite{db: db}
+}
+
+func (r *LLMUsageRepositorySQLite) RecordUsage(usage types.LLMUsage) error {
+	return r.db.Create(&usage).Error
+}
+
+func (r *LLMUsageRepositorySQLite) GetUsageByDay(userID string, day string) ([]types.LLMUsage, error) {
+	var usage []types.LLMUsage
+	err := r.db.Where("user_id = ? AND day = ?", userID, day).
+		Order("created_at ASC").
+		Find(&usage).Error
+	if err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+func (r *LLMUsageRepositorySQLite) CountRequests(userID string, day string) (int64, error) {
+	var count int64
+	err := r.db.Model(&types.LLMUsage{}).
+		Where("user_id = ? AND day = ? AND cached = ?", userID, day, false).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *LLMUsageRepositorySQLite) GetCachedResponse(hash string, since time.Time) (*types.LLMCacheEntry, error) {
+	var entry types.LLMCacheEntry
+	err := r.db.Where("hash = ? AND created_at >= ?", hash, since).First(&entry).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *LLMUsageRepositorySQLite) CacheResponse(entry types.LLMCacheEntry) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "hash"}},
+		DoUpdates: clause.AssignmentColumns([]string{"provider", "model", "response", "created_at"}),
+	}).Create(&entry).Error
+}
@@ -0,0 +1,62 @@
+// repositories/llm_usage_test.go
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	th "github.com/robstave/meowmorize/internal/adapters/repositories/repositories_test"
+	"github.com/robstave/meowmorize/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLLMUsageRepositorySQLite_Ledger(t *testing.T) {
+	db := th.SetupTestDB(t)
+	repo := NewLLMUsageRepositorySQLite(db)
+
+	now := time.Now()
+	entries := []types.LLMUsage{
+		{ID: "u1", UserID: "meow", Day: "2024-05-01", Purpose: types.LLMPurposeExplain, PromptChars: 40, CreatedAt: now},
+		{ID: "u2", UserID: "meow", Day: "2024-05-01", Purpose: types.LLMPurposeExplain, Cached: true, CreatedAt: now.Add(time.Second)},
+		{ID: "u3", UserID: "meow", Day: "2024-05-01", Purpose: types.LLMPurposeGrade, Failed: true, CreatedAt: now.Add(2 * time.Second)},
+		{ID: "u4", UserID: "meow", Day: "2024-04-30", Purpose: types.LLMPurposeGrade, CreatedAt: now},
+		{ID: "u5", UserID: "other", Day: "2024-05-01", Purpose: types.LLMPurposeGenerate, CreatedAt: now},
+	}
+	for _, entry := range entries {
+		assert.NoError(t, repo.RecordUsage(entry))
+	}
+
+	usage, err := repo.GetUsageByDay("meow", "2024-05-01")
+	assert.NoError(t, err)
+	if assert.Len(t, usage, 3) {
+		assert.Equal(t, "u1", usage[0].ID)
+		assert.Equal(t, 40, usage[0].PromptChars)
+	}
+
+	// Cached responses do not count, failed requests do
+	count, err := repo.CountRequests("meow", "2024-05-01")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}
+
+func TestLLMUsageRepositorySQLite_Cache(t *testing.T) {
+	db := th.SetupTestDB(t)
+	repo := NewLLMUsageRepositorySQLite(db)
+
+	now := time.Now()
+	entry, err := repo.GetCachedResponse("abc", now.Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Nil(t, entry)
+
+	assert.NoError(t, repo.CacheResponse(types.LLMCacheEntry{Hash: "abc", Response: "old", CreatedAt: now.Add(-2 * time.Hour)}))
+	entry, err = repo.GetCachedResponse("abc", now.Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Nil(t, entry) // Expired
+
+	assert.NoError(t, repo.CacheResponse(types.LLMCacheEntry{Hash: "abc", Response: "new", CreatedAt: now}))
+	entry, err = repo.GetCachedResponse("abc", now.Add(-time.Hour))
+	assert.NoError(t, err)
+	if assert.NotNil(t, entry) {
+		assert.Equal(t, "new", entry.Response)
+	}
+}
@@ -13,6 +13,34 @@ type CardRepository struct {
 	mock.Mock
 }
 
+// AddHint provides a mock function with given fields: hint
+func (_m *CardRepository) AddHint(hint types.CardHint) error {
+	ret := _m.Called(hint)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(types.CardHint) error); ok {
+		r0 = rf(hint)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddRevision provides a mock function with given fields: revision
+func (_m *CardRepository) AddRevision(revision types.CardRevision) error {
+	ret := _m.Called(revision)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(types.CardRevision) error); ok {
+		r0 = rf(revision)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CloneCardToDeck provides a mock function with given fields: cardID, targetDeckID
 func (_m *CardRepository) CloneCardToDeck(cardID string, targetDeckID string) (*types.Card, error) {
 	ret := _m.Called(cardID, targetDeckID)
@@ -131,6 +159,66 @@ func (_m *CardRepository) GetCardsByDeckID(deckID string) ([]types.Card, error)
 	return r0, r1
 }
 
+// GetHints provides a mock function with given fields: cardID, fingerprint
+func (_m *CardRepository) GetHints(cardID string, fingerprint string) ([]types.CardHint, error) {
+	ret := _m.Called(cardID, fingerprint)
+
+	var r0 []types.CardHint
+	if rf, ok := ret.Get(0).(func(string, string) []types.CardHint); ok {
+		r0 = rf(cardID, fingerprint)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.CardHint)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(cardID, fingerprint)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRevisions provides a mock function with given fields: cardID
+func (_m *CardRepository) GetRevisions(cardID string) ([]types.CardRevision, error) {
+	ret := _m.Called(cardID)
+
+	var r0 []types.CardRevision
+	if rf, ok := ret.Get(0).(func(string) []types.CardRevision); ok {
+		r0 = rf(cardID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.CardRevision)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(cardID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkTranslationsStale provides a mock function with given fields: sourceCardID
+func (_m *CardRepository) MarkTranslationsStale(sourceCardID string) error {
+	ret := _m.Called(sourceCardID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(sourceCardID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateCard provides a mock function with given fields: card
 func (_m *CardRepository) UpdateCard(card types.Card) error {
 	ret := _m.Called(card)
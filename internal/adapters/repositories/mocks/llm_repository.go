@@ -15,3 +15,23 @@ func (m *LLMRepository) RunPrompt(ctx context.Context, prompt string) (string, e
 	args := m.Called(ctx, prompt)
 	return args.String(0), args.Error(1)
 }
+
+func (m *LLMRepository) Available() bool {
+	args := m.Called()
+	return args.Bool(0)
+}
+
+func (m *LLMRepository) Provider() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func (m *LLMRepository) Model() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func (m *LLMRepository) StreamPrompt(ctx context.Context, prompt string, onChunk func(chunk string) error) (string, error) {
+	args := m.Called(ctx, prompt, onChunk)
+	return args.String(0), args.Error(1)
+}
@@ -5,6 +5,8 @@ package mocks
 import (
 	mock "github.com/stretchr/testify/mock"
 
+	time "time"
+
 	types "github.com/robstave/meowmorize/internal/domain/types"
 )
 
@@ -13,6 +15,48 @@ type SessionLogRepository struct {
 	mock.Mock
 }
 
+// BackfillRollups provides a mock function with given fields:
+func (_m *SessionLogRepository) BackfillRollups() (int64, error) {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountLapses provides a mock function with given fields: cardID, since
+func (_m *SessionLogRepository) CountLapses(cardID string, since time.Time) (int64, error) {
+	ret := _m.Called(cardID, since)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string, time.Time) int64); ok {
+		r0 = rf(cardID, since)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, time.Time) error); ok {
+		r1 = rf(cardID, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateLog provides a mock function with given fields: log
 func (_m *SessionLogRepository) CreateLog(log types.SessionLog) error {
 	ret := _m.Called(log)
@@ -27,16 +71,16 @@ func (_m *SessionLogRepository) CreateLog(log types.SessionLog) error {
 	return r0
 }
 
-// GetSessionLogIdsByUser provides a mock function with given fields: userID, deckID
-func (_m *SessionLogRepository) GetSessionLogIdsByUser(userID string, deckID string) ([]string, error) {
+// GetDailyRollups provides a mock function with given fields: userID, deckID
+func (_m *SessionLogRepository) GetDailyRollups(userID string, deckID string) ([]types.DailyRollup, error) {
 	ret := _m.Called(userID, deckID)
 
-	var r0 []string
-	if rf, ok := ret.Get(0).(func(string, string) []string); ok {
+	var r0 []types.DailyRollup
+	if rf, ok := ret.Get(0).(func(string, string) []types.DailyRollup); ok {
 		r0 = rf(userID, deckID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
+			r0 = ret.Get(0).([]types.DailyRollup)
 		}
 	}
 
@@ -50,6 +94,59 @@ func (_m *SessionLogRepository) GetSessionLogIdsByUser(userID string, deckID str
 	return r0, r1
 }
 
+// GetRecentResponseTimes provides a mock function with given fields: cardID, limit
+func (_m *SessionLogRepository) GetRecentResponseTimes(cardID string, limit int) ([]int64, error) {
+	ret := _m.Called(cardID, limit)
+
+	var r0 []int64
+	if rf, ok := ret.Get(0).(func(string, int) []int64); ok {
+		r0 = rf(cardID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(cardID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSessionLogIdsByUser provides a mock function with given fields: userID, query
+func (_m *SessionLogRepository) GetSessionLogIdsByUser(userID string, query types.SessionHistoryQuery) ([]string, int64, error) {
+	ret := _m.Called(userID, query)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string, types.SessionHistoryQuery) []string); ok {
+		r0 = rf(userID, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(string, types.SessionHistoryQuery) int64); ok {
+		r1 = rf(userID, query)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, types.SessionHistoryQuery) error); ok {
+		r2 = rf(userID, query)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetSessionLogsBySessionID provides a mock function with given fields: sessionID
 func (_m *SessionLogRepository) GetSessionLogsBySessionID(sessionID string) ([]types.SessionLog, error) {
 	ret := _m.Called(sessionID)
@@ -73,13 +170,13 @@ func (_m *SessionLogRepository) GetSessionLogsBySessionID(sessionID string) ([]t
 	return r0, r1
 }
 
-// PruneLogs provides a mock function with given fields: maxRows
-func (_m *SessionLogRepository) PruneLogs(maxRows int) error {
-	ret := _m.Called(maxRows)
+// MarkUndone provides a mock function with given fields: logID
+func (_m *SessionLogRepository) MarkUndone(logID string) error {
+	ret := _m.Called(logID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int) error); ok {
-		r0 = rf(maxRows)
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(logID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -87,6 +184,27 @@ func (_m *SessionLogRepository) PruneLogs(maxRows int) error {
 	return r0
 }
 
+// PruneLogs provides a mock function with given fields: userID, before
+func (_m *SessionLogRepository) PruneLogs(userID string, before time.Time) (int64, error) {
+	ret := _m.Called(userID, before)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string, time.Time) int64); ok {
+		r0 = rf(userID, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, time.Time) error); ok {
+		r1 = rf(userID, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewSessionLogRepository interface {
 	mock.TestingT
 	Cleanup(func())
@@ -87,6 +87,20 @@ func (_m *UserRepository) GetUserByUsername(username string) (*types.User, error
 	return r0, r1
 }
 
+// UpdateUserLLMQuota provides a mock function with given fields: userID, quota
+func (_m *UserRepository) UpdateUserLLMQuota(userID string, quota int) error {
+	ret := _m.Called(userID, quota)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int) error); ok {
+		r0 = rf(userID, quota)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateUserPassword provides a mock function with given fields: userID, password
 func (_m *UserRepository) UpdateUserPassword(userID string, password string) error {
 	ret := _m.Called(userID, password)
@@ -101,6 +115,20 @@ func (_m *UserRepository) UpdateUserPassword(userID string, password string) err
 	return r0
 }
 
+// UpdateUserSettings provides a mock function with given fields: username, settings
+func (_m *UserRepository) UpdateUserSettings(username string, settings types.UserSettings) error {
+	ret := _m.Called(username, settings)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, types.UserSettings) error); ok {
+		r0 = rf(username, settings)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewUserRepository interface {
 	mock.TestingT
 	Cleanup(func())
@@ -0,0 +1,69 @@
+// internal/adapters/repositories/prompt_template.go
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/robstave/meowmorize/internal/domain/types"
+	"gorm.io/gorm"
+)
+
+// PromptTemplateRepository stores the versions of the admin default and user prompt templates.
+// The admin defaults have an empty user ID.
+type PromptTemplateRepository interface {
+	CreateTemplate(template types.PromptTemplate) error
+	// GetLatestTemplate returns the newest version of the user's template of the kind that
+	// has not been reset, nil when there is none
+	GetLatestTemplate(userID string, kind string) (*types.PromptTemplate, error)
+	// GetLatestVersion returns the highest version of the user's template of the kind,
+	// including reset ones, 0 when there is none
+	GetLatestVersion(userID string, kind string) (int, error)
+	// ResetTemplates marks the versions of the user's template of the kind as reset and
+	// returns how many were still in use
+	ResetTemplates(userID string, kind string) (int64, error)
+}
+
+// PromptTemplateRepositorySQLite implements PromptTemplateRepository using SQLite
+type PromptTemplateRepositorySQLite struct {
+	db *gorm.DB
+}
+
+// NewPromptTemplateRepositorySQLite creates a new prompt template repository
+func NewPromptTemplateRepositorySQLite(db *gorm.DB) PromptTemplateRepository {
+	return &PromptTemplateRepositorySQLite{db: db}
+}
+
+func (r *PromptTemplateRepositorySQLite) CreateTemplate(template types.PromptTemplate) error {
+	return r.db.Create(&template).Error
+}
+
+func (r *PromptTemplateRepositorySQLite) GetLatestTemplate(userID string, kind string) (*types.PromptTemplate, error) {
+	var template types.PromptTemplate
+	err := r.db.Where("user_id = ? AND kind = ? AND reset_at IS NULL", userID, kind).
+		Order("version DESC").
+		First(&template).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (r *PromptTemplateRepositorySQLite) GetLatestVersion(userID string, kind string) (int, error) {
+	var version int
+	err := r.db.Model(&types.PromptTemplate{}).
+		Where("user_id = ? AND kind = ?", userID, kind).
+		Select("COALESCE(MAX(version), 0)").
+		Scan(&version).Error
+	return version, err
+}
+
+func (r *PromptTemplateRepositorySQLite) ResetTemplates(userID string, kind string) (int64, error) {
+	result := r.db.Model(&types.PromptTemplate{}).
+		Where("user_id = ? AND kind = ? AND reset_at IS NULL", userID, kind).
+		Update("reset_at", time.Now())
+	return result.RowsAffected, result.Error
+}
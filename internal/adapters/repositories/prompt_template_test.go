@@ -0,0 +1,68 @@
+// repositories/prompt_template_test.go
+package repositories
+
+import (
+	"testing"
+
+	th "github.com/robstave/meowmorize/internal/adapters/repositories/repositories_test"
+	"github.com/robstave/meowmorize/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromptTemplateRepositorySQLite(t *testing.T) {
+	db := th.SetupTestDB(t)
+	repo := NewPromptTemplateRepositorySQLite(db)
+
+	template, err := repo.GetLatestTemplate("meow", types.LLMPurposeExplain)
+	assert.NoError(t, err)
+	assert.Nil(t, template)
+
+	templates := []types.PromptTemplate{
+		{ID: "p1", UserID: "", Kind: types.LLMPurposeExplain, Version: 1, Body: "default"},
+		{ID: "p2", UserID: "meow", Kind: types.LLMPurposeExplain, Version: 1, Body: "mine"},
+		{ID: "p3", UserID: "meow", Kind: types.LLMPurposeExplain, Version: 2, Body: "mine again"},
+		{ID: "p4", UserID: "meow", Kind: types.LLMPurposeGrade, Version: 1, Body: "grade"},
+	}
+	for _, template := range templates {
+		assert.NoError(t, repo.CreateTemplate(template))
+	}
+
+	// A version can only be saved once
+	assert.Error(t, repo.CreateTemplate(types.PromptTemplate{ID: "p5", UserID: "meow", Kind: types.LLMPurposeExplain, Version: 2}))
+
+	template, err = repo.GetLatestTemplate("meow", types.LLMPurposeExplain)
+	assert.NoError(t, err)
+	if assert.NotNil(t, template) {
+		assert.Equal(t, "p3", template.ID)
+		assert.Equal(t, "mine again", template.Body)
+	}
+
+	template, err = repo.GetLatestTemplate("", types.LLMPurposeExplain)
+	assert.NoError(t, err)
+	if assert.NotNil(t, template) {
+		assert.Equal(t, "default", template.Body)
+	}
+
+	reset, err := repo.ResetTemplates("meow", types.LLMPurposeExplain)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), reset)
+
+	// Reset versions are kept but no longer used
+	template, err = repo.GetLatestTemplate("meow", types.LLMPurposeExplain)
+	assert.NoError(t, err)
+	assert.Nil(t, template)
+	version, err := repo.GetLatestVersion("meow", types.LLMPurposeExplain)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, version)
+	reset, err = repo.ResetTemplates("meow", types.LLMPurposeExplain)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), reset)
+
+	version, err = repo.GetLatestVersion("meow", types.LLMPurposeHint)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, version)
+
+	template, err = repo.GetLatestTemplate("meow", types.LLMPurposeGrade)
+	assert.NoError(t, err)
+	assert.NotNil(t, template)
+}
@@ -17,7 +17,7 @@ func SetupTestDB(t *testing.T) *gorm.DB {
 	}
 
 	// Perform migrations
-	err = db.AutoMigrate(&types.Card{}, &types.Deck{})
+	err = db.AutoMigrate(&types.Card{}, &types.Deck{}, &types.Attachment{}, &types.AttachmentOwner{}, &types.SessionLog{}, &types.DailyRollup{}, &types.CardDraft{}, &types.ExplanationTurn{}, &types.LLMUsage{}, &types.LLMCacheEntry{}, &types.PromptTemplate{}, &types.LintJob{}, &types.LintFinding{}, &types.CardRevision{}, &types.CardHint{})
 	if err != nil {
 		t.Fatalf("Failed to migrate database: %v", err)
 	}
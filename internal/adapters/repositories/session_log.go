@@ -1,23 +1,39 @@
 package repositories
 
 import (
-	"fmt"
+	"errors"
+	"time"
 
 	"github.com/robstave/meowmorize/internal/domain/types"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-const maxSessionLogRows = 50000
+// reviewActions are the session log actions counted in the daily rollups
+var reviewActions = []string{string(types.IncrementPass), string(types.IncrementFail), string(types.IncrementSkip)}
 
 // SessionLogRepository defines methods to work with session logs.
 type SessionLogRepository interface {
-	// CreateLog creates a new session log entry and prunes old entries if needed.
+	// CreateLog creates a new session log entry and adds reviews to the daily rollup.
 	CreateLog(log types.SessionLog) error
-	// PruneLogs ensures that the total number of log entries does not exceed maxRows.
-	PruneLogs(maxRows int) error
+	// PruneLogs deletes the user's log entries created before the cutoff. Rollups are kept.
+	PruneLogs(userID string, before time.Time) (int64, error)
+	// BackfillRollups builds the daily rollups from the raw logs when there are none yet,
+	// e.g. right after the rollup table was added.
+	BackfillRollups() (int64, error)
 
 	GetSessionLogsBySessionID(sessionID string) ([]types.SessionLog, error)
-	GetSessionLogIdsByUser(userID, deckID string) ([]string, error)
+	// GetSessionLogIdsByUser returns a page of the user's session IDs, most recent first,
+	// along with the total number of sessions matching the query.
+	GetSessionLogIdsByUser(userID string, query types.SessionHistoryQuery) ([]string, int64, error)
+	// MarkUndone flags a log entry as undone and takes it out of the daily rollup; the row is kept for history.
+	MarkUndone(logID string) error
+	// GetRecentResponseTimes returns the most recent known answer times for a card, newest first.
+	GetRecentResponseTimes(cardID string, limit int) ([]int64, error)
+	// GetDailyRollups returns the user's daily rollups, oldest first, optionally for one deck.
+	GetDailyRollups(userID string, deckID string) ([]types.DailyRollup, error)
+	// CountLapses counts the fails of a card logged after since, leaving out undone ones.
+	CountLapses(cardID string, since time.Time) (int64, error)
 }
 
 // SessionLogRepositorySQLite implements SessionLogRepository using SQLite.
@@ -30,32 +46,100 @@ func NewSessionLogRepositorySQLite(db *gorm.DB) SessionLogRepository {
 	return &SessionLogRepositorySQLite{db: db}
 }
 
-// CreateLog inserts a new session log entry and prunes old entries if needed.
+// CreateLog inserts a new session log entry. Reviews are added to the daily rollup
+// in the same transaction.
 func (r *SessionLogRepositorySQLite) CreateLog(log types.SessionLog) error {
-	if err := r.db.Create(&log).Error; err != nil {
-		return err
-	}
-	// Prune old log entries if exceeding the limit.
-	return r.PruneLogs(maxSessionLogRows)
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&log).Error; err != nil {
+			return err
+		}
+		if !isRollupAction(log.Action) || log.Undone {
+			return nil
+		}
+		return addToRollup(tx, log, 1)
+	})
+}
+
+// PruneLogs deletes the user's session log entries created before the cutoff.
+func (r *SessionLogRepositorySQLite) PruneLogs(userID string, before time.Time) (int64, error) {
+	result := r.db.Where("user_id = ? AND created_at < ?", userID, before).Delete(&types.SessionLog{})
+	return result.RowsAffected, result.Error
 }
 
-// PruneLogs deletes the oldest session log entries if total rows exceed maxRows.
-func (r *SessionLogRepositorySQLite) PruneLogs(maxRows int) error {
+// BackfillRollups rebuilds the daily rollups from the session logs if the rollup table is empty.
+// Entries logged before the local day was recorded are counted on their UTC day.
+func (r *SessionLogRepositorySQLite) BackfillRollups() (int64, error) {
 	var count int64
-	if err := r.db.Model(&types.SessionLog{}).Count(&count).Error; err != nil {
-		return err
+	if err := r.db.Model(&types.DailyRollup{}).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	if count > 0 {
+		return 0, nil
+	}
+
+	result := r.db.Exec(`
+		INSERT INTO daily_rollups (user_id, deck_id, day, reviews, passes, fails, skips, timed_reviews, response_ms, updated_at)
+		SELECT user_id, deck_id, COALESCE(NULLIF(day, ''), date(created_at)),
+			COUNT(*),
+			SUM(CASE WHEN action = ? THEN 1 ELSE 0 END),
+			SUM(CASE WHEN action = ? THEN 1 ELSE 0 END),
+			SUM(CASE WHEN action = ? THEN 1 ELSE 0 END),
+			SUM(CASE WHEN response_ms > 0 THEN 1 ELSE 0 END),
+			SUM(response_ms),
+			?
+		FROM session_logs
+		WHERE undone = ? AND action IN ?
+		GROUP BY user_id, deck_id, COALESCE(NULLIF(day, ''), date(created_at))`,
+		string(types.IncrementPass), string(types.IncrementFail), string(types.IncrementSkip),
+		time.Now(), false, reviewActions)
+	return result.RowsAffected, result.Error
+}
+
+// isRollupAction reports whether a log action is counted in the daily rollups
+func isRollupAction(action string) bool {
+	for _, a := range reviewActions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// addToRollup adds (sign 1) or removes (sign -1) a review from its daily rollup
+func addToRollup(tx *gorm.DB, log types.SessionLog, sign int) error {
+	day := log.Day
+	if day == "" {
+		day = log.CreatedAt.UTC().Format("2006-01-02")
 	}
-	if count <= int64(maxRows) {
-		return nil
+
+	rollup := types.DailyRollup{UserID: log.UserID, DeckID: log.DeckID, Day: day, UpdatedAt: time.Now()}
+	updates := map[string]interface{}{
+		"reviews":    gorm.Expr("reviews + ?", sign),
+		"updated_at": rollup.UpdatedAt,
+	}
+	switch types.CardAction(log.Action) {
+	case types.IncrementPass:
+		rollup.Passes = sign
+		updates["passes"] = gorm.Expr("passes + ?", sign)
+	case types.IncrementFail:
+		rollup.Fails = sign
+		updates["fails"] = gorm.Expr("fails + ?", sign)
+	case types.IncrementSkip:
+		rollup.Skips = sign
+		updates["skips"] = gorm.Expr("skips + ?", sign)
+	}
+	rollup.Reviews = sign
+	if log.ResponseMs > 0 {
+		rollup.TimedReviews = sign
+		rollup.ResponseMs = int64(sign) * log.ResponseMs
+		updates["timed_reviews"] = gorm.Expr("timed_reviews + ?", sign)
+		updates["response_ms"] = gorm.Expr("response_ms + ?", int64(sign)*log.ResponseMs)
 	}
-	// Calculate the number of rows to delete.
-	rowsToDelete := count - int64(maxRows)
-	// Delete the oldest entries (ordered by CreatedAt ascending).
-	sql := fmt.Sprintf(
-		"DELETE FROM session_logs WHERE id IN (SELECT id FROM session_logs ORDER BY created_at ASC LIMIT %d)",
-		rowsToDelete,
-	)
-	return r.db.Exec(sql).Error
+
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "deck_id"}, {Name: "day"}},
+		DoUpdates: clause.Assignments(updates),
+	}).Create(&rollup).Error
 }
 
 // GetSessionLogsBySessionID retrieves all session logs for a specific session, ordered by CreatedAt ascending.
@@ -68,26 +152,123 @@ func (r *SessionLogRepositorySQLite) GetSessionLogsBySessionID(sessionID string)
 	return logs, nil
 }
 
-func (r *SessionLogRepositorySQLite) GetSessionLogIdsByUser(userID, deckID string) ([]string, error) {
-	var sessionIDs []string
-
-	// Direct query using window functions to get distinct session_ids ordered by their latest created_at
-	query := r.db.Table("session_logs").
-		Select("DISTINCT session_id").
+func (r *SessionLogRepositorySQLite) GetSessionLogIdsByUser(userID string, query types.SessionHistoryQuery) ([]string, int64, error) {
+	sessions := r.db.Model(&types.SessionLog{}).
+		Select("session_id, MAX(created_at) AS last_at").
 		Where("user_id = ?", userID)
 
-	if deckID != "" {
-		query = query.Where("deck_id = ?", deckID)
+	if query.DeckID != "" {
+		sessions = sessions.Where("deck_id = ?", query.DeckID)
+	}
+	if !query.From.IsZero() {
+		sessions = sessions.Where("created_at >= ?", query.From)
+	}
+	if !query.To.IsZero() {
+		sessions = sessions.Where("created_at < ?", query.To)
+	}
+	sessions = sessions.Group("session_id")
+
+	var total int64
+	if err := r.db.Table("(?) AS sessions", sessions).Count(&total).Error; err != nil {
+		return nil, 0, err
 	}
 
-	err := query.
-		Order("MAX(created_at) OVER (PARTITION BY session_id) DESC").
-		Limit(3).
-		Pluck("session_id", &sessionIDs).Error
+	var rows []struct {
+		SessionID string
+	}
+	page := r.db.Table("(?) AS sessions", sessions).Select("session_id").Order("last_at DESC").Offset(query.Offset)
+	if query.Limit > 0 {
+		page = page.Limit(query.Limit)
+	}
+	if err := page.Scan(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+
+	sessionIDs := make([]string, len(rows))
+	for i, row := range rows {
+		sessionIDs[i] = row.SessionID
+	}
+	return sessionIDs, total, nil
+}
 
+// MarkUndone flags a session log entry as undone and removes it from the daily rollup.
+func (r *SessionLogRepositorySQLite) MarkUndone(logID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var log types.SessionLog
+		err := tx.Where("id = ?", logID).First(&log).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Already pruned, nothing left to undo
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if log.Undone {
+			return nil
+		}
+		if err := tx.Model(&log).Update("undone", true).Error; err != nil {
+			return err
+		}
+		if !isRollupAction(log.Action) {
+			return nil
+		}
+		return addToRollup(tx, log, -1)
+	})
+}
+
+// GetRecentResponseTimes returns the answer times of the latest pass and fail entries for a card.
+// Undone entries and entries without a recorded answer time are ignored.
+func (r *SessionLogRepositorySQLite) GetRecentResponseTimes(cardID string, limit int) ([]int64, error) {
+	var times []int64
+	err := r.db.Model(&types.SessionLog{}).
+		Where("card_id = ? AND response_ms > 0 AND undone = ? AND action IN ?", cardID, false, []string{string(types.IncrementPass), string(types.IncrementFail)}).
+		Order("created_at DESC").
+		Limit(limit).
+		Pluck("response_ms", &times).Error
 	if err != nil {
 		return nil, err
 	}
+	return times, nil
+}
+
+// GetDailyRollups retrieves the user's daily rollups ordered by day, optionally for a single deck.
+func (r *SessionLogRepositorySQLite) GetDailyRollups(userID string, deckID string) ([]types.DailyRollup, error) {
+	var rollups []types.DailyRollup
+	query := r.db.Where("user_id = ?", userID)
+	if deckID != "" {
+		query = query.Where("deck_id = ?", deckID)
+	}
+	if err := query.Order("day ASC").Find(&rollups).Error; err != nil {
+		return nil, err
+	}
+	return rollups, nil
+}
 
-	return sessionIDs, nil
+// CountLapses counts the fails of a card logged after since that were not undone.
+func (r *SessionLogRepositorySQLite) CountLapses(cardID string, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&types.SessionLog{}).
+		Where("card_id = ? AND action = ? AND undone = ? AND created_at > ?", cardID, string(types.IncrementFail), false, since).
+		Count(&count).Error
+	return count, err
+}
+
+// sqliteTimeLayouts are the layouts times are stored with by the SQLite driver
+var sqliteTimeLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02T15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	time.RFC3339Nano,
+}
+
+// parseSQLiteTime parses a time returned as text by an aggregate query
+func parseSQLiteTime(value string) (time.Time, error) {
+	var err error
+	for _, layout := range sqliteTimeLayouts {
+		var t time.Time
+		if t, err = time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
 }
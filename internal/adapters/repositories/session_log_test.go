@@ -0,0 +1,214 @@
+// repositories/session_log_test.go
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	th "github.com/robstave/meowmorize/internal/adapters/repositories/repositories_test"
+	"github.com/robstave/meowmorize/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionLogRepositorySQLite_GetRecentResponseTimes(t *testing.T) {
+	db := th.SetupTestDB(t)
+	repo := NewSessionLogRepositorySQLite(db)
+
+	base := time.Now().Add(-time.Hour)
+	logs := []types.SessionLog{
+		{ID: "1", DeckID: "deck1", CardID: "card1", SessionID: "s1", UserID: "meow", Action: string(types.IncrementPass), ResponseMs: 1000, CreatedAt: base},
+		{ID: "2", DeckID: "deck1", CardID: "card1", SessionID: "s1", UserID: "meow", Action: string(types.IncrementFail), ResponseMs: 2000, CreatedAt: base.Add(time.Minute)},
+		{ID: "3", DeckID: "deck1", CardID: "card1", SessionID: "s1", UserID: "meow", Action: string(types.IncrementSkip), ResponseMs: 9000, CreatedAt: base.Add(2 * time.Minute)},
+		{ID: "4", DeckID: "deck1", CardID: "card1", SessionID: "s1", UserID: "meow", Action: string(types.IncrementPass), CreatedAt: base.Add(3 * time.Minute)},
+		{ID: "5", DeckID: "deck1", CardID: "card2", SessionID: "s1", UserID: "meow", Action: string(types.IncrementPass), ResponseMs: 5000, CreatedAt: base.Add(4 * time.Minute)},
+	}
+	for _, log := range logs {
+		assert.NoError(t, repo.CreateLog(log))
+	}
+
+	times, err := repo.GetRecentResponseTimes("card1", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{2000, 1000}, times)
+
+	times, err = repo.GetRecentResponseTimes("card1", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{2000}, times)
+}
+
+func TestSessionLogRepositorySQLite_MarkUndone(t *testing.T) {
+	db := th.SetupTestDB(t)
+	repo := NewSessionLogRepositorySQLite(db)
+
+	assert.NoError(t, repo.CreateLog(types.SessionLog{ID: "1", DeckID: "deck1", CardID: "card1", SessionID: "s1", UserID: "meow", Action: string(types.IncrementFail), ResponseMs: 1000}))
+	assert.NoError(t, repo.MarkUndone("1"))
+
+	logs, err := repo.GetSessionLogsBySessionID("s1")
+	assert.NoError(t, err)
+	assert.Len(t, logs, 1)
+	assert.True(t, logs[0].Undone)
+
+	times, err := repo.GetRecentResponseTimes("card1", 10)
+	assert.NoError(t, err)
+	assert.Empty(t, times)
+}
+
+func TestSessionLogRepositorySQLite_GetSessionLogIdsByUser(t *testing.T) {
+	db := th.SetupTestDB(t)
+	repo := NewSessionLogRepositorySQLite(db)
+
+	base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	logs := []types.SessionLog{
+		{ID: "1", DeckID: "deck1", CardID: "card1", SessionID: "s1", UserID: "meow", Action: string(types.IncrementPass), CreatedAt: base},
+		{ID: "2", DeckID: "deck1", CardID: "card1", SessionID: "s2", UserID: "meow", Action: string(types.IncrementPass), CreatedAt: base.AddDate(0, 0, 1)},
+		{ID: "3", DeckID: "deck1", CardID: "card2", SessionID: "s2", UserID: "meow", Action: string(types.IncrementFail), CreatedAt: base.AddDate(0, 0, 1).Add(time.Minute)},
+		{ID: "4", DeckID: "deck2", CardID: "card3", SessionID: "s3", UserID: "meow", Action: string(types.IncrementPass), CreatedAt: base.AddDate(0, 0, 2)},
+		{ID: "5", DeckID: "deck1", CardID: "card1", SessionID: "s4", UserID: "meow", Action: string(types.IncrementPass), CreatedAt: base.AddDate(0, 0, 3)},
+		{ID: "6", DeckID: "deck1", CardID: "card1", SessionID: "s5", UserID: "other", Action: string(types.IncrementPass), CreatedAt: base.AddDate(0, 0, 4)},
+	}
+	for _, log := range logs {
+		assert.NoError(t, repo.CreateLog(log))
+	}
+
+	ids, total, err := repo.GetSessionLogIdsByUser("meow", types.SessionHistoryQuery{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), total)
+	assert.Equal(t, []string{"s4", "s3", "s2", "s1"}, ids)
+
+	ids, total, err = repo.GetSessionLogIdsByUser("meow", types.SessionHistoryQuery{DeckID: "deck1", Offset: 1, Limit: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	assert.Equal(t, []string{"s2"}, ids)
+
+	ids, total, err = repo.GetSessionLogIdsByUser("meow", types.SessionHistoryQuery{
+		From: base.Add(time.Hour),
+		To:   base.AddDate(0, 0, 3),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Equal(t, []string{"s3", "s2"}, ids)
+}
+
+func TestSessionLogRepositorySQLite_DailyRollups(t *testing.T) {
+	db := th.SetupTestDB(t)
+	repo := NewSessionLogRepositorySQLite(db)
+
+	at := time.Date(2024, 5, 1, 23, 30, 0, 0, time.UTC)
+	logs := []types.SessionLog{
+		{ID: "1", DeckID: "deck1", CardID: "card1", SessionID: "s1", UserID: "meow", Action: string(types.IncrementPass), ResponseMs: 1000, Day: "2024-05-02", CreatedAt: at},
+		{ID: "2", DeckID: "deck1", CardID: "card2", SessionID: "s1", UserID: "meow", Action: string(types.IncrementFail), ResponseMs: 3000, Day: "2024-05-02", CreatedAt: at},
+		{ID: "3", DeckID: "deck1", CardID: "card2", SessionID: "s1", UserID: "meow", Action: string(types.IncrementSkip), Day: "2024-05-02", CreatedAt: at},
+		{ID: "4", DeckID: "deck1", SessionID: "s1", UserID: "meow", Action: "reshuffle", Day: "2024-05-02", CreatedAt: at},
+		{ID: "5", DeckID: "deck2", CardID: "card3", SessionID: "s2", UserID: "meow", Action: string(types.IncrementPass), CreatedAt: at},
+	}
+	for _, log := range logs {
+		assert.NoError(t, repo.CreateLog(log))
+	}
+	assert.NoError(t, repo.MarkUndone("2"))
+	assert.NoError(t, repo.MarkUndone("2")) // Undoing twice changes nothing
+	assert.NoError(t, repo.MarkUndone("missing"))
+
+	rollups, err := repo.GetDailyRollups("meow", "")
+	assert.NoError(t, err)
+	assert.Len(t, rollups, 2)
+	// Entries without a local day fall back to their UTC day
+	assert.Equal(t, "2024-05-01", rollups[0].Day)
+	assert.Equal(t, "deck2", rollups[0].DeckID)
+
+	deck1 := rollups[1]
+	assert.Equal(t, "2024-05-02", deck1.Day)
+	assert.Equal(t, 2, deck1.Reviews)
+	assert.Equal(t, 1, deck1.Passes)
+	assert.Equal(t, 0, deck1.Fails)
+	assert.Equal(t, 1, deck1.Skips)
+	assert.Equal(t, 1, deck1.TimedReviews)
+	assert.Equal(t, int64(1000), deck1.ResponseMs)
+
+	rollups, err = repo.GetDailyRollups("meow", "deck1")
+	assert.NoError(t, err)
+	assert.Len(t, rollups, 1)
+}
+
+func TestSessionLogRepositorySQLite_PruneLogsKeepsRollups(t *testing.T) {
+	db := th.SetupTestDB(t)
+	repo := NewSessionLogRepositorySQLite(db)
+
+	old := time.Now().AddDate(0, 0, -40)
+	assert.NoError(t, repo.CreateLog(types.SessionLog{ID: "1", DeckID: "deck1", CardID: "card1", SessionID: "s1", UserID: "meow", Action: string(types.IncrementPass), CreatedAt: old}))
+	assert.NoError(t, repo.CreateLog(types.SessionLog{ID: "2", DeckID: "deck1", CardID: "card1", SessionID: "s2", UserID: "meow", Action: string(types.IncrementPass), CreatedAt: time.Now()}))
+	assert.NoError(t, repo.CreateLog(types.SessionLog{ID: "3", DeckID: "deck1", CardID: "card1", SessionID: "s3", UserID: "other", Action: string(types.IncrementPass), CreatedAt: old}))
+
+	pruned, err := repo.PruneLogs("meow", time.Now().AddDate(0, 0, -30))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), pruned)
+
+	logs, err := repo.GetSessionLogsBySessionID("s1")
+	assert.NoError(t, err)
+	assert.Empty(t, logs)
+	logs, err = repo.GetSessionLogsBySessionID("s3")
+	assert.NoError(t, err)
+	assert.Len(t, logs, 1)
+
+	rollups, err := repo.GetDailyRollups("meow", "")
+	assert.NoError(t, err)
+	assert.Len(t, rollups, 2)
+}
+
+func TestSessionLogRepositorySQLite_BackfillRollups(t *testing.T) {
+	db := th.SetupTestDB(t)
+	repo := NewSessionLogRepositorySQLite(db)
+
+	// Logs written before the rollup table existed
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	logs := []types.SessionLog{
+		{ID: "1", DeckID: "deck1", CardID: "card1", SessionID: "s1", UserID: "meow", Action: string(types.IncrementPass), ResponseMs: 1000, CreatedAt: at},
+		{ID: "2", DeckID: "deck1", CardID: "card2", SessionID: "s1", UserID: "meow", Action: string(types.IncrementFail), CreatedAt: at},
+		{ID: "3", DeckID: "deck1", CardID: "card2", SessionID: "s1", UserID: "meow", Action: string(types.IncrementPass), Undone: true, CreatedAt: at},
+		{ID: "4", DeckID: "deck1", SessionID: "s1", UserID: "meow", Action: "reshuffle", CreatedAt: at},
+	}
+	assert.NoError(t, db.Create(&logs).Error)
+
+	n, err := repo.BackfillRollups()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	rollups, err := repo.GetDailyRollups("meow", "deck1")
+	assert.NoError(t, err)
+	if assert.Len(t, rollups, 1) {
+		assert.Equal(t, "2024-05-01", rollups[0].Day)
+		assert.Equal(t, 2, rollups[0].Reviews)
+		assert.Equal(t, 1, rollups[0].Passes)
+		assert.Equal(t, 1, rollups[0].Fails)
+		assert.Equal(t, 1, rollups[0].TimedReviews)
+		assert.Equal(t, int64(1000), rollups[0].ResponseMs)
+	}
+
+	// Only runs once
+	n, err = repo.BackfillRollups()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), n)
+}
+
+func TestSessionLogRepositorySQLite_CountLapses(t *testing.T) {
+	db := th.SetupTestDB(t)
+	repo := NewSessionLogRepositorySQLite(db)
+
+	base := time.Now().Add(-time.Hour)
+	logs := []types.SessionLog{
+		{ID: "1", DeckID: "deck1", CardID: "card1", SessionID: "s1", UserID: "meow", Action: string(types.IncrementFail), CreatedAt: base},
+		{ID: "2", DeckID: "deck1", CardID: "card1", SessionID: "s1", UserID: "meow", Action: string(types.IncrementFail), CreatedAt: base.Add(time.Minute)},
+		{ID: "3", DeckID: "deck1", CardID: "card1", SessionID: "s1", UserID: "meow", Action: string(types.IncrementFail), Undone: true, CreatedAt: base.Add(2 * time.Minute)},
+		{ID: "4", DeckID: "deck1", CardID: "card1", SessionID: "s1", UserID: "meow", Action: string(types.IncrementPass), CreatedAt: base.Add(3 * time.Minute)},
+		{ID: "5", DeckID: "deck1", CardID: "card2", SessionID: "s1", UserID: "meow", Action: string(types.IncrementFail), CreatedAt: base.Add(4 * time.Minute)},
+	}
+	for _, log := range logs {
+		assert.NoError(t, repo.CreateLog(log))
+	}
+
+	lapses, err := repo.CountLapses("card1", time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), lapses)
+
+	lapses, err = repo.CountLapses("card1", base.Add(30*time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), lapses)
+}
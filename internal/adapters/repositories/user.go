@@ -14,6 +14,8 @@ type UserRepository interface {
 	GetAllUsers() ([]types.User, error)
 	DeleteUser(userID string) error
 	UpdateUserPassword(userID string, password string) error
+	UpdateUserSettings(username string, settings types.UserSettings) error
+	UpdateUserLLMQuota(userID string, quota int) error
 }
 
 type UserRepositorySQLite struct {
@@ -50,3 +52,30 @@ func (r *UserRepositorySQLite) DeleteUser(userID string) error {
 func (r *UserRepositorySQLite) UpdateUserPassword(userID string, password string) error {
 	return r.db.Model(&types.User{}).Where("id = ?", userID).Update("password", password).Error
 }
+
+func (r *UserRepositorySQLite) UpdateUserLLMQuota(userID string, quota int) error {
+	return r.db.Model(&types.User{}).Where("id = ?", userID).Update("llm_daily_quota", quota).Error
+}
+
+func (r *UserRepositorySQLite) UpdateUserSettings(username string, settings types.UserSettings) error {
+	updates := map[string]interface{}{}
+	if settings.Timezone != nil {
+		updates["timezone"] = *settings.Timezone
+	}
+	if settings.StreakFreezes != nil {
+		updates["streak_freezes"] = *settings.StreakFreezes
+	}
+	if settings.LogRetentionDays != nil {
+		updates["log_retention_days"] = *settings.LogRetentionDays
+	}
+	if settings.LeechThreshold != nil {
+		updates["leech_threshold"] = *settings.LeechThreshold
+	}
+	if settings.LeechAction != nil {
+		updates["leech_action"] = *settings.LeechAction
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+	return r.db.Model(&types.User{}).Where("username = ?", username).Updates(updates).Error
+}
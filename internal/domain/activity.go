@@ -0,0 +1,183 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/robstave/meowmorize/internal/domain/types"
+)
+
+// dateLayout is the layout of the local day keys of the activity calendar
+const dateLayout = "2006-01-02"
+
+// GetActivityCalendar aggregates the user's daily rollups over the last days, optionally for
+// one deck, and computes the current and longest streaks in the user's timezone
+func (s *Service) GetActivityCalendar(username string, deckID string, days int) (types.ActivityCalendar, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		s.logger.Error("Failed to retrieve user", "username", username, "error", err)
+		return types.ActivityCalendar{}, err
+	}
+	if user == nil {
+		return types.ActivityCalendar{}, errors.New("user not found")
+	}
+
+	loc := userLocation(user.Timezone)
+
+	rollups, err := s.sessionLogRepo.GetDailyRollups(username, deckID)
+	if err != nil {
+		s.logger.Error("Failed to retrieve daily rollups", "username", username, "error", err)
+		return types.ActivityCalendar{}, err
+	}
+
+	return buildActivityCalendar(rollups, loc, time.Now(), user.StreakFreezes, days), nil
+}
+
+// UpdateUserSettings changes the user's timezone, monthly streak freeze allowance,
+// session log retention and leech handling. Settings left nil are kept.
+func (s *Service) UpdateUserSettings(username string, settings types.UserSettings) error {
+	if settings.Timezone != nil {
+		if _, err := time.LoadLocation(*settings.Timezone); err != nil || *settings.Timezone == "" {
+			return errors.New("invalid timezone")
+		}
+	}
+	if settings.StreakFreezes != nil && *settings.StreakFreezes < 0 {
+		return errors.New("streak freezes must not be negative")
+	}
+	if settings.LogRetentionDays != nil && *settings.LogRetentionDays < 0 {
+		return errors.New("log retention must not be negative")
+	}
+	if settings.LeechThreshold != nil && *settings.LeechThreshold < 1 {
+		return errors.New("leech threshold must be positive")
+	}
+	if settings.LeechAction != nil && *settings.LeechAction != types.LeechFlag && *settings.LeechAction != types.LeechRetire {
+		return errors.New("leech action must be flag or retire")
+	}
+
+	if err := s.userRepo.UpdateUserSettings(username, settings); err != nil {
+		return err
+	}
+
+	s.locationsMu.Lock()
+	delete(s.locations, username)
+	s.locationsMu.Unlock()
+	return nil
+}
+
+// localDayOf returns the user's local day at t, used to roll session logs up per day.
+// Timezones are cached per user.
+func (s *Service) localDayOf(username string, t time.Time) string {
+	s.locationsMu.Lock()
+	loc, ok := s.locations[username]
+	s.locationsMu.Unlock()
+
+	if !ok {
+		user, err := s.userRepo.GetUserByUsername(username)
+		if err != nil || user == nil {
+			return localDay(t, time.UTC).Format(dateLayout)
+		}
+		loc = userLocation(user.Timezone)
+
+		s.locationsMu.Lock()
+		s.locations[username] = loc
+		s.locationsMu.Unlock()
+	}
+	return localDay(t, loc).Format(dateLayout)
+}
+
+// userLocation loads the user's timezone, falling back to UTC
+func userLocation(timezone string) *time.Location {
+	if timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// localDay returns midnight UTC of the calendar day t falls on in loc, so days can be
+// stepped through with AddDate without daylight saving surprises
+func localDay(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// buildActivityCalendar sums the rollups per day and walks every day from the first
+// active day to today. An active day extends the streak. A missed day uses one of the month's
+// freezes while a streak is running and breaks it once they are used up. Today never breaks
+// the streak since it is not over yet.
+func buildActivityCalendar(rollups []types.DailyRollup, loc *time.Location, now time.Time, freezes int, days int) types.ActivityCalendar {
+	calendar := types.ActivityCalendar{
+		Timezone:      loc.String(),
+		Days:          []types.ActivityDay{},
+		StreakFreezes: freezes,
+		FreezesLeft:   freezes,
+	}
+
+	today := localDay(now, loc)
+
+	// Rollups are per deck; days that only had undone reviews left are not active
+	counts := map[string]*types.ActivityDay{}
+	var first time.Time
+	for _, rollup := range rollups {
+		if rollup.Reviews <= 0 {
+			continue
+		}
+		date, err := time.Parse(dateLayout, rollup.Day)
+		if err != nil {
+			continue
+		}
+		if first.IsZero() || date.Before(first) {
+			first = date
+		}
+
+		day, ok := counts[rollup.Day]
+		if !ok {
+			day = &types.ActivityDay{Date: rollup.Day}
+			counts[rollup.Day] = day
+		}
+		day.Reviews += rollup.Reviews
+		day.Passes += rollup.Passes
+		day.Fails += rollup.Fails
+	}
+	if len(counts) == 0 {
+		return calendar
+	}
+
+	rangeStart := today.AddDate(0, 0, 1-days)
+	usedFreezes := map[string]int{} // keyed by YYYY-MM
+	streak := 0
+
+	for day := first; !day.After(today); day = day.AddDate(0, 0, 1) {
+		key := day.Format(dateLayout)
+		month := day.Format("2006-01")
+		activity, active := counts[key]
+
+		switch {
+		case active:
+			streak++
+			if streak > calendar.LongestStreak {
+				calendar.LongestStreak = streak
+			}
+		case day.Equal(today):
+			// The day is not over yet
+		case streak > 0 && usedFreezes[month] < freezes:
+			usedFreezes[month]++
+			activity = &types.ActivityDay{Date: key, Frozen: true}
+		default:
+			streak = 0
+		}
+
+		if activity != nil && !day.Before(rangeStart) {
+			calendar.Days = append(calendar.Days, *activity)
+		}
+	}
+
+	calendar.CurrentStreak = streak
+	_, calendar.ActiveToday = counts[today.Format(dateLayout)]
+	calendar.FreezesLeft = max(freezes-usedFreezes[today.Format("2006-01")], 0)
+
+	return calendar
+}
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/robstave/meowmorize/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func rollup(deckID, day string, passes, fails, skips int) types.DailyRollup {
+	return types.DailyRollup{UserID: "meow", DeckID: deckID, Day: day, Reviews: passes + fails + skips, Passes: passes, Fails: fails, Skips: skips}
+}
+
+func TestBuildActivityCalendar_StreaksAndFreezes(t *testing.T) {
+	rollups := []types.DailyRollup{
+		rollup("deck1", "2024-05-01", 1, 1, 0),
+		rollup("deck2", "2024-05-01", 1, 0, 0),
+		rollup("deck1", "2024-05-02", 1, 0, 0),
+		rollup("deck1", "2024-05-03", 1, 0, 0),
+		// day 4 missed, frozen
+		rollup("deck1", "2024-05-05", 0, 0, 1),
+		// days 6 and 7 missed, only one freeze left, streak breaks on day 7
+		rollup("deck1", "2024-05-07", 0, 0, 0), // Every review of the day was undone
+		rollup("deck1", "2024-05-08", 1, 0, 0),
+		rollup("deck1", "2024-05-09", 1, 0, 0),
+	}
+
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	calendar := buildActivityCalendar(rollups, time.UTC, now, 2, 30)
+
+	assert.Equal(t, 4, calendar.LongestStreak)
+	assert.Equal(t, 2, calendar.CurrentStreak) // Today is not over yet
+	assert.False(t, calendar.ActiveToday)
+	assert.Equal(t, 0, calendar.FreezesLeft)
+
+	dates := map[string]types.ActivityDay{}
+	for _, d := range calendar.Days {
+		dates[d.Date] = d
+	}
+	assert.Equal(t, types.ActivityDay{Date: "2024-05-01", Reviews: 3, Passes: 2, Fails: 1}, dates["2024-05-01"])
+	assert.True(t, dates["2024-05-04"].Frozen)
+	assert.True(t, dates["2024-05-06"].Frozen)
+	assert.NotContains(t, dates, "2024-05-07")
+	assert.Equal(t, 1, dates["2024-05-05"].Reviews)
+}
+
+func TestBuildActivityCalendar_MissedDayBreaksStreak(t *testing.T) {
+	rollups := []types.DailyRollup{
+		rollup("deck1", "2024-05-01", 1, 0, 0),
+		rollup("deck1", "2024-05-02", 1, 0, 0),
+	}
+
+	now := time.Date(2024, 5, 4, 12, 0, 0, 0, time.UTC)
+	calendar := buildActivityCalendar(rollups, time.UTC, now, 0, 2)
+	assert.Equal(t, 2, calendar.LongestStreak)
+	assert.Equal(t, 0, calendar.CurrentStreak)
+	assert.Empty(t, calendar.Days) // Outside the requested range
+}
+
+func TestBuildActivityCalendar_UsesTimezoneForToday(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+
+	// 03:00 UTC on the 2nd is still the evening of the 1st in New York
+	now := time.Date(2024, 5, 2, 3, 0, 0, 0, time.UTC)
+	calendar := buildActivityCalendar([]types.DailyRollup{rollup("deck1", "2024-05-01", 2, 0, 0)}, loc, now, 0, 7)
+	assert.Equal(t, "America/New_York", calendar.Timezone)
+	assert.Len(t, calendar.Days, 1)
+	assert.True(t, calendar.ActiveToday)
+	assert.Equal(t, 1, calendar.CurrentStreak)
+}
+
+func TestLocalDayOf_UsesUserTimezone(t *testing.T) {
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	userRepo.On("GetUserByUsername", "nyc").Return(&types.User{ID: "nyc", Username: "nyc", Timezone: "America/New_York"}, nil).Once()
+
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo).(*Service)
+	at := time.Date(2024, 5, 2, 2, 0, 0, 0, time.UTC)
+	assert.Equal(t, "2024-05-01", s.localDayOf("nyc", at))
+	assert.Equal(t, "2024-05-01", s.localDayOf("nyc", at)) // Cached
+	assert.Equal(t, "2024-05-02", s.localDayOf("meow", at))
+	userRepo.AssertExpectations(t)
+}
+
+func TestUpdateUserSettings_Validation(t *testing.T) {
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+
+	oslo, mars, utc := "Europe/Oslo", "Mars/Olympus", "UTC"
+	one, negative := 1, -1
+	userRepo.On("UpdateUserSettings", "meow", types.UserSettings{Timezone: &oslo, StreakFreezes: &one}).Return(nil)
+
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	assert.EqualError(t, s.UpdateUserSettings("meow", types.UserSettings{Timezone: &mars}), "invalid timezone")
+	assert.EqualError(t, s.UpdateUserSettings("meow", types.UserSettings{Timezone: &utc, StreakFreezes: &negative}), "streak freezes must not be negative")
+	assert.EqualError(t, s.UpdateUserSettings("meow", types.UserSettings{LogRetentionDays: &negative}), "log retention must not be negative")
+	assert.NoError(t, s.UpdateUserSettings("meow", types.UserSettings{Timezone: &oslo, StreakFreezes: &one}))
+	userRepo.AssertExpectations(t)
+}
+
+func TestPruneSessionLogs_UsesUserRetention(t *testing.T) {
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	userRepo.On("GetAllUsers").Return([]types.User{
+		{Username: "meow", LogRetentionDays: 30},
+		{Username: "keeper", LogRetentionDays: 0},
+	}, nil)
+	cutoff := time.Now().AddDate(0, 0, -30)
+	sessionRepo.On("PruneLogs", "meow", mock.MatchedBy(func(before time.Time) bool {
+		return before.Sub(cutoff).Abs() < time.Minute
+	})).Return(int64(4), nil)
+
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	pruned, err := s.PruneSessionLogs()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), pruned)
+	sessionRepo.AssertNotCalled(t, "PruneLogs", "keeper", mock.Anything)
+	sessionRepo.AssertExpectations(t)
+}
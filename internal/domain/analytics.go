@@ -0,0 +1,123 @@
+// internal/domain/analytics.go
+package domain
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/robstave/meowmorize/internal/domain/types"
+)
+
+// hardestCardsLimit is how many of the hardest cards the analytics list
+const hardestCardsLimit = 10
+
+// trendDays is how many recent study days the trend covers
+const trendDays = 10
+
+// passRateBucketSize is the width of the pass rate distribution buckets in percent
+const passRateBucketSize = 20
+
+// GetDeckAnalytics computes the health of one of the user's decks from the card
+// counters and the daily rollups. It costs two queries. The rollups outlive the raw
+// session logs, so the trend is not cut short by log retention.
+func (s *Service) GetDeckAnalytics(deckID string, username string) (types.DeckAnalytics, error) {
+	deck, err := s.deckRepo.GetDeckByID(deckID)
+	if err != nil {
+		s.logger.Error("Failed to retrieve deck", "deck_id", deckID, "error", err)
+		return types.DeckAnalytics{}, errors.New("deck not found")
+	}
+	if deck.UserID != username {
+		return types.DeckAnalytics{}, errors.New("deck not found")
+	}
+
+	rollups, err := s.sessionLogRepo.GetDailyRollups(username, deckID)
+	if err != nil {
+		s.logger.Error("Failed to retrieve daily rollups", "deck_id", deckID, "error", err)
+		return types.DeckAnalytics{}, err
+	}
+
+	return buildDeckAnalytics(deck, rollups), nil
+}
+
+// buildDeckAnalytics summarises the deck's cards. Retired cards are only counted;
+// the distributions cover the active cards. The rollups are expected oldest first.
+func buildDeckAnalytics(deck types.Deck, rollups []types.DailyRollup) types.DeckAnalytics {
+	analytics := types.DeckAnalytics{
+		DeckID:       deck.ID,
+		DeckName:     deck.Name,
+		Cards:        len(deck.Cards),
+		Stars:        []types.StarCount{},
+		HardestCards: []types.HardCard{},
+		Trend:        []types.TrendPoint{},
+	}
+
+	for lower := 0; lower < 100; lower += passRateBucketSize {
+		analytics.PassRates = append(analytics.PassRates, types.PassRateBucket{Min: lower, Max: lower + passRateBucketSize})
+	}
+
+	stars := map[int]int{}
+	var passes, reviews int
+	for _, card := range deck.Cards {
+		if card.Retired {
+			analytics.Retired++
+			continue
+		}
+		stars[card.StarRating]++
+
+		cardReviews := card.PassCount + card.FailCount + card.SkipCount
+		if cardReviews == 0 {
+			analytics.NeverReviewed++
+			continue
+		}
+		passes += card.PassCount
+		reviews += cardReviews
+
+		bucket := card.PassCount * 100 / cardReviews / passRateBucketSize
+		analytics.PassRates[min(bucket, len(analytics.PassRates)-1)].Cards++
+
+		analytics.HardestCards = append(analytics.HardestCards, types.HardCard{
+			CardID:       card.ID,
+			Front:        card.Front.Text,
+			CombinedRate: calculateCombinedRate(card),
+			PassCount:    card.PassCount,
+			FailCount:    card.FailCount,
+			SkipCount:    card.SkipCount,
+		})
+	}
+
+	if reviews > 0 {
+		analytics.PassRate = float64(passes) / float64(reviews) * 100
+	}
+
+	for rating, count := range stars {
+		analytics.Stars = append(analytics.Stars, types.StarCount{Stars: rating, Cards: count})
+	}
+	sort.Slice(analytics.Stars, func(i, j int) bool { return analytics.Stars[i].Stars < analytics.Stars[j].Stars })
+
+	sort.SliceStable(analytics.HardestCards, func(i, j int) bool {
+		return analytics.HardestCards[i].CombinedRate > analytics.HardestCards[j].CombinedRate
+	})
+	if len(analytics.HardestCards) > hardestCardsLimit {
+		analytics.HardestCards = analytics.HardestCards[:hardestCardsLimit]
+	}
+
+	for _, rollup := range rollups {
+		if rollup.Reviews == 0 {
+			// Every review of the day was undone
+			continue
+		}
+		analytics.Trend = append(analytics.Trend, types.TrendPoint{
+			Day:      rollup.Day,
+			Reviews:  rollup.Reviews,
+			Passes:   rollup.Passes,
+			Fails:    rollup.Fails,
+			Skips:    rollup.Skips,
+			PassRate: float64(rollup.Passes) / float64(rollup.Reviews) * 100,
+		})
+	}
+	if len(analytics.Trend) > trendDays {
+		analytics.Trend = analytics.Trend[len(analytics.Trend)-trendDays:]
+	}
+
+	return analytics
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/robstave/meowmorize/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildDeckAnalytics(t *testing.T) {
+	deck := types.Deck{ID: "deck1", Name: "Capitals", UserID: "meow", Cards: []types.Card{
+		{ID: "easy", PassCount: 9, FailCount: 1, StarRating: 1},
+		{ID: "hard", PassCount: 1, FailCount: 4, StarRating: 3},
+		{ID: "hopeless", FailCount: 2, SkipCount: 1},
+		{ID: "new", StarRating: 3},
+		{ID: "retired", PassCount: 5, Retired: true, StarRating: 5},
+	}}
+	rollups := []types.DailyRollup{{Day: "2024-05-01", Reviews: 4, Passes: 3, Fails: 1}, {Day: "2024-05-02"}}
+	for day := 3; day <= 12; day++ {
+		rollups = append(rollups, types.DailyRollup{Day: fmt.Sprintf("2024-05-%02d", day), Reviews: 2, Passes: 1, Skips: 1})
+	}
+
+	analytics := buildDeckAnalytics(deck, rollups)
+
+	assert.Equal(t, 5, analytics.Cards)
+	assert.Equal(t, 1, analytics.NeverReviewed)
+	assert.Equal(t, 1, analytics.Retired)
+	assert.InDelta(t, 10.0/18.0*100, analytics.PassRate, 0.001)
+
+	assert.Len(t, analytics.PassRates, 5)
+	assert.Equal(t, 1, analytics.PassRates[0].Cards) // hopeless
+	assert.Equal(t, 1, analytics.PassRates[1].Cards) // hard, 20%
+	assert.Equal(t, 1, analytics.PassRates[4].Cards) // easy, 90%
+
+	assert.Equal(t, []types.StarCount{{Stars: 0, Cards: 1}, {Stars: 1, Cards: 1}, {Stars: 3, Cards: 2}}, analytics.Stars)
+
+	if assert.Len(t, analytics.HardestCards, 3) {
+		// Ranked by calculateCombinedRate, which caps cards without passes at 100
+		assert.Equal(t, "hard", analytics.HardestCards[0].CardID)
+		assert.Equal(t, 400.0, analytics.HardestCards[0].CombinedRate)
+		assert.Equal(t, "hopeless", analytics.HardestCards[1].CardID)
+		assert.Equal(t, "easy", analytics.HardestCards[2].CardID)
+	}
+
+	// The last 10 study days, oldest first
+	if assert.Len(t, analytics.Trend, trendDays) {
+		assert.Equal(t, "2024-05-03", analytics.Trend[0].Day)
+		assert.Equal(t, "2024-05-12", analytics.Trend[9].Day)
+		assert.Equal(t, 50.0, analytics.Trend[9].PassRate)
+	}
+	assert.Empty(t, buildDeckAnalytics(deck, []types.DailyRollup{{Day: "2024-05-02"}}).Trend) // Every review of the day was undone
+}
+
+func TestGetDeckAnalytics_OtherUsersDeck(t *testing.T) {
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	deckRepo.On("GetDeckByID", "deck1").Return(types.Deck{ID: "deck1", UserID: "someone"}, nil)
+
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	_, err := s.GetDeckAnalytics("deck1", "meow")
+	assert.EqualError(t, err, "deck not found")
+	sessionRepo.AssertNotCalled(t, "GetDailyRollups", "meow", "deck1")
+}
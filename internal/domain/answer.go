@@ -0,0 +1,174 @@
+// internal/domain/answer.go
+package domain
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode"
+
+	"github.com/robstave/meowmorize/internal/domain/types"
+	"golang.org/x/text/unicode/norm"
+)
+
+// strictnessRatios is the share of the expected answer's length that may be
+// wrong (measured as edit distance) for the answer to still count as correct
+var strictnessRatios = map[types.Strictness]float64{
+	types.ExactStrictness:   0,
+	types.StrictStrictness:  0.1,
+	types.NormalStrictness:  0.2,
+	types.LenientStrictness: 0.34,
+}
+
+// SubmitAnswer grades a typed answer for a card in a typed or LLM graded session
+// and records the result as a pass, fail or skip
+func (s *Service) SubmitAnswer(ctx context.Context, deckID string, cardID string, answer string, userID string) (types.AnswerGrade, error) {
+	s.sessionsMu.RLock()
+	session, exists := s.sessions[deckID]
+	var strictness types.Strictness
+	var mode types.SessionMode
+	var serving string
+	if exists {
+		strictness = session.Strictness
+		mode = session.Mode
+		serving = session.Serving
+	}
+	s.sessionsMu.RUnlock()
+
+	if !exists {
+		return types.AnswerGrade{}, errors.New("session does not exist for the given deck")
+	}
+	if !session.TypedAnswers() {
+		return types.AnswerGrade{}, errors.New("session is not in typed mode")
+	}
+	if cardID != serving {
+		return types.AnswerGrade{}, errors.New("card is not being served")
+	}
+
+	card, err := s.cardRepo.GetCardByID(cardID)
+	if err != nil {
+		s.logger.Error("Failed to retrieve card", "card_id", cardID, "error", err)
+		return types.AnswerGrade{}, err
+	}
+	if card == nil {
+		return types.AnswerGrade{}, errors.New("card not found")
+	}
+
+	var grade types.AnswerGrade
+	if mode == types.LLMGradedMode {
+		grade = s.gradeAnswerWithLLM(ctx, *card, answer, deckID, userID)
+		if grade.SelfGrade {
+			// Let the user grade the card through UpdateCardStats instead
+			s.sessionsMu.Lock()
+			session.SelfGradeCardID = cardID
+			s.sessionsMu.Unlock()
+			return grade, nil
+		}
+	} else {
+		grade = gradeAnswer(*card, answer, strictness)
+	}
+
+	details := reviewDetails{Answer: answer, Graded: true, Partial: grade.Verdict == types.PartialVerdict}
+	if err := s.updateCardStats(cardID, grade.Action, nil, deckID, userID, details); err != nil {
+		return types.AnswerGrade{}, err
+	}
+
+	s.logger.Info("Typed answer graded", "card_id", cardID, "deck_id", deckID, "correct", grade.Correct, "distance", grade.Distance)
+	return grade, nil
+}
+
+// gradeAnswer compares an answer against the back of the card and its
+// accepted alternates. The closest candidate wins. A blank answer is a skip.
+func gradeAnswer(card types.Card, answer string, strictness types.Strictness) types.AnswerGrade {
+	ratio, ok := strictnessRatios[strictness]
+	if !ok {
+		strictness = types.NormalStrictness
+		ratio = strictnessRatios[strictness]
+	}
+	foldAccents := strictness == types.NormalStrictness || strictness == types.LenientStrictness
+
+	grade := types.AnswerGrade{
+		CardID:   card.ID,
+		Answer:   answer,
+		Expected: card.Back.Text,
+		Action:   types.IncrementFail,
+	}
+
+	given := []rune(normalizeAnswer(answer, foldAccents))
+	if len(given) == 0 {
+		grade.Action = types.IncrementSkip
+		return grade
+	}
+
+	candidates := append([]string{card.Back.Text}, card.Alternates...)
+	bestSimilarity := -1.0
+	for i, candidate := range candidates {
+		expected := []rune(normalizeAnswer(candidate, foldAccents))
+		if len(expected) == 0 {
+			continue
+		}
+
+		distance := levenshtein(given, expected)
+		similarity := 1 - float64(distance)/float64(max(len(given), len(expected)))
+		correct := distance <= int(float64(len(expected))*ratio)
+
+		// Prefer a correct match, then the most similar candidate
+		if (correct && !grade.Correct) || (correct == grade.Correct && similarity > bestSimilarity) {
+			bestSimilarity = similarity
+			grade.Distance = distance
+			grade.Similarity = similarity
+			grade.Correct = correct
+			grade.MatchedAlt = i > 0
+		}
+	}
+
+	if grade.Correct {
+		grade.Action = types.IncrementPass
+	}
+	return grade
+}
+
+// normalizeAnswer lowercases the text, drops markdown and punctuation,
+// collapses whitespace and optionally strips accents
+func normalizeAnswer(text string, foldAccents bool) string {
+	if foldAccents {
+		text = norm.NFD.String(text)
+	}
+
+	var b strings.Builder
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case foldAccents && unicode.Is(unicode.Mn, r):
+			continue
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			b.WriteRune(' ')
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// levenshtein returns the edit distance between two rune slices
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
@@ -0,0 +1,191 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/robstave/meowmorize/internal/adapters/repositories/mocks"
+	"github.com/robstave/meowmorize/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGradeAnswer(t *testing.T) {
+	card := types.Card{
+		ID:         "card1",
+		Back:       types.CardBack{Text: "**Mitochondria**"},
+		Alternates: []string{"mitochondrion"},
+	}
+
+	tests := []struct {
+		name       string
+		answer     string
+		strictness types.Strictness
+		correct    bool
+		action     types.CardAction
+		matchedAlt bool
+	}{
+		{"exact match ignores case and markdown", "mitochondria", types.ExactStrictness, true, types.IncrementPass, false},
+		{"typo fails exact", "mitocondria", types.ExactStrictness, false, types.IncrementFail, false},
+		{"typo passes normal", "mitocondria", types.NormalStrictness, true, types.IncrementPass, false},
+		{"alternate accepted", "Mitochondrion", types.StrictStrictness, true, types.IncrementPass, true},
+		{"wrong answer fails lenient", "ribosome", types.LenientStrictness, false, types.IncrementFail, false},
+		{"blank answer is a skip", "   ", types.NormalStrictness, false, types.IncrementSkip, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grade := gradeAnswer(card, tt.answer, tt.strictness)
+			assert.Equal(t, tt.correct, grade.Correct)
+			assert.Equal(t, tt.action, grade.Action)
+			assert.Equal(t, tt.matchedAlt, grade.MatchedAlt)
+		})
+	}
+}
+
+func TestGradeAnswer_AlternateFlag(t *testing.T) {
+	card := types.Card{
+		ID:         "card1",
+		Back:       types.CardBack{Text: "Paris"},
+		Alternates: []string{"City of Light"},
+	}
+
+	grade := gradeAnswer(card, "city of light", types.ExactStrictness)
+	assert.True(t, grade.Correct)
+	assert.True(t, grade.MatchedAlt)
+	assert.Equal(t, 0, grade.Distance)
+}
+
+func TestGradeAnswer_Accents(t *testing.T) {
+	card := types.Card{ID: "card1", Back: types.CardBack{Text: "Café"}}
+
+	assert.False(t, gradeAnswer(card, "cafe", types.ExactStrictness).Correct)
+	assert.True(t, gradeAnswer(card, "cafe", types.NormalStrictness).Correct)
+}
+
+func TestLevenshtein(t *testing.T) {
+	assert.Equal(t, 0, levenshtein([]rune("paris"), []rune("paris")))
+	assert.Equal(t, 1, levenshtein([]rune("paris"), []rune("pari")))
+	assert.Equal(t, 3, levenshtein([]rune("kitten"), []rune("sitting")))
+	assert.Equal(t, 4, levenshtein([]rune(""), []rune("oslo")))
+}
+
+func TestSubmitAnswer_TypedSession(t *testing.T) {
+	deckID := uuid.New().String()
+	card := types.Card{
+		ID:     "card1",
+		UserID: "meow",
+		Front:  types.CardFront{Text: "Capital of Norway"},
+		Back:   types.CardBack{Text: "Oslo"},
+	}
+	deck := types.Deck{ID: deckID, Name: "Capitals", Cards: []types.Card{card}}
+
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	deckRepo.On("GetDeckByID", deckID).Return(deck, nil)
+	deckRepo.On("UpdateDeck", mock.AnythingOfType("types.Deck")).Return(nil)
+	cardRepo.On("GetCardByID", "card1").Return(&card, nil)
+	cardRepo.On("UpdateCard", mock.MatchedBy(func(c types.Card) bool {
+		return c.ID == "card1" && c.PassCount == 1
+	})).Return(nil)
+	sessionRepo.On("CreateLog", mock.MatchedBy(func(log types.SessionLog) bool {
+		return log.CardID == "card1" && log.Action == string(types.IncrementPass) && log.Answer == "osloo"
+	})).Return(nil)
+	sessionRepo.On("GetRecentResponseTimes", "card1", mock.Anything).Return(nil, nil).Maybe()
+
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	err := s.StartSession(deckID, -1, types.RandomMethod, "meow", types.SessionOptions{Mode: types.TypedMode, Strictness: types.LenientStrictness})
+	assert.NoError(t, err)
+	_, err = s.GetNextCard(deckID)
+	assert.NoError(t, err)
+
+	grade, err := s.SubmitAnswer(context.Background(), deckID, "card1", "osloo", "meow")
+	assert.NoError(t, err)
+	assert.True(t, grade.Correct)
+
+	cardRepo.AssertExpectations(t)
+	sessionRepo.AssertExpectations(t)
+}
+
+func TestSubmitAnswer_NotTypedSession(t *testing.T) {
+	deckID := uuid.New().String()
+	card := types.Card{ID: "card1", UserID: "meow", Back: types.CardBack{Text: "Oslo"}}
+	deck := types.Deck{ID: deckID, Cards: []types.Card{card}}
+
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	deckRepo.On("GetDeckByID", deckID).Return(deck, nil)
+	deckRepo.On("UpdateDeck", mock.AnythingOfType("types.Deck")).Return(nil)
+
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	err := s.StartSession(deckID, -1, types.RandomMethod, "meow", types.SessionOptions{})
+	assert.NoError(t, err)
+
+	_, err = s.SubmitAnswer(context.Background(), deckID, "card1", "Oslo", "meow")
+	assert.EqualError(t, err, "session is not in typed mode")
+}
+
+// startTypedSession starts a typed session on a deck with two cards
+func startTypedSession(t *testing.T) (MeowDomain, string, *mocks.CardRepository) {
+	deckID := uuid.New().String()
+	cards := []types.Card{
+		{ID: "card1", UserID: "meow", Back: types.CardBack{Text: "Oslo"}},
+		{ID: "card2", UserID: "meow", Back: types.CardBack{Text: "Bergen"}},
+	}
+	deck := types.Deck{ID: deckID, Cards: cards}
+
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	deckRepo.On("GetDeckByID", deckID).Return(deck, nil)
+	deckRepo.On("UpdateDeck", mock.AnythingOfType("types.Deck")).Return(nil)
+	cardRepo.On("GetCardByID", "card1").Return(&cards[0], nil)
+	cardRepo.On("GetCardByID", "card2").Return(&cards[1], nil)
+	cardRepo.On("GetCardByID", "card3").Return(&types.Card{ID: "card3", UserID: "meow"}, nil)
+
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	err := s.StartSession(deckID, -1, types.RandomMethod, "meow", types.SessionOptions{Mode: types.TypedMode})
+	assert.NoError(t, err)
+	return s, deckID, cardRepo
+}
+
+func TestSubmitAnswer_CardNotServed(t *testing.T) {
+	s, deckID, cardRepo := startTypedSession(t)
+
+	served, err := s.GetNextCard(deckID)
+	assert.NoError(t, err)
+	other := "card1"
+	if served == "card1" {
+		other = "card2"
+	}
+
+	_, err = s.SubmitAnswer(context.Background(), deckID, other, "Oslo", "meow")
+	assert.EqualError(t, err, "card is not being served")
+	cardRepo.AssertNotCalled(t, "UpdateCard", mock.Anything)
+}
+
+func TestUpdateCardStats_TypedSessionRejectsSelfGrade(t *testing.T) {
+	s, deckID, cardRepo := startTypedSession(t)
+
+	served, err := s.GetNextCard(deckID)
+	assert.NoError(t, err)
+
+	err = s.UpdateCardStats(served, types.IncrementPass, nil, deckID, "meow")
+	assert.EqualError(t, err, "session only takes typed answers")
+	cardRepo.AssertNotCalled(t, "UpdateCard", mock.Anything)
+}
+
+func TestUpdateCardStats_CardNotInSession(t *testing.T) {
+	s, deckID, cardRepo := startTypedSession(t)
+
+	// The session check runs before the card is changed
+	err := s.UpdateCardStats("card3", types.SetStars, nil, deckID, "meow")
+	assert.EqualError(t, err, "card not found in session")
+	cardRepo.AssertNotCalled(t, "UpdateCard", mock.Anything)
+}
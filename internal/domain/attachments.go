@@ -0,0 +1,231 @@
+// internal/domain/attachments.go
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/robstave/meowmorize/internal/domain/types"
+)
+
+// maxAttachmentBytes caps the size of a single uploaded file
+const maxAttachmentBytes = 10 << 20
+
+// attachmentGracePeriod keeps fresh uploads around long enough for the card
+// referencing them to be saved before garbage collection can remove them
+const attachmentGracePeriod = time.Hour
+
+var attachmentRefPattern = regexp.MustCompile(regexp.QuoteMeta(types.AttachmentURLPrefix) + `([0-9a-f]{64})`)
+
+// UploadAttachment stores an image or audio file and returns its metadata.
+// Uploading the same content twice returns the existing attachment.
+func (s *Service) UploadAttachment(userID string, filename string, content []byte) (types.Attachment, error) {
+	if len(content) > maxAttachmentBytes {
+		return types.Attachment{}, types.ErrAttachmentTooLarge
+	}
+
+	contentType, kind := detectAttachmentType(content)
+	if kind == "" {
+		s.logger.Warn("Rejected attachment upload", "filename", filename, "content_type", contentType)
+		return types.Attachment{}, types.ErrUnsupportedAttachment
+	}
+
+	sum := sha256.Sum256(content)
+	attachment := types.Attachment{
+		ID:          hex.EncodeToString(sum[:]),
+		UserID:      userID,
+		Filename:    filename,
+		ContentType: contentType,
+		Kind:        kind,
+		Size:        int64(len(content)),
+		CreatedAt:   time.Now(),
+	}
+
+	if err := s.attachmentRepo.SaveAttachment(attachment, content); err != nil {
+		s.logger.Error("Failed to save attachment", "attachment_id", attachment.ID, "error", err)
+		return types.Attachment{}, err
+	}
+
+	s.logger.Info("Attachment uploaded", "attachment_id", attachment.ID, "kind", kind, "size", attachment.Size)
+	return attachment, nil
+}
+
+// GetAttachment returns the attachment metadata and an open reader for its content.
+// Only users who uploaded the attachment can get it; for anyone else it is not found.
+// The caller must close the reader.
+func (s *Service) GetAttachment(id string, userID string) (*types.Attachment, io.ReadSeekCloser, error) {
+	attachment, err := s.attachmentRepo.GetAttachmentByID(id)
+	if err != nil {
+		s.logger.Error("Failed to retrieve attachment", "attachment_id", id, "error", err)
+		return nil, nil, err
+	}
+	if attachment == nil {
+		return nil, nil, errors.New("attachment not found")
+	}
+
+	owner, err := s.attachmentRepo.IsAttachmentOwner(id, userID)
+	if err != nil {
+		s.logger.Error("Failed to check attachment owner", "attachment_id", id, "error", err)
+		return nil, nil, err
+	}
+	if !owner {
+		s.logger.Warn("Attachment requested by another user", "attachment_id", id, "user_id", userID)
+		return nil, nil, errors.New("attachment not found")
+	}
+
+	content, err := s.attachmentRepo.OpenAttachment(id)
+	if err != nil {
+		s.logger.Error("Failed to open attachment content", "attachment_id", id, "error", err)
+		return nil, nil, err
+	}
+
+	return attachment, content, nil
+}
+
+// GarbageCollectAttachments deletes attachments that are no longer referenced
+// by any card (including its notes and hint), deck icon, card draft or pending
+// lint suggestion. It returns the number of attachments removed.
+func (s *Service) GarbageCollectAttachments() (int, error) {
+	decks, err := s.deckRepo.GetAllDecks()
+	if err != nil {
+		s.logger.Error("Failed to load decks for attachment GC", "error", err)
+		return 0, err
+	}
+
+	var texts []string
+	for _, deck := range decks {
+		texts = append(texts, deckTexts(deck)...)
+	}
+
+	// Drafts and suggestions become card text once accepted
+	if s.draftRepo != nil {
+		drafts, err := s.draftRepo.GetAllDrafts()
+		if err != nil {
+			s.logger.Error("Failed to load card drafts for attachment GC", "error", err)
+			return 0, err
+		}
+		for _, draft := range drafts {
+			texts = append(texts, draft.Front, draft.Back)
+		}
+	}
+	if s.lintRepo != nil {
+		findings, err := s.lintRepo.GetPendingFindings()
+		if err != nil {
+			s.logger.Error("Failed to load lint findings for attachment GC", "error", err)
+			return 0, err
+		}
+		for _, finding := range findings {
+			texts = append(texts, finding.SuggestedFront, finding.SuggestedBack)
+		}
+	}
+	referenced := referencedAttachments(texts...)
+
+	attachments, err := s.attachmentRepo.GetAllAttachments()
+	if err != nil {
+		s.logger.Error("Failed to list attachments for GC", "error", err)
+		return 0, err
+	}
+
+	cutoff := time.Now().Add(-attachmentGracePeriod)
+	removed := 0
+	for _, attachment := range attachments {
+		if referenced[attachment.ID] || attachment.CreatedAt.After(cutoff) {
+			continue
+		}
+		if err := s.attachmentRepo.DeleteAttachment(attachment.ID); err != nil {
+			s.logger.Error("Failed to delete unreferenced attachment", "attachment_id", attachment.ID, "error", err)
+			return removed, err
+		}
+		removed++
+	}
+
+	s.logger.Info("Attachment garbage collection finished", "checked", len(attachments), "removed", removed)
+	return removed, nil
+}
+
+// exportAttachments bundles the content of every attachment referenced by the deck
+func (s *Service) exportAttachments(deck types.Deck) ([]types.AttachmentData, error) {
+	var bundled []types.AttachmentData
+	for id := range referencedAttachments(deckTexts(deck)...) {
+		attachment, content, err := s.GetAttachment(id, deck.UserID)
+		if err != nil {
+			if err.Error() == "attachment not found" {
+				s.logger.Warn("Deck references a missing attachment", "deck_id", deck.ID, "attachment_id", id)
+				continue
+			}
+			return nil, err
+		}
+
+		data, err := io.ReadAll(content)
+		content.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		bundled = append(bundled, types.AttachmentData{
+			ID:          attachment.ID,
+			Filename:    attachment.Filename,
+			ContentType: attachment.ContentType,
+			Data:        data,
+		})
+	}
+	return bundled, nil
+}
+
+// importAttachments stores the attachments bundled with an imported deck.
+// The content must hash to the declared ID so references in the cards stay valid.
+func (s *Service) importAttachments(bundled []types.AttachmentData, userID string) error {
+	for _, data := range bundled {
+		attachment, err := s.UploadAttachment(userID, data.Filename, data.Data)
+		if err != nil {
+			return err
+		}
+		if attachment.ID != data.ID {
+			return fmt.Errorf("attachment %s content does not match its ID", data.ID)
+		}
+	}
+	return nil
+}
+
+// deckTexts returns every piece of deck text that may reference an attachment
+func deckTexts(deck types.Deck) []string {
+	texts := []string{deck.IconURL}
+	for _, card := range deck.Cards {
+		texts = append(texts, card.Front.Text, card.Back.Text, card.Notes, card.Hint)
+	}
+	return texts
+}
+
+// referencedAttachments extracts the attachment IDs referenced in the given texts
+func referencedAttachments(texts ...string) map[string]bool {
+	ids := map[string]bool{}
+	for _, text := range texts {
+		for _, match := range attachmentRefPattern.FindAllStringSubmatch(text, -1) {
+			ids[match[1]] = true
+		}
+	}
+	return ids
+}
+
+// detectAttachmentType sniffs the content and returns its content type and
+// attachment kind. The kind is empty for anything other than images and audio.
+func detectAttachmentType(content []byte) (string, string) {
+	contentType := http.DetectContentType(content)
+	switch {
+	case contentType == "application/ogg":
+		return "audio/ogg", types.AudioAttachment
+	case strings.HasPrefix(contentType, "audio/"):
+		return contentType, types.AudioAttachment
+	case strings.HasPrefix(contentType, "image/"):
+		return contentType, types.ImageAttachment
+	default:
+		return contentType, ""
+	}
+}
@@ -0,0 +1,164 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/robstave/meowmorize/internal/domain/types"
+	"github.com/robstave/meowmorize/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// pngHeader is enough of a PNG file for content sniffing
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestUploadAttachment_Image(t *testing.T) {
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+	attachmentRepo := setupAttachmentRepository()
+
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	attachmentRepo.On("SaveAttachment", mock.MatchedBy(func(a types.Attachment) bool {
+		return len(a.ID) == 64 && a.Kind == types.ImageAttachment && a.ContentType == "image/png" && a.UserID == "meow"
+	}), pngHeader).Return(nil)
+
+	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo, attachmentRepo, nil, nil, nil, nil, nil)
+	attachment, err := s.UploadAttachment("meow", "diagram.png", pngHeader)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(attachment.URL(), types.AttachmentURLPrefix))
+	attachmentRepo.AssertExpectations(t)
+}
+
+func TestUploadAttachment_RejectsOtherTypes(t *testing.T) {
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+	attachmentRepo := setupAttachmentRepository()
+
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+
+	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo, attachmentRepo, nil, nil, nil, nil, nil)
+	_, err := s.UploadAttachment("meow", "evil.svg", []byte(`<svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script></svg>`))
+	assert.ErrorIs(t, err, types.ErrUnsupportedAttachment)
+	attachmentRepo.AssertNotCalled(t, "SaveAttachment", mock.Anything, mock.Anything)
+}
+
+func TestGetAttachment_OtherUser(t *testing.T) {
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+	attachmentRepo := setupAttachmentRepository()
+
+	id := strings.Repeat("a", 64)
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	attachmentRepo.On("GetAttachmentByID", id).Return(&types.Attachment{ID: id, UserID: "meow"}, nil)
+	attachmentRepo.On("IsAttachmentOwner", id, "other").Return(false, nil)
+
+	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo, attachmentRepo, nil, nil, nil, nil, nil)
+	_, _, err := s.GetAttachment(id, "other")
+	assert.EqualError(t, err, "attachment not found")
+	attachmentRepo.AssertNotCalled(t, "OpenAttachment", id)
+}
+
+func TestGarbageCollectAttachments(t *testing.T) {
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+	attachmentRepo := setupAttachmentRepository()
+
+	kept := strings.Repeat("a", 64)
+	icon := strings.Repeat("b", 64)
+	orphan := strings.Repeat("c", 64)
+	fresh := strings.Repeat("d", 64)
+	old := time.Now().Add(-48 * time.Hour)
+
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	deckRepo.On("GetAllDecks").Return([]types.Deck{{
+		ID:      "deck1",
+		IconURL: types.AttachmentURLPrefix + icon,
+		Cards: []types.Card{{
+			ID:    "card1",
+			Front: types.CardFront{Text: "What is this? ![cell](" + types.AttachmentURLPrefix + kept + ")"},
+		}},
+	}}, nil)
+	attachmentRepo.On("GetAllAttachments").Return([]types.Attachment{
+		{ID: kept, CreatedAt: old},
+		{ID: icon, CreatedAt: old},
+		{ID: orphan, CreatedAt: old},
+		{ID: fresh, CreatedAt: time.Now()},
+	}, nil)
+	attachmentRepo.On("DeleteAttachment", orphan).Return(nil)
+
+	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo, attachmentRepo, nil, nil, nil, nil, nil)
+	removed, err := s.GarbageCollectAttachments()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	attachmentRepo.AssertExpectations(t)
+}
+
+func TestGarbageCollectAttachments_KeepsDraftsAndSuggestions(t *testing.T) {
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+	attachmentRepo := setupAttachmentRepository()
+	draftRepo := setupCardDraftRepository()
+	lintRepo := setupLintRepository()
+
+	drafted := strings.Repeat("a", 64)
+	suggested := strings.Repeat("b", 64)
+	orphan := strings.Repeat("c", 64)
+	old := time.Now().Add(-48 * time.Hour)
+
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	deckRepo.On("GetAllDecks").Return([]types.Deck{}, nil)
+	draftRepo.On("GetAllDrafts").Return([]types.CardDraft{
+		{ID: "d1", Front: "What is this?", Back: "![cell](" + types.AttachmentURLPrefix + drafted + ")"},
+	}, nil)
+	lintRepo.On("GetPendingFindings").Return([]types.LintFinding{
+		{ID: "f1", SuggestedFront: "Which organelle? ![cell](" + types.AttachmentURLPrefix + suggested + ")"},
+	}, nil)
+	attachmentRepo.On("GetAllAttachments").Return([]types.Attachment{
+		{ID: drafted, CreatedAt: old},
+		{ID: suggested, CreatedAt: old},
+		{ID: orphan, CreatedAt: old},
+	}, nil)
+	attachmentRepo.On("DeleteAttachment", orphan).Return(nil)
+
+	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo, attachmentRepo, draftRepo, nil, nil, nil, lintRepo)
+	removed, err := s.GarbageCollectAttachments()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	attachmentRepo.AssertExpectations(t)
+}
+
+func TestGarbageCollectAttachments_KeepsNotesAndHints(t *testing.T) {
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+	attachmentRepo := setupAttachmentRepository()
+
+	noted := strings.Repeat("a", 64)
+	hinted := strings.Repeat("b", 64)
+	orphan := strings.Repeat("c", 64)
+	old := time.Now().Add(-48 * time.Hour)
+
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	deckRepo.On("GetAllDecks").Return([]types.Deck{{
+		ID: "deck1",
+		Cards: []types.Card{{
+			ID:    "card1",
+			Front: types.CardFront{Text: "What is this?"},
+			Notes: "See the diagram ![cell](" + types.AttachmentURLPrefix + noted + ")",
+			Hint:  "Listen: ![meow](" + types.AttachmentURLPrefix + hinted + ")",
+		}},
+	}}, nil)
+	attachmentRepo.On("GetAllAttachments").Return([]types.Attachment{
+		{ID: noted, CreatedAt: old},
+		{ID: hinted, CreatedAt: old},
+		{ID: orphan, CreatedAt: old},
+	}, nil)
+	attachmentRepo.On("DeleteAttachment", orphan).Return(nil)
+
+	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo, attachmentRepo, nil, nil, nil, nil, nil)
+	removed, err := s.GarbageCollectAttachments()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	attachmentRepo.AssertExpectations(t)
+}
@@ -52,16 +52,25 @@ func (s *Service) UpdateCard(card types.Card) error {
 		return err
 	}
 
+	// Translations of the card need redoing once the text they translate changes
+	textChanged := existingCard.Front != card.Front || existingCard.Back != card.Back || existingCard.Hint != card.Hint
+
 	// Update fields
 	existingCard.Front = card.Front
 	existingCard.Back = card.Back
 	existingCard.Link = card.Link
+	existingCard.Alternates = card.Alternates
+	existingCard.Notes = card.Notes
+	existingCard.Hint = card.Hint
 
 	// Save the updated card
 	if err := s.cardRepo.UpdateCard(*existingCard); err != nil {
 		s.logger.Error("Failed to update card", "card_id", card.ID, "error", err)
 		return err
 	}
+	if textChanged {
+		s.markTranslationsStale(card.ID)
+	}
 
 	s.logger.Info("Card updated successfully", "card_id", card.ID)
 	return nil
@@ -90,6 +99,11 @@ func (s *Service) CloneCardToDeck(cardID string, targetDeckID string) (*types.Ca
 
 // UpdateCardStats updates the card based on the provided action
 func (s *Service) UpdateCardStats(cardID string, action types.CardAction, value *int, deckID string, userID string) error {
+	return s.updateCardStats(cardID, action, value, deckID, userID, reviewDetails{})
+}
+
+// updateCardStats updates the card and the session, recording the review details on the session log
+func (s *Service) updateCardStats(cardID string, action types.CardAction, value *int, deckID string, userID string, details reviewDetails) error {
 	card, err := s.cardRepo.GetCardByID(cardID)
 	if err != nil {
 		s.logger.Error("Failed to retrieve card", "card_id", cardID, "error", err)
@@ -99,32 +113,80 @@ func (s *Service) UpdateCardStats(cardID string, action types.CardAction, value
 		return errors.New("card not found")
 	}
 
+	// Check the session takes the review before the card is changed
+	if err := s.checkSessionReview(deckID, cardID, action, details.Graded); err != nil {
+		return err
+	}
+
+	switch action {
+	case types.IncrementFail, types.IncrementPass, types.IncrementSkip:
+		details.ServedAt, details.ResponseMs = s.responseTiming(deckID, cardID)
+		details.HintLevel = s.sessionHintLevel(deckID, cardID)
+		previous := *card
+		details.PreviousCard = &previous
+	}
+
+	now := time.Now()
+
 	switch action {
 	case types.IncrementFail:
 		card.FailCount++
 	case types.IncrementPass:
 		card.PassCount++
+		// A hinted pass is not also counted as slow, so the two counts never overlap
+		if details.HintLevel > 0 {
+			card.HintedPassCount++
+		} else if details.ResponseMs > slowPassThreshold.Milliseconds() {
+			card.SlowPassCount++
+		}
 	case types.IncrementSkip:
 		card.SkipCount++
 	case types.SetStars:
 		if value != nil {
+			previousStars, stars := card.StarRating, *value
+			details.PreviousStars, details.Stars = &previousStars, &stars
 			card.StarRating = *value
 		}
 
 	case types.Retire:
-		card.Retired = true
+		retireCard(card, now)
 	case types.Unretire:
-		card.Retired = false
+		unretireCard(card, now)
+		if card.Leech {
+			clearLeech(card, now)
+		}
+	case types.ClearLeech:
+		clearLeech(card, now)
 	case types.ResetStats:
 		card.FailCount = 0
 		card.PassCount = 0
 		card.SkipCount = 0
+		card.SlowPassCount = 0
+		card.HintedPassCount = 0
+		card.IntervalDays = 0
+		card.DueAt = time.Time{}
+		clearLeech(card, now)
 	default:
 		return fmt.Errorf("unknown action: %s", action)
 	}
 
+	if card.Retired {
+		scheduleRetiredCard(card, action, now)
+	} else {
+		scheduleCard(card, action, gradePass(details), now)
+	}
+	if action == types.IncrementFail {
+		s.checkLeech(card, userID)
+	}
+
+	// Skips are not answers, so only passes and fails feed the median
+	if details.ResponseMs > 0 && (action == types.IncrementPass || action == types.IncrementFail) {
+		card.MedianResponseMs = s.medianResponseMs(cardID, details.ResponseMs)
+	}
+	details.MedianResponseMs = card.MedianResponseMs
+
 	// Update the ReviewedAt timestamp
-	card.ReviewedAt = time.Now()
+	card.ReviewedAt = now
 
 	// Update the UpdatedAt timestamp is handled by GORM automatically
 
@@ -134,7 +196,7 @@ func (s *Service) UpdateCardStats(cardID string, action types.CardAction, value
 		return err
 	}
 
-	err = s.AdjustSession(deckID, cardID, action, card.StarRating, userID)
+	err = s.adjustSession(deckID, cardID, action, card.StarRating, userID, details)
 	if err != nil {
 		s.logger.Error("Failed to update session", "card_id", cardID, "deck_id", deckID, "error", err)
 		return err
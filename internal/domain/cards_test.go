@@ -5,7 +5,6 @@ import (
 	"testing"
 
 	"github.com/robstave/meowmorize/internal/domain/types"
-	"github.com/robstave/meowmorize/internal/logger"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -34,7 +33,7 @@ func TestCardService_GetCardDetails_Success(t *testing.T) {
 		return u.Username != ""
 	})).Return(nil, nil)
 
-	dm := NewService(logger.InitializeLogger(), dr, cardRepo, userRepo, sessionRepo, llmRepo)
+	dm := newTestService(dr, cardRepo, userRepo, sessionRepo, llmRepo)
 
 	card, err := dm.GetCardByID("card1")
 	assert.NoError(t, err)
@@ -58,7 +57,7 @@ func TestCardService_GetCardDetails_NotFound(t *testing.T) {
 	// Simulate not finding the card.
 	cardRepo.On("GetCardByID", "non-existent").Return(nil, nil)
 
-	dm := NewService(logger.InitializeLogger(), dr, cardRepo, userRepo, sessionRepo, llmRepo)
+	dm := newTestService(dr, cardRepo, userRepo, sessionRepo, llmRepo)
 	card, err := dm.GetCardByID("non-existent")
 	assert.Error(t, err)
 	assert.Nil(t, card)
@@ -85,7 +84,7 @@ func TestCardService_CreateCard_Success(t *testing.T) {
 	cardRepo.On("CreateCard", mock.AnythingOfType("types.Card")).Return(nil)
 	dr.On("AddCardToDeck", "deck1", mock.AnythingOfType("types.Card")).Return(nil)
 
-	dm := NewService(logger.InitializeLogger(), dr, cardRepo, userRepo, sessionRepo, llmRepo)
+	dm := newTestService(dr, cardRepo, userRepo, sessionRepo, llmRepo)
 	createdCard, err := dm.CreateCard(newCard, "deck1", "user1")
 	assert.NoError(t, err)
 	assert.NotNil(t, createdCard)
@@ -117,8 +116,10 @@ func TestCardService_UpdateCard_Success(t *testing.T) {
 	// Expect retrieval of the card and its subsequent update.
 	cardRepo.On("GetCardByID", "card123").Return(existingCard, nil)
 	cardRepo.On("UpdateCard", mock.AnythingOfType("types.Card")).Return(nil)
+	// The text changed, so its translations need redoing
+	cardRepo.On("MarkTranslationsStale", "card123").Return(nil)
 
-	dm := NewService(logger.InitializeLogger(), dr, cardRepo, userRepo, sessionRepo, llmRepo)
+	dm := newTestService(dr, cardRepo, userRepo, sessionRepo, llmRepo)
 
 	updatedCard := types.Card{
 		ID:    "card123",
@@ -133,6 +134,23 @@ func TestCardService_UpdateCard_Success(t *testing.T) {
 	userRepo.AssertExpectations(t)
 }
 
+func TestCardService_UpdateCard_HintChanged(t *testing.T) {
+	cardRepo, userRepo, dr, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+
+	existingCard := &types.Card{ID: "card123", Front: types.CardFront{Text: "Front"}, Back: types.CardBack{Text: "Back"}, Hint: "Old hint"}
+	cardRepo.On("GetCardByID", "card123").Return(existingCard, nil)
+	cardRepo.On("UpdateCard", mock.AnythingOfType("types.Card")).Return(nil)
+	// Translations include the hint, so they need redoing too
+	cardRepo.On("MarkTranslationsStale", "card123").Return(nil).Once()
+
+	dm := newTestService(dr, cardRepo, userRepo, sessionRepo, llmRepo)
+	err := dm.UpdateCard(types.Card{ID: "card123", Front: types.CardFront{Text: "Front"}, Back: types.CardBack{Text: "Back"}, Hint: "New hint"})
+	assert.NoError(t, err)
+	cardRepo.AssertExpectations(t)
+}
+
 func TestCardService_DeleteCardByID_Success(t *testing.T) {
 	cardRepo, userRepo, dr, sessionRepo := setupRepositories()
 	llmRepo := setupLLMRepository()
@@ -144,7 +162,7 @@ func TestCardService_DeleteCardByID_Success(t *testing.T) {
 	// Expect deletion of the card.
 	cardRepo.On("DeleteCardByID", "cardToDelete").Return(nil)
 
-	dm := NewService(logger.InitializeLogger(), dr, cardRepo, userRepo, sessionRepo, llmRepo)
+	dm := newTestService(dr, cardRepo, userRepo, sessionRepo, llmRepo)
 	err := dm.DeleteCardByID("cardToDelete")
 	assert.NoError(t, err)
 
@@ -167,7 +185,7 @@ func TestCardService_CloneCardToDeck_Success(t *testing.T) {
 	cardRepo.On("CloneCardToDeck", "cardOriginal", "deckTarget").Return(clonedCard, nil)
 	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
 
-	dm := NewService(logger.InitializeLogger(), dr, cardRepo, userRepo, sessionRepo, llmRepo)
+	dm := newTestService(dr, cardRepo, userRepo, sessionRepo, llmRepo)
 	result, err := dm.CloneCardToDeck("cardOriginal", "deckTarget")
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -199,7 +217,7 @@ func TestCardService_UpdateCardStats_Success(t *testing.T) {
 		return updatedCard.ID == "cardStats1" && updatedCard.PassCount == 1
 	})).Return(nil)
 
-	dm := NewService(logger.InitializeLogger(), dr, cardRepo, userRepo, sessionRepo, llmRepo)
+	dm := newTestService(dr, cardRepo, userRepo, sessionRepo, llmRepo)
 	err := dm.UpdateCardStats("cardStats1", types.IncrementPass, nil, "deckDummy", "meow")
 	assert.NoError(t, err)
 	cardRepo.AssertExpectations(t)
@@ -13,6 +13,14 @@ func (s *Service) CreateDeck(deck types.Deck) error {
 			"back", card.Back.Text)
 	}
 
+	// Store any media bundled with an imported deck before the cards referencing it
+	if len(deck.Attachments) > 0 {
+		if err := s.importAttachments(deck.Attachments, deck.UserID); err != nil {
+			s.logger.Error("Failed to import deck attachments", "error", err)
+			return err
+		}
+	}
+
 	err := s.deckRepo.CreateDeck(deck)
 	if err != nil {
 		s.logger.Error("Failed to create deck", "error", err)
@@ -6,7 +6,6 @@ import (
 	"testing"
 
 	"github.com/robstave/meowmorize/internal/domain/types"
-	"github.com/robstave/meowmorize/internal/logger"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -37,7 +36,7 @@ func TestCreateDeck_Success(t *testing.T) {
 	deckRepo.On("CreateDeck", testDeck).Return(nil)
 	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
 
-	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
 	err := s.CreateDeck(testDeck)
 	assert.NoError(t, err)
 	deckRepo.AssertExpectations(t)
@@ -58,7 +57,7 @@ func TestCreateDeck_Failure(t *testing.T) {
 	deckRepo.On("CreateDeck", testDeck).Return(expectedErr)
 	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
 
-	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
 	err := s.CreateDeck(testDeck)
 	assert.Error(t, err)
 	assert.Equal(t, expectedErr, err)
@@ -73,7 +72,7 @@ func TestDeleteDeck_Success(t *testing.T) {
 	deckRepo.On("DeleteDeck", deckID).Return(nil)
 	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
 
-	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
 	err := s.DeleteDeck(deckID)
 	assert.NoError(t, err)
 	deckRepo.AssertExpectations(t)
@@ -88,7 +87,7 @@ func TestDeleteDeck_Failure(t *testing.T) {
 	deckRepo.On("DeleteDeck", deckID).Return(expectedErr)
 	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
 
-	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
 	err := s.DeleteDeck(deckID)
 	assert.Error(t, err)
 	assert.Equal(t, expectedErr, err)
@@ -109,7 +108,7 @@ func TestGetDeckByID_Success(t *testing.T) {
 	deckRepo.On("GetDeckByID", "deck1").Return(expectedDeck, nil)
 	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
 
-	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
 	deck, err := s.GetDeckByID("deck1")
 	assert.NoError(t, err)
 	assert.Equal(t, expectedDeck, deck)
@@ -124,7 +123,7 @@ func TestGetDeckByID_Failure(t *testing.T) {
 	deckRepo.On("GetDeckByID", "nonexistent").Return(types.Deck{}, expectedErr)
 	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
 
-	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
 	deck, err := s.GetDeckByID("nonexistent")
 	assert.Error(t, err)
 	assert.Equal(t, expectedErr, err)
@@ -154,7 +153,7 @@ func TestGetAllDecks_Success(t *testing.T) {
 	deckRepo.On("GetAllDecksByUser", "user1").Return(expectedDecks, nil)
 	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
 
-	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
 	decks, err := s.GetAllDecks("user1")
 	assert.NoError(t, err)
 	assert.Equal(t, expectedDecks, decks)
@@ -175,7 +174,7 @@ func TestUpdateDeck_Success(t *testing.T) {
 	deckRepo.On("UpdateDeck", testDeck).Return(nil)
 	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
 
-	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
 	err := s.UpdateDeck(testDeck)
 	assert.NoError(t, err)
 	deckRepo.AssertExpectations(t)
@@ -196,7 +195,7 @@ func TestUpdateDeck_Failure(t *testing.T) {
 	deckRepo.On("UpdateDeck", testDeck).Return(expectedErr)
 	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
 
-	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
 	err := s.UpdateDeck(testDeck)
 	assert.Error(t, err)
 	assert.Equal(t, expectedErr, err)
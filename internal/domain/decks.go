@@ -7,13 +7,21 @@ import (
 	"github.com/robstave/meowmorize/internal/domain/types"
 )
 
-// ExportDeck retrieves the deck by ID for exporting
+// ExportDeck retrieves the deck by ID for exporting, bundling any referenced attachments
 func (s *Service) ExportDeck(deckID string) (types.Deck, error) {
 	deck, err := s.deckRepo.GetDeckByID(deckID)
 	if err != nil {
 		s.logger.Error("Failed to export deck", "deck_id", deckID, "error", err)
 		return types.Deck{}, err
 	}
+
+	attachments, err := s.exportAttachments(deck)
+	if err != nil {
+		s.logger.Error("Failed to export deck attachments", "deck_id", deckID, "error", err)
+		return types.Deck{}, err
+	}
+	deck.Attachments = attachments
+
 	return deck, nil
 }
 
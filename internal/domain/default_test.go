@@ -5,7 +5,6 @@ import (
 	"testing"
 
 	"github.com/robstave/meowmorize/internal/domain/types"
-	"github.com/robstave/meowmorize/internal/logger"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -21,7 +20,7 @@ func TestCreateDefaultDeck_WithData(t *testing.T) {
 		savedDeck = args.Get(0).(types.Deck)
 	})
 
-	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
 	deck, err := s.CreateDefaultDeck(true, "user1")
 	assert.NoError(t, err)
 	assert.Equal(t, "user1", deck.UserID)
@@ -42,7 +41,7 @@ func TestCreateDefaultDeck_NoData(t *testing.T) {
 		savedDeck = args.Get(0).(types.Deck)
 	})
 
-	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
 	deck, err := s.CreateDefaultDeck(false, "user2")
 	assert.NoError(t, err)
 	assert.Equal(t, "user2", deck.UserID)
@@ -58,7 +57,7 @@ func TestCreateDefaultDeck_Error(t *testing.T) {
 
 	deckRepo.On("CreateDeck", mock.AnythingOfType("types.Deck")).Return(errors.New("fail"))
 
-	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
 	deck, err := s.CreateDefaultDeck(false, "user3")
 	assert.Error(t, err)
 	assert.NotEmpty(t, deck.ID)
@@ -0,0 +1,134 @@
+// internal/domain/events.go
+package domain
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/robstave/meowmorize/internal/domain/types"
+)
+
+// eventBufferSize is how many events a slow subscriber may fall behind before events are dropped
+const eventBufferSize = 32
+
+// eventBus fans session events out to the subscribers of each session
+type eventBus struct {
+	mu          sync.Mutex
+	subscribers map[string]map[chan types.SessionEvent]struct{}
+}
+
+func newEventBus() *eventBus {
+	return &eventBus{subscribers: make(map[string]map[chan types.SessionEvent]struct{})}
+}
+
+// subscribe registers a subscriber for the session. The returned function
+// unsubscribes and must be called once the subscriber is done.
+func (b *eventBus) subscribe(sessionID string) (chan types.SessionEvent, func()) {
+	ch := make(chan types.SessionEvent, eventBufferSize)
+
+	b.mu.Lock()
+	if b.subscribers[sessionID] == nil {
+		b.subscribers[sessionID] = make(map[chan types.SessionEvent]struct{})
+	}
+	b.subscribers[sessionID][ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			if subs, ok := b.subscribers[sessionID]; ok {
+				if _, ok := subs[ch]; ok {
+					delete(subs, ch)
+					close(ch)
+				}
+				if len(subs) == 0 {
+					delete(b.subscribers, sessionID)
+				}
+			}
+		})
+	}
+}
+
+// publish delivers the event without blocking; subscribers that are too far
+// behind miss it and catch up with the next event's stats
+func (b *eventBus) publish(event types.SessionEvent) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	dropped := 0
+	for ch := range b.subscribers[event.SessionID] {
+		select {
+		case ch <- event:
+		default:
+			dropped++
+		}
+	}
+	return dropped
+}
+
+// closeSession ends every subscription to the session
+func (b *eventBus) closeSession(sessionID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers[sessionID] {
+		close(ch)
+	}
+	delete(b.subscribers, sessionID)
+}
+
+// publishSessionEvent publishes an event with a copy of the session's current stats
+func (s *Service) publishSessionEvent(session *types.Session, eventType types.SessionEventType, cardID string, action types.CardAction) {
+	event := sessionEvent(session, eventType, cardID, action)
+	if dropped := s.events.publish(event); dropped > 0 {
+		s.logger.Warn("Dropped session event for slow subscribers", "session_id", session.SessionID, "type", eventType, "dropped", dropped)
+	}
+}
+
+// sessionEvent builds an event from the session's current stats
+func sessionEvent(session *types.Session, eventType types.SessionEventType, cardID string, action types.CardAction) types.SessionEvent {
+	stats := session.GetSessionStats()
+	// Subscribers read the stats after the session moves on, so they get their own copy
+	stats.CardStats = append([]types.CardStats(nil), stats.CardStats...)
+
+	return types.SessionEvent{
+		Type:      eventType,
+		DeckID:    session.DeckID,
+		SessionID: session.SessionID,
+		CardID:    cardID,
+		Action:    action,
+		Stats:     stats,
+		Timestamp: time.Now(),
+	}
+}
+
+// SubscribeSessionEvents follows a session by its ID. The first event is a snapshot
+// of the current state. The channel is closed when the session is cleared or replaced.
+// The returned function unsubscribes and must be called once the caller is done.
+// Only the user who started the session may follow it.
+func (s *Service) SubscribeSessionEvents(sessionID string, username string) (<-chan types.SessionEvent, func(), error) {
+	s.sessionsMu.RLock()
+	defer s.sessionsMu.RUnlock()
+
+	var session *types.Session
+	for _, candidate := range s.sessions {
+		if candidate.SessionID == sessionID {
+			session = candidate
+			break
+		}
+	}
+	// Another user's session is reported as missing, so IDs cannot be probed
+	if session == nil || session.UserID != username {
+		return nil, nil, errors.New("session not found")
+	}
+
+	// Holding the sessions lock keeps the snapshot and the following events in order
+	ch, unsubscribe := s.events.subscribe(sessionID)
+	ch <- sessionEvent(session, types.SnapshotEvent, "", "")
+
+	s.logger.Info("Session event subscriber connected", "session_id", sessionID)
+	return ch, unsubscribe, nil
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/robstave/meowmorize/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSubscribeSessionEvents(t *testing.T) {
+	deckID := uuid.New().String()
+	card := types.Card{ID: "card1", UserID: "meow"}
+	deck := types.Deck{ID: deckID, Name: "Capitals", Cards: []types.Card{card}}
+
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	deckRepo.On("GetDeckByID", deckID).Return(deck, nil)
+	deckRepo.On("UpdateDeck", mock.AnythingOfType("types.Deck")).Return(nil)
+	cardRepo.On("GetCardByID", "card1").Return(&card, nil)
+	cardRepo.On("UpdateCard", mock.AnythingOfType("types.Card")).Return(nil)
+	sessionRepo.On("CreateLog", mock.AnythingOfType("types.SessionLog")).Return(nil)
+
+	dm := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	assert.NoError(t, dm.StartSession(deckID, -1, types.RandomMethod, "meow", types.SessionOptions{GoalCorrect: 2}))
+
+	stats, err := dm.GetSessionStats(deckID)
+	assert.NoError(t, err)
+
+	// Other users cannot follow the session
+	_, _, err = dm.SubscribeSessionEvents(stats.SessionID, "someone")
+	assert.EqualError(t, err, "session not found")
+
+	events, unsubscribe, err := dm.SubscribeSessionEvents(stats.SessionID, "meow")
+	assert.NoError(t, err)
+	defer unsubscribe()
+
+	snapshot := <-events
+	assert.Equal(t, types.SnapshotEvent, snapshot.Type)
+	assert.Equal(t, 1, snapshot.Stats.Remaining)
+
+	_, err = dm.GetNextCard(deckID)
+	assert.NoError(t, err)
+	served := <-events
+	assert.Equal(t, types.CardServedEvent, served.Type)
+	assert.Equal(t, "card1", served.CardID)
+
+	assert.NoError(t, dm.UpdateCardStats("card1", types.IncrementPass, nil, deckID, "meow"))
+	graded := <-events
+	assert.Equal(t, types.CardGradedEvent, graded.Type)
+	assert.Equal(t, types.IncrementPass, graded.Action)
+	assert.True(t, graded.Stats.CardStats[0].Passed)
+
+	_, err = dm.GetNextCard(deckID)
+	assert.NoError(t, err)
+	assert.Equal(t, types.ReshuffleEvent, (<-events).Type)
+	assert.Equal(t, types.CardServedEvent, (<-events).Type)
+
+	// The second pass reaches the goal
+	assert.NoError(t, dm.UpdateCardStats("card1", types.IncrementPass, nil, deckID, "meow"))
+	assert.Equal(t, types.CardGradedEvent, (<-events).Type)
+	_, err = dm.GetNextCard(deckID)
+	assert.ErrorIs(t, err, types.ErrSessionComplete)
+	complete := <-events
+	assert.Equal(t, types.SessionCompleteEvent, complete.Type)
+	assert.True(t, complete.Stats.GoalMet)
+
+	// Clearing the session ends the stream
+	assert.NoError(t, dm.ClearSession(deckID))
+	_, open := <-events
+	assert.False(t, open)
+}
+
+func TestSubscribeSessionEvents_UnknownSession(t *testing.T) {
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+
+	dm := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	_, _, err := dm.SubscribeSessionEvents("missing", "meow")
+	assert.EqualError(t, err, "session not found")
+}
@@ -0,0 +1,188 @@
+// internal/domain/explanation.go
+package domain
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/robstave/meowmorize/internal/domain/types"
+)
+
+// threadContextTurns is how many of the latest turns of a thread go along with a follow-up question
+const threadContextTurns = 10
+
+// ExplainCard asks the LLM the user's question about the card, with the earlier turns of the
+// user's thread on the card as context, and adds the exchange to the thread
+func (s *Service) ExplainCard(ctx context.Context, cardID string, username string, question string) (types.ExplanationTurn, error) {
+	return s.explainCard(ctx, cardID, username, question, nil)
+}
+
+// StreamExplainCard is ExplainCard with the answer passed to onChunk as it is generated.
+// The exchange is only added to the thread once the answer is complete.
+func (s *Service) StreamExplainCard(ctx context.Context, cardID string, username string, question string, onChunk func(chunk string) error) (types.ExplanationTurn, error) {
+	return s.explainCard(ctx, cardID, username, question, onChunk)
+}
+
+// explainCard asks the question, streaming the answer when onChunk is set
+func (s *Service) explainCard(ctx context.Context, cardID string, username string, question string, onChunk func(chunk string) error) (types.ExplanationTurn, error) {
+	card, err := s.cardRepo.GetCardByID(cardID)
+	if err != nil {
+		s.logger.Error("Failed to retrieve card", "card_id", cardID, "error", err)
+		return types.ExplanationTurn{}, err
+	}
+	if card == nil {
+		return types.ExplanationTurn{}, errors.New("card not found")
+	}
+
+	turns, err := s.threadRepo.GetThread(cardID, username)
+	if err != nil {
+		s.logger.Error("Failed to retrieve explanation thread", "card_id", cardID, "error", err)
+		return types.ExplanationTurn{}, err
+	}
+	if len(turns) > threadContextTurns {
+		turns = turns[len(turns)-threadContextTurns:]
+	}
+
+	prompt, err := s.renderPrompt(username, types.LLMPurposeExplain, types.PromptData{
+		Front:    card.Front.Text,
+		Back:     card.Back.Text,
+		Deck:     s.cardDeckName(username, cardID),
+		Question: question,
+		Turns:    turns,
+	})
+	if err != nil {
+		return types.ExplanationTurn{}, err
+	}
+	s.logger.Info("Getting LLM explanation", "card_id", cardID, "prompt_length", len(prompt.text), "streaming", onChunk != nil)
+
+	answer, err := s.runPrompt(ctx, username, prompt, onChunk)
+	if err != nil {
+		s.logger.Error("Failed to get LLM explanation", "card_id", cardID, "error", err)
+		return types.ExplanationTurn{}, err
+	}
+
+	turn := types.ExplanationTurn{
+		ID:        uuid.New().String(),
+		CardID:    cardID,
+		UserID:    username,
+		Question:  question,
+		Answer:    answer,
+		CreatedAt: time.Now(),
+	}
+	if err := s.threadRepo.AddTurn(turn); err != nil {
+		s.logger.Error("Failed to save explanation turn", "card_id", cardID, "error", err)
+		return types.ExplanationTurn{}, err
+	}
+	return turn, nil
+}
+
+// GetExplanationThreads lists the user's threads, most recently active first
+func (s *Service) GetExplanationThreads(username string) ([]types.ExplanationThreadSummary, error) {
+	threads, err := s.threadRepo.GetThreadsByUser(username)
+	if err != nil {
+		s.logger.Error("Failed to retrieve explanation threads", "username", username, "error", err)
+		return nil, err
+	}
+	if threads == nil {
+		threads = []types.ExplanationThreadSummary{}
+	}
+	return threads, nil
+}
+
+// GetExplanationThread returns the user's thread on the card. A card nobody asked about
+// has an empty thread.
+func (s *Service) GetExplanationThread(cardID string, username string) (types.ExplanationThread, error) {
+	turns, err := s.threadRepo.GetThread(cardID, username)
+	if err != nil {
+		s.logger.Error("Failed to retrieve explanation thread", "card_id", cardID, "error", err)
+		return types.ExplanationThread{}, err
+	}
+	if turns == nil {
+		turns = []types.ExplanationTurn{}
+	}
+	return types.ExplanationThread{CardID: cardID, Turns: turns}, nil
+}
+
+// DeleteExplanationThread removes the user's thread on the card
+func (s *Service) DeleteExplanationThread(cardID string, username string) error {
+	deleted, err := s.threadRepo.DeleteThread(cardID, username)
+	if err != nil {
+		s.logger.Error("Failed to delete explanation thread", "card_id", cardID, "error", err)
+		return err
+	}
+	if deleted == 0 {
+		return errors.New("thread not found")
+	}
+	return nil
+}
+
+// PromoteExplanation appends the answer of a turn to the back or the notes of its card
+func (s *Service) PromoteExplanation(turnID string, username string, target string) (*types.Card, error) {
+	if target != types.PromoteToBack && target != types.PromoteToNotes {
+		return nil, errors.New("promote target must be back or notes")
+	}
+
+	turn, err := s.threadRepo.GetTurnByID(turnID)
+	if err != nil {
+		s.logger.Error("Failed to retrieve explanation turn", "turn_id", turnID, "error", err)
+		return nil, err
+	}
+	if turn == nil || turn.UserID != username {
+		return nil, errors.New("explanation not found")
+	}
+
+	card, err := s.cardRepo.GetCardByID(turn.CardID)
+	if err != nil {
+		s.logger.Error("Failed to retrieve card", "card_id", turn.CardID, "error", err)
+		return nil, err
+	}
+	if card == nil {
+		return nil, errors.New("card not found")
+	}
+
+	if target == types.PromoteToBack {
+		card.Back.Text = appendParagraph(card.Back.Text, turn.Answer)
+	} else {
+		card.Notes = appendParagraph(card.Notes, turn.Answer)
+	}
+
+	if err := s.cardRepo.UpdateCard(*card); err != nil {
+		s.logger.Error("Failed to update card", "card_id", card.ID, "error", err)
+		return nil, err
+	}
+	if target == types.PromoteToBack {
+		s.markTranslationsStale(card.ID)
+	}
+
+	s.logger.Info("Explanation promoted", "turn_id", turnID, "card_id", card.ID, "target", target)
+	return card, nil
+}
+
+// cardDeckName returns the name of a deck of the user's holding the card, empty when
+// there is none
+func (s *Service) cardDeckName(username string, cardID string) string {
+	decks, err := s.deckRepo.GetAllDecksByUser(username)
+	if err != nil {
+		s.logger.Warn("Failed to retrieve decks", "username", username, "error", err)
+		return ""
+	}
+	for _, deck := range decks {
+		for _, card := range deck.Cards {
+			if card.ID == cardID {
+				return deck.Name
+			}
+		}
+	}
+	return ""
+}
+
+// appendParagraph adds the text as a new paragraph
+func appendParagraph(text string, paragraph string) string {
+	if strings.TrimSpace(text) == "" {
+		return paragraph
+	}
+	return strings.TrimRight(text, "\n") + "\n\n" + paragraph
+}
@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/robstave/meowmorize/internal/domain/types"
+	"github.com/robstave/meowmorize/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExplainCard_FollowUp(t *testing.T) {
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+	threadRepo := setupExplanationRepository()
+
+	card := &types.Card{ID: "card1", Front: types.CardFront{Text: "Why do cats purr?"}, Back: types.CardBack{Text: "Contentment"}}
+	var earlier []types.ExplanationTurn
+	for i := 0; i < threadContextTurns+2; i++ {
+		earlier = append(earlier, types.ExplanationTurn{Question: fmt.Sprintf("q%d", i), Answer: fmt.Sprintf("a%d", i)})
+	}
+
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	cardRepo.On("GetCardByID", "card1").Return(card, nil)
+	threadRepo.On("GetThread", "card1", "meow").Return(earlier, nil)
+	deckRepo.On("GetAllDecksByUser", "meow").Return([]types.Deck{{Name: "Cats", Cards: []types.Card{*card}}}, nil)
+	llmRepo.On("RunPrompt", mock.Anything, mock.MatchedBy(func(prompt string) bool {
+		// Only the latest turns go along
+		return strings.HasPrefix(prompt, `This is regarding the following flashcard from the deck "Cats":`) && !strings.Contains(prompt, "Question: q1\n") && strings.Contains(prompt, "Question: q2\nAnswer: a2") &&
+			strings.HasSuffix(prompt, "Question: And when hurt?")
+	})).Return("Also to self-soothe.", nil)
+	threadRepo.On("AddTurn", mock.MatchedBy(func(turn types.ExplanationTurn) bool {
+		return turn.CardID == "card1" && turn.UserID == "meow" && turn.Question == "And when hurt?" && turn.Answer == "Also to self-soothe."
+	})).Return(nil)
+
+	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo, nil, nil, threadRepo, nil, nil, nil)
+	turn, err := s.ExplainCard(context.Background(), "card1", "meow", "And when hurt?")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, turn.ID)
+	threadRepo.AssertExpectations(t)
+}
+
+func TestExplainCard_LLMUnavailable(t *testing.T) {
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+	threadRepo := setupExplanationRepository()
+
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	cardRepo.On("GetCardByID", "card1").Return(&types.Card{ID: "card1"}, nil)
+	threadRepo.On("GetThread", "card1", "meow").Return(nil, nil)
+	deckRepo.On("GetAllDecksByUser", "meow").Return(nil, nil)
+	llmRepo.On("RunPrompt", mock.Anything, mock.Anything).Return("", types.ErrLLMNotInitialized)
+
+	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo, nil, nil, threadRepo, nil, nil, nil)
+	_, err := s.ExplainCard(context.Background(), "card1", "meow", "Why?")
+	assert.ErrorIs(t, err, types.ErrLLMNotInitialized)
+	threadRepo.AssertNotCalled(t, "AddTurn", mock.Anything)
+}
+
+func TestPromoteExplanation(t *testing.T) {
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	threadRepo := setupExplanationRepository()
+
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	threadRepo.On("GetTurnByID", "turn1").Return(&types.ExplanationTurn{ID: "turn1", CardID: "card1", UserID: "meow", Answer: "Purring self-soothes."}, nil)
+	threadRepo.On("GetTurnByID", "turn2").Return(&types.ExplanationTurn{ID: "turn2", CardID: "card1", UserID: "someone"}, nil)
+	cardRepo.On("GetCardByID", "card1").Return(func(string) *types.Card {
+		return &types.Card{ID: "card1", Back: types.CardBack{Text: "Contentment"}, Notes: "Mine"}
+	}, nil)
+	cardRepo.On("UpdateCard", mock.MatchedBy(func(card types.Card) bool {
+		return card.Back.Text == "Contentment\n\nPurring self-soothes." && card.Notes == "Mine"
+	})).Return(nil).Once()
+	cardRepo.On("UpdateCard", mock.MatchedBy(func(card types.Card) bool {
+		return card.Back.Text == "Contentment" && card.Notes == "Mine\n\nPurring self-soothes."
+	})).Return(nil).Once()
+	// Only the back is translated, so only promoting to it flags the translations
+	cardRepo.On("MarkTranslationsStale", "card1").Return(nil).Once()
+
+	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, nil, nil, nil, threadRepo, nil, nil, nil)
+
+	_, err := s.PromoteExplanation("turn1", "meow", types.PromoteToBack)
+	assert.NoError(t, err)
+	_, err = s.PromoteExplanation("turn1", "meow", types.PromoteToNotes)
+	assert.NoError(t, err)
+	cardRepo.AssertExpectations(t)
+
+	_, err = s.PromoteExplanation("turn2", "meow", types.PromoteToNotes)
+	assert.EqualError(t, err, "explanation not found")
+	_, err = s.PromoteExplanation("turn1", "meow", "front")
+	assert.EqualError(t, err, "promote target must be back or notes")
+}
+
+func TestDeleteExplanationThread_NotFound(t *testing.T) {
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	threadRepo := setupExplanationRepository()
+
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	threadRepo.On("DeleteThread", "card1", "meow").Return(int64(0), nil)
+
+	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, nil, nil, nil, threadRepo, nil, nil, nil)
+	assert.EqualError(t, s.DeleteExplanationThread("card1", "meow"), "thread not found")
+}
+
+func TestStreamExplainCard(t *testing.T) {
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+	threadRepo := setupExplanationRepository()
+
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	cardRepo.On("GetCardByID", "card1").Return(&types.Card{ID: "card1"}, nil)
+	threadRepo.On("GetThread", "card1", "meow").Return(nil, nil)
+	deckRepo.On("GetAllDecksByUser", "meow").Return(nil, nil)
+	llmRepo.On("StreamPrompt", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		onChunk := args.Get(2).(func(string) error)
+		onChunk("Be")
+		onChunk("cause")
+	}).Return("Because", nil)
+	threadRepo.On("AddTurn", mock.MatchedBy(func(turn types.ExplanationTurn) bool {
+		return turn.Answer == "Because"
+	})).Return(nil)
+
+	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo, nil, nil, threadRepo, nil, nil, nil)
+	var chunks []string
+	turn, err := s.StreamExplainCard(context.Background(), "card1", "meow", "Why?", func(chunk string) error {
+		chunks = append(chunks, chunk)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Because", turn.Answer)
+	assert.Equal(t, []string{"Be", "cause"}, chunks)
+	llmRepo.AssertNotCalled(t, "RunPrompt", mock.Anything, mock.Anything)
+	threadRepo.AssertExpectations(t)
+}
@@ -0,0 +1,121 @@
+// internal/domain/forecast.go
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/robstave/meowmorize/internal/domain/types"
+)
+
+// retentionWindowDays is how far back the retention estimate looks
+const retentionWindowDays = 30
+
+// GetForecast predicts the number of reviews due per day over the next days for each of
+// the user's decks, and estimates each deck's retention from its recent passes and fails
+func (s *Service) GetForecast(username string, days int) (types.Forecast, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		s.logger.Error("Failed to retrieve user", "username", username, "error", err)
+		return types.Forecast{}, err
+	}
+	if user == nil {
+		return types.Forecast{}, errors.New("user not found")
+	}
+
+	decks, err := s.deckRepo.GetAllDecksByUser(username)
+	if err != nil {
+		s.logger.Error("Failed to retrieve decks", "username", username, "error", err)
+		return types.Forecast{}, err
+	}
+
+	rollups, err := s.sessionLogRepo.GetDailyRollups(username, "")
+	if err != nil {
+		s.logger.Error("Failed to retrieve daily rollups", "username", username, "error", err)
+		return types.Forecast{}, err
+	}
+
+	return buildForecast(decks, rollups, userLocation(user.Timezone), time.Now(), days), nil
+}
+
+// buildForecast buckets the due dates of the active scheduled cards into local days,
+// starting today. Overdue cards are due today.
+func buildForecast(decks []types.Deck, rollups []types.DailyRollup, loc *time.Location, now time.Time, days int) types.Forecast {
+	today := localDay(now, loc)
+	forecast := types.Forecast{
+		Timezone:      loc.String(),
+		RetentionDays: retentionWindowDays,
+		Total:         emptyForecastDays(today, days),
+		Decks:         []types.DeckForecast{},
+	}
+
+	// Recent outcomes per deck
+	windowStart := today.AddDate(0, 0, 1-retentionWindowDays).Format(dateLayout)
+	passes, fails := map[string]int{}, map[string]int{}
+	for _, rollup := range rollups {
+		if rollup.Day < windowStart {
+			continue
+		}
+		passes[rollup.DeckID] += rollup.Passes
+		fails[rollup.DeckID] += rollup.Fails
+	}
+
+	var totalPasses, totalFails int
+	for _, deck := range decks {
+		deckForecast := types.DeckForecast{
+			DeckID:       deck.ID,
+			DeckName:     deck.Name,
+			Days:         emptyForecastDays(today, days),
+			RecentPasses: passes[deck.ID],
+			RecentFails:  fails[deck.ID],
+			Retention:    retention(passes[deck.ID], fails[deck.ID]),
+		}
+		totalPasses += passes[deck.ID]
+		totalFails += fails[deck.ID]
+
+		for _, card := range deck.Cards {
+			if card.Retired {
+				continue
+			}
+			if card.DueAt.IsZero() {
+				deckForecast.NewCards++
+				continue
+			}
+
+			due := localDay(card.DueAt, loc)
+			if due.Before(today) {
+				deckForecast.Overdue++
+				due = today
+			}
+			offset := int(due.Sub(today).Hours() / 24)
+			if offset >= days {
+				continue
+			}
+			deckForecast.Days[offset].Due++
+			forecast.Total[offset].Due++
+		}
+
+		forecast.Decks = append(forecast.Decks, deckForecast)
+	}
+
+	forecast.Retention = retention(totalPasses, totalFails)
+	return forecast
+}
+
+// emptyForecastDays lists the days from today on with nothing due
+func emptyForecastDays(today time.Time, days int) []types.ForecastDay {
+	forecastDays := make([]types.ForecastDay, days)
+	for i := range forecastDays {
+		forecastDays[i].Date = today.AddDate(0, 0, i).Format(dateLayout)
+	}
+	return forecastDays
+}
+
+// retention returns the share of passes, or nil when there are no outcomes
+func retention(passes, fails int) *float64 {
+	if passes+fails == 0 {
+		return nil
+	}
+	r := float64(passes) / float64(passes+fails)
+	return &r
+}
@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/robstave/meowmorize/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduleCard(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	card := types.Card{ID: "card1"}
+
+	scheduleCard(&card, types.IncrementPass, cleanPass, now)
+	assert.Equal(t, 1, card.IntervalDays)
+	assert.Equal(t, now.AddDate(0, 0, 1), card.DueAt)
+
+	scheduleCard(&card, types.IncrementPass, cleanPass, now)
+	assert.Equal(t, 3, card.IntervalDays)
+
+	scheduleCard(&card, types.IncrementPass, slowPass, now)
+	assert.Equal(t, 5, card.IntervalDays) // Slow passes grow the interval less
+
+	scheduleCard(&card, types.IncrementPass, hintedPass, now)
+	assert.Equal(t, 6, card.IntervalDays) // Hinted passes grow it least
+
+	scheduleCard(&card, types.IncrementSkip, cleanPass, now)
+	assert.Equal(t, 6, card.IntervalDays)
+
+	scheduleCard(&card, types.IncrementFail, cleanPass, now)
+	assert.Equal(t, 0, card.IntervalDays)
+	assert.Equal(t, now, card.DueAt)
+
+	card.IntervalDays = 300
+	scheduleCard(&card, types.IncrementPass, cleanPass, now)
+	assert.Equal(t, maxIntervalDays, card.IntervalDays)
+}
+
+func TestBuildForecast(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	decks := []types.Deck{
+		{ID: "deck1", Name: "Capitals", Cards: []types.Card{
+			{ID: "overdue", DueAt: now.AddDate(0, 0, -3)},
+			{ID: "today", DueAt: now.Add(time.Hour)},
+			{ID: "tomorrow", DueAt: now.AddDate(0, 0, 1)},
+			{ID: "later", DueAt: now.AddDate(0, 0, 30)},
+			{ID: "new"},
+			{ID: "retired", DueAt: now, Retired: true},
+		}},
+		{ID: "deck2", Name: "Rivers", Cards: []types.Card{
+			{ID: "river", DueAt: now.AddDate(0, 0, 2)},
+		}},
+	}
+	rollups := []types.DailyRollup{
+		{DeckID: "deck1", Day: "2024-05-09", Reviews: 4, Passes: 3, Fails: 1},
+		{DeckID: "deck1", Day: "2024-03-01", Reviews: 10, Passes: 0, Fails: 10}, // Outside the window
+		{DeckID: "deck2", Day: "2024-05-01", Reviews: 1, Passes: 1},
+	}
+
+	forecast := buildForecast(decks, rollups, time.UTC, now, 7)
+
+	assert.Len(t, forecast.Total, 7)
+	assert.Equal(t, "2024-05-10", forecast.Total[0].Date)
+	assert.Equal(t, 2, forecast.Total[0].Due)
+	assert.Equal(t, 1, forecast.Total[1].Due)
+	assert.Equal(t, 1, forecast.Total[2].Due)
+
+	deck1 := forecast.Decks[0]
+	assert.Equal(t, 1, deck1.Overdue)
+	assert.Equal(t, 1, deck1.NewCards)
+	assert.Equal(t, 2, deck1.Days[0].Due)
+	if assert.NotNil(t, deck1.Retention) {
+		assert.InDelta(t, 0.75, *deck1.Retention, 0.001)
+	}
+	if assert.NotNil(t, forecast.Retention) {
+		assert.InDelta(t, 0.8, *forecast.Retention, 0.001)
+	}
+
+	assert.Equal(t, 1, forecast.Decks[1].Days[2].Due)
+}
+
+func TestBuildForecast_NoHistory(t *testing.T) {
+	forecast := buildForecast(nil, nil, time.UTC, time.Now(), 3)
+	assert.Nil(t, forecast.Retention)
+	assert.Empty(t, forecast.Decks)
+	assert.Len(t, forecast.Total, 3)
+}
+
+func TestScheduleRetiredCard(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	card := types.Card{ID: "card1", IntervalDays: 10}
+
+	retireCard(&card, now)
+	assert.True(t, card.Retired)
+	assert.Equal(t, now.AddDate(0, 0, resurrectIntervalDays), card.DueAt)
+
+	scheduleRetiredCard(&card, types.IncrementPass, now)
+	assert.True(t, card.Retired)
+	assert.Equal(t, 2*resurrectIntervalDays, card.IntervalDays)
+
+	scheduleRetiredCard(&card, types.IncrementFail, now)
+	assert.False(t, card.Retired)
+	assert.Equal(t, 0, card.IntervalDays)
+	assert.Equal(t, now, card.DueAt)
+}
@@ -0,0 +1,203 @@
+// internal/domain/generate.go
+package domain
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/robstave/meowmorize/internal/domain/types"
+	"github.com/robstave/meowmorize/prompts"
+)
+
+// maxGeneratedCards caps how many cards one generation asks for
+const maxGeneratedCards = 50
+
+// maxSourceBytes caps the source text sent to the LLM
+const maxSourceBytes = 64 << 10
+
+var (
+	cardBlockPattern = regexp.MustCompile(`(?s)<!--\s*Card Start\s*-->(.*?)<!--\s*Card End\s*-->`)
+	cardFrontPattern = regexp.MustCompile(`(?is)###\s*Front(.*?)###\s*Back`)
+	cardBackPattern  = regexp.MustCompile(`(?is)###\s*Back(.*?)(?:<!--|\z)`)
+	cardLinkPattern  = regexp.MustCompile(`(?i)<!---?\s*Card Link\s*-->\s*(https?://\S+)`)
+)
+
+// GenerateCards prompts the LLM for cards on the source text and puts them in the review
+// queue of the deck. Nothing is added to the deck until the drafts are approved.
+func (s *Service) GenerateCards(ctx context.Context, username string, request types.GenerateCardsRequest) ([]types.CardDraft, error) {
+	if strings.TrimSpace(request.Text) == "" {
+		return nil, errors.New("source text is required")
+	}
+	if len(request.Text) > maxSourceBytes {
+		return nil, types.ErrSourceTooLarge
+	}
+	if request.Count < 1 || request.Count > maxGeneratedCards {
+		return nil, errors.New("card count must be between 1 and 50")
+	}
+
+	deck, err := s.deckRepo.GetDeckByID(request.DeckID)
+	if err != nil || deck.UserID != username {
+		return nil, errors.New("deck not found")
+	}
+	if s.llmRepo == nil {
+		return nil, types.ErrLLMNotInitialized
+	}
+
+	prompt, err := s.renderPrompt(username, types.LLMPurposeGenerate, types.PromptData{
+		Deck:       deck.Name,
+		Count:      request.Count,
+		Source:     request.Text,
+		CardFormat: prompts.CardFormat,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.runPrompt(ctx, username, prompt, nil)
+	if err != nil {
+		s.logger.Error("Failed to generate cards", "deck_id", request.DeckID, "error", err)
+		return nil, err
+	}
+
+	parsed := parseMarkdownCards(response)
+	if len(parsed) == 0 {
+		s.logger.Warn("LLM response had no cards", "deck_id", request.DeckID, "response_length", len(response))
+		return nil, types.ErrNoCardsGenerated
+	}
+	if len(parsed) > request.Count {
+		parsed = parsed[:request.Count]
+	}
+
+	now := time.Now()
+	drafts := make([]types.CardDraft, 0, len(parsed))
+	for _, card := range parsed {
+		drafts = append(drafts, types.CardDraft{
+			ID:        uuid.New().String(),
+			UserID:    username,
+			DeckID:    deck.ID,
+			Front:     card.Front.Text,
+			Back:      card.Back.Text,
+			Link:      card.Link,
+			Source:    request.Source,
+			CreatedAt: now,
+		})
+	}
+
+	if err := s.draftRepo.CreateDrafts(drafts); err != nil {
+		s.logger.Error("Failed to save card drafts", "deck_id", deck.ID, "error", err)
+		return nil, err
+	}
+
+	s.logger.Info("Generated card drafts", "deck_id", deck.ID, "requested", request.Count, "generated", len(drafts))
+	return drafts, nil
+}
+
+// GetCardDrafts lists the user's review queue, optionally for one deck
+func (s *Service) GetCardDrafts(username string, deckID string) ([]types.CardDraft, error) {
+	drafts, err := s.draftRepo.GetDraftsByUser(username, deckID)
+	if err != nil {
+		s.logger.Error("Failed to retrieve card drafts", "username", username, "error", err)
+		return nil, err
+	}
+	if drafts == nil {
+		drafts = []types.CardDraft{}
+	}
+	return drafts, nil
+}
+
+// ApproveCardDraft creates the card of the draft in its deck, with any edits applied,
+// and takes the draft off the review queue
+func (s *Service) ApproveCardDraft(draftID string, username string, edit types.CardDraftEdit) (*types.Card, error) {
+	draft, err := s.userDraft(draftID, username)
+	if err != nil {
+		return nil, err
+	}
+
+	card := types.Card{
+		Front: types.CardFront{Text: draft.Front},
+		Back:  types.CardBack{Text: draft.Back},
+		Link:  draft.Link,
+	}
+	if edit.Front != nil {
+		card.Front.Text = *edit.Front
+	}
+	if edit.Back != nil {
+		card.Back.Text = *edit.Back
+	}
+	if strings.TrimSpace(card.Front.Text) == "" || strings.TrimSpace(card.Back.Text) == "" {
+		return nil, errors.New("card front and back are required")
+	}
+
+	created, err := s.CreateCard(card, draft.DeckID, username)
+	if err != nil {
+		s.logger.Error("Failed to create card from draft", "draft_id", draftID, "error", err)
+		return nil, err
+	}
+
+	if err := s.draftRepo.DeleteDraft(draftID); err != nil {
+		s.logger.Error("Failed to delete approved draft", "draft_id", draftID, "error", err)
+		return nil, err
+	}
+
+	s.logger.Info("Card draft approved", "draft_id", draftID, "card_id", created.ID, "deck_id", draft.DeckID)
+	return created, nil
+}
+
+// RejectCardDraft takes the draft off the review queue without creating a card
+func (s *Service) RejectCardDraft(draftID string, username string) error {
+	if _, err := s.userDraft(draftID, username); err != nil {
+		return err
+	}
+	if err := s.draftRepo.DeleteDraft(draftID); err != nil {
+		s.logger.Error("Failed to delete card draft", "draft_id", draftID, "error", err)
+		return err
+	}
+	return nil
+}
+
+// userDraft returns the draft if it belongs to the user
+func (s *Service) userDraft(draftID string, username string) (*types.CardDraft, error) {
+	draft, err := s.draftRepo.GetDraftByID(draftID)
+	if err != nil {
+		s.logger.Error("Failed to retrieve card draft", "draft_id", draftID, "error", err)
+		return nil, err
+	}
+	if draft == nil || draft.UserID != username {
+		return nil, errors.New("draft not found")
+	}
+	return draft, nil
+}
+
+// parseMarkdownCards extracts the cards between Card Start and Card End markers, the same
+// way the frontend parses imported markdown. Cards missing a front or back are skipped.
+func parseMarkdownCards(markdown string) []types.Card {
+	var cards []types.Card
+	for _, block := range cardBlockPattern.FindAllStringSubmatch(markdown, -1) {
+		content := block[1]
+
+		var front, back, link string
+		if match := cardFrontPattern.FindStringSubmatch(content); match != nil {
+			front = strings.TrimSpace(match[1])
+		}
+		if match := cardBackPattern.FindStringSubmatch(content); match != nil {
+			back = strings.TrimSpace(match[1])
+		}
+		if match := cardLinkPattern.FindStringSubmatch(content); match != nil {
+			link = match[1]
+		}
+
+		if front == "" || back == "" {
+			continue
+		}
+		cards = append(cards, types.Card{
+			Front: types.CardFront{Text: front},
+			Back:  types.CardBack{Text: back},
+			Link:  link,
+		})
+	}
+	return cards
+}
@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/robstave/meowmorize/internal/domain/types"
+	"github.com/robstave/meowmorize/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const generatedCards = `Here are your cards:
+
+<!-- Card Start -->
+### Front
+What is a **kitten**?
+
+### Back
+A young cat.
+<!--- Card Link --> https://example.com/kitten
+<!-- Card End -->
+
+<!-- Card Start -->
+### Front
+Missing its back
+<!-- Card End -->
+
+<!-- Card Start -->
+### Front
+What do cats say?
+### Back
+Meow
+<!-- Card End -->`
+
+func TestParseMarkdownCards(t *testing.T) {
+	cards := parseMarkdownCards(generatedCards)
+	assert.Len(t, cards, 2)
+	assert.Equal(t, "What is a **kitten**?", cards[0].Front.Text)
+	assert.Equal(t, "A young cat.", cards[0].Back.Text)
+	assert.Equal(t, "https://example.com/kitten", cards[0].Link)
+	assert.Equal(t, "What do cats say?", cards[1].Front.Text)
+	assert.Equal(t, "Meow", cards[1].Back.Text)
+
+	assert.Empty(t, parseMarkdownCards("Sorry, I cannot help with that."))
+}
+
+func TestGenerateCards(t *testing.T) {
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+	draftRepo := setupCardDraftRepository()
+
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	deckRepo.On("GetDeckByID", "deck1").Return(types.Deck{ID: "deck1", UserID: "meow"}, nil)
+	llmRepo.On("RunPrompt", mock.Anything, mock.MatchedBy(func(prompt string) bool {
+		return strings.Contains(prompt, "Create 1 flashcards") && strings.HasSuffix(prompt, "Cats are small.")
+	})).Return(generatedCards, nil)
+	draftRepo.On("CreateDrafts", mock.MatchedBy(func(drafts []types.CardDraft) bool {
+		return len(drafts) == 1 && drafts[0].UserID == "meow" && drafts[0].DeckID == "deck1" && drafts[0].Source == "notes.md"
+	})).Return(nil)
+
+	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo, nil, draftRepo, nil, nil, nil, nil)
+	drafts, err := s.GenerateCards(context.Background(), "meow", types.GenerateCardsRequest{DeckID: "deck1", Count: 1, Text: "Cats are small.", Source: "notes.md"})
+	assert.NoError(t, err)
+	assert.Len(t, drafts, 1)
+	assert.Equal(t, "A young cat.", drafts[0].Back)
+	cardRepo.AssertNotCalled(t, "CreateCard", mock.Anything)
+	draftRepo.AssertExpectations(t)
+}
+
+func TestGenerateCards_Cancelled(t *testing.T) {
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+	draftRepo := setupCardDraftRepository()
+
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	deckRepo.On("GetDeckByID", "deck1").Return(types.Deck{ID: "deck1", UserID: "meow"}, nil)
+	// The request's context reaches the LLM, so a client that goes away stops the prompt
+	llmRepo.On("RunPrompt", mock.MatchedBy(func(ctx context.Context) bool {
+		return errors.Is(ctx.Err(), context.Canceled)
+	}), mock.Anything).Return("", context.Canceled)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo, nil, draftRepo, nil, nil, nil, nil)
+	_, err := s.GenerateCards(ctx, "meow", types.GenerateCardsRequest{DeckID: "deck1", Count: 1, Text: "Cats are small."})
+	assert.ErrorIs(t, err, context.Canceled)
+	llmRepo.AssertExpectations(t)
+	draftRepo.AssertNotCalled(t, "CreateDrafts", mock.Anything)
+}
+
+func TestGenerateCards_Errors(t *testing.T) {
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+	draftRepo := setupCardDraftRepository()
+
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	deckRepo.On("GetDeckByID", "deck1").Return(types.Deck{ID: "deck1", UserID: "meow"}, nil)
+	deckRepo.On("GetDeckByID", "other").Return(types.Deck{ID: "other", UserID: "someone"}, nil)
+	llmRepo.On("RunPrompt", mock.Anything, mock.Anything).Return("I cannot do that.", nil)
+
+	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo, nil, draftRepo, nil, nil, nil, nil)
+
+	_, err := s.GenerateCards(context.Background(), "meow", types.GenerateCardsRequest{DeckID: "deck1", Count: 5, Text: "  "})
+	assert.EqualError(t, err, "source text is required")
+
+	_, err = s.GenerateCards(context.Background(), "meow", types.GenerateCardsRequest{DeckID: "deck1", Count: 51, Text: "cats"})
+	assert.EqualError(t, err, "card count must be between 1 and 50")
+
+	_, err = s.GenerateCards(context.Background(), "meow", types.GenerateCardsRequest{DeckID: "deck1", Count: 5, Text: strings.Repeat("x", maxSourceBytes+1)})
+	assert.ErrorIs(t, err, types.ErrSourceTooLarge)
+
+	_, err = s.GenerateCards(context.Background(), "meow", types.GenerateCardsRequest{DeckID: "other", Count: 5, Text: "cats"})
+	assert.EqualError(t, err, "deck not found")
+
+	_, err = s.GenerateCards(context.Background(), "meow", types.GenerateCardsRequest{DeckID: "deck1", Count: 5, Text: "cats"})
+	assert.ErrorIs(t, err, types.ErrNoCardsGenerated)
+	draftRepo.AssertNotCalled(t, "CreateDrafts", mock.Anything)
+}
+
+func TestApproveCardDraft(t *testing.T) {
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	draftRepo := setupCardDraftRepository()
+
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	draftRepo.On("GetDraftByID", "draft1").Return(&types.CardDraft{ID: "draft1", UserID: "meow", DeckID: "deck1", Front: "Q", Back: "A"}, nil)
+	draftRepo.On("DeleteDraft", "draft1").Return(nil)
+	cardRepo.On("CreateCard", mock.MatchedBy(func(card types.Card) bool {
+		return card.Front.Text == "Q" && card.Back.Text == "Edited" && card.UserID == "meow"
+	})).Return(nil)
+	deckRepo.On("AddCardToDeck", "deck1", mock.AnythingOfType("types.Card")).Return(nil)
+
+	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, nil, nil, draftRepo, nil, nil, nil, nil)
+	edited := "Edited"
+	card, err := s.ApproveCardDraft("draft1", "meow", types.CardDraftEdit{Back: &edited})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, card.ID)
+	draftRepo.AssertExpectations(t)
+	deckRepo.AssertExpectations(t)
+}
+
+func TestRejectCardDraft_OtherUser(t *testing.T) {
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	draftRepo := setupCardDraftRepository()
+
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	draftRepo.On("GetDraftByID", "draft1").Return(&types.CardDraft{ID: "draft1", UserID: "someone"}, nil)
+
+	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, nil, nil, draftRepo, nil, nil, nil, nil)
+	assert.EqualError(t, s.RejectCardDraft("draft1", "meow"), "draft not found")
+	draftRepo.AssertNotCalled(t, "DeleteDraft", mock.Anything)
+}
@@ -0,0 +1,135 @@
+// internal/domain/hint.go
+package domain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/robstave/meowmorize/internal/domain/types"
+)
+
+// GetCardHint returns the hint of the level for the card. A card with an author-written
+// hint has just that one; otherwise the LLM writes hints of increasing specificity up to
+// types.MaxHintLevel, each generated once and cached. The hint is recorded on the card
+// in the deck's session, so passing it afterwards is graded lower.
+func (s *Service) GetCardHint(ctx context.Context, cardID string, deckID string, username string, level int) (types.Hint, error) {
+	card, err := s.cardRepo.GetCardByID(cardID)
+	if err != nil {
+		s.logger.Error("Failed to retrieve card", "card_id", cardID, "error", err)
+		return types.Hint{}, err
+	}
+	if card == nil {
+		return types.Hint{}, errors.New("card not found")
+	}
+
+	var hint types.Hint
+	if text := strings.TrimSpace(card.Hint); text != "" {
+		hint = types.Hint{CardID: cardID, Level: 1, MaxLevel: 1, Text: text, Source: types.HintAuthor}
+	} else {
+		level = min(max(level, 1), types.MaxHintLevel)
+		hints, err := s.generatedHints(ctx, card, username, level)
+		if err != nil {
+			return types.Hint{}, err
+		}
+		hint = types.Hint{CardID: cardID, Level: level, MaxLevel: types.MaxHintLevel, Text: hints[level-1], Source: types.HintLLM}
+	}
+
+	s.recordHint(deckID, cardID, hint.Level)
+	return hint, nil
+}
+
+// generatedHints returns the card's generated hints up to the level, least specific
+// first, generating the ones not cached yet. Each hint is written knowing the ones
+// before it, so it can be more specific.
+func (s *Service) generatedHints(ctx context.Context, card *types.Card, username string, level int) ([]string, error) {
+	fingerprint := hintFingerprint(*card)
+	cached, err := s.cardRepo.GetHints(card.ID, fingerprint)
+	if err != nil {
+		s.logger.Error("Failed to retrieve cached hints", "card_id", card.ID, "error", err)
+		return nil, err
+	}
+
+	var hints []string
+	for _, h := range cached {
+		if h.Level == len(hints)+1 {
+			hints = append(hints, h.Text)
+		}
+	}
+	if len(hints) >= level {
+		return hints, nil
+	}
+
+	deck := s.cardDeckName(username, card.ID)
+	for next := len(hints) + 1; next <= level; next++ {
+		prompt, err := s.renderPrompt(username, types.LLMPurposeHint, types.PromptData{
+			Front: card.Front.Text,
+			Back:  card.Back.Text,
+			Deck:  deck,
+			Level: next,
+			Hints: hints,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		response, err := s.runPrompt(ctx, username, prompt, nil)
+		if err != nil {
+			s.logger.Error("Failed to generate hint", "card_id", card.ID, "level", next, "error", err)
+			return nil, err
+		}
+		text := strings.TrimSpace(response)
+		if text == "" {
+			return nil, errors.New("the LLM returned an empty hint")
+		}
+
+		err = s.cardRepo.AddHint(types.CardHint{
+			ID:          uuid.New().String(),
+			CardID:      card.ID,
+			Fingerprint: fingerprint,
+			Level:       next,
+			Text:        text,
+			CreatedAt:   time.Now(),
+		})
+		if err != nil {
+			// The hint is still served, it is just generated again next time
+			s.logger.Error("Failed to cache hint", "card_id", card.ID, "level", next, "error", err)
+		}
+		hints = append(hints, text)
+	}
+	return hints, nil
+}
+
+// recordHint notes the hint on the card in the deck's session, if there is one
+func (s *Service) recordHint(deckID string, cardID string, level int) {
+	s.sessionsMu.RLock()
+	session, exists := s.sessions[deckID]
+	s.sessionsMu.RUnlock()
+
+	if !exists || !session.RecordHint(cardID, level) {
+		return
+	}
+	s.logger.Info("Hint recorded", "deck_id", deckID, "card_id", cardID, "level", level)
+}
+
+// sessionHintLevel returns the highest hint level shown for the card in the deck's session
+func (s *Service) sessionHintLevel(deckID string, cardID string) int {
+	s.sessionsMu.RLock()
+	session, exists := s.sessions[deckID]
+	s.sessionsMu.RUnlock()
+
+	if !exists {
+		return 0
+	}
+	return session.CardHintLevel(cardID)
+}
+
+// hintFingerprint identifies the card content the hints were written for
+func hintFingerprint(card types.Card) string {
+	sum := sha256.Sum256([]byte(card.Front.Text + "\x00" + card.Back.Text))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/robstave/meowmorize/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetCardHint_AuthorHint(t *testing.T) {
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	cardRepo.On("GetCardByID", "card1").Return(&types.Card{ID: "card1", Hint: " It is in Scandinavia. "}, nil)
+
+	dm := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	hint, err := dm.GetCardHint(context.Background(), "card1", "", "meow", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, types.Hint{CardID: "card1", Level: 1, MaxLevel: 1, Text: "It is in Scandinavia.", Source: types.HintAuthor}, hint)
+	llmRepo.AssertNotCalled(t, "RunPrompt", mock.Anything, mock.Anything)
+}
+
+func TestGetCardHint_GeneratesMissingLevels(t *testing.T) {
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+
+	card := &types.Card{ID: "card1", Front: types.CardFront{Text: "Capital of Norway?"}, Back: types.CardBack{Text: "Oslo"}}
+	fingerprint := hintFingerprint(*card)
+
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	cardRepo.On("GetCardByID", "card1").Return(card, nil)
+	cardRepo.On("GetHints", "card1", fingerprint).Return([]types.CardHint{{CardID: "card1", Level: 1, Text: "A Nordic city."}}, nil)
+	deckRepo.On("GetAllDecksByUser", "meow").Return(nil, nil)
+	llmRepo.On("RunPrompt", mock.Anything, mock.MatchedBy(func(prompt string) bool {
+		return strings.Contains(prompt, "- A Nordic city.\n") && strings.Contains(prompt, "hint number 2")
+	})).Return("It starts with O.\n", nil)
+	cardRepo.On("AddHint", mock.MatchedBy(func(h types.CardHint) bool {
+		return h.CardID == "card1" && h.Fingerprint == fingerprint && h.Level == 2 && h.Text == "It starts with O."
+	})).Return(nil)
+
+	dm := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	hint, err := dm.GetCardHint(context.Background(), "card1", "", "meow", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, types.Hint{CardID: "card1", Level: 2, MaxLevel: types.MaxHintLevel, Text: "It starts with O.", Source: types.HintLLM}, hint)
+
+	// Cached levels are served without the LLM
+	hint, err = dm.GetCardHint(context.Background(), "card1", "", "meow", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "A Nordic city.", hint.Text)
+
+	cardRepo.AssertExpectations(t)
+	llmRepo.AssertNumberOfCalls(t, "RunPrompt", 1)
+}
+
+func TestUpdateCardStats_HintedPass(t *testing.T) {
+	deckID := uuid.New().String()
+	card := types.Card{ID: "card1", UserID: "meow", Hint: "Nordic", IntervalDays: 10}
+	deck := types.Deck{ID: deckID, Name: "Capitals", Cards: []types.Card{card}}
+
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	deckRepo.On("GetDeckByID", deckID).Return(deck, nil)
+	deckRepo.On("UpdateDeck", mock.AnythingOfType("types.Deck")).Return(nil)
+	cardRepo.On("GetCardByID", "card1").Return(&card, nil)
+	sessionRepo.On("GetRecentResponseTimes", "card1", mock.Anything).Return(nil, nil).Maybe()
+	cardRepo.On("UpdateCard", mock.MatchedBy(func(c types.Card) bool {
+		return c.PassCount == 1 && c.HintedPassCount == 1 && c.SlowPassCount == 0 && c.IntervalDays == 12
+	})).Return(nil)
+	sessionRepo.On("CreateLog", mock.MatchedBy(func(log types.SessionLog) bool {
+		return log.HintLevel == 1
+	})).Return(nil)
+
+	dm := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	assert.NoError(t, dm.StartSession(deckID, -1, types.RandomMethod, "meow", types.SessionOptions{}))
+	_, err := dm.GetNextCard(deckID)
+	assert.NoError(t, err)
+
+	_, err = dm.GetCardHint(context.Background(), "card1", deckID, "meow", 1)
+	assert.NoError(t, err)
+	stats, err := dm.GetSessionStats(deckID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, stats.CardStats[0].HintLevel)
+
+	assert.NoError(t, dm.UpdateCardStats("card1", types.IncrementPass, nil, deckID, "meow"))
+
+	// The next serve starts without a hint
+	stats, err = dm.GetSessionStats(deckID)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, stats.CardStats[0].HintLevel)
+
+	cardRepo.AssertExpectations(t)
+	sessionRepo.AssertExpectations(t)
+}
+
+func TestEffectivePassCount_HintedPassesRankWeakest(t *testing.T) {
+	slow := types.Card{ID: "slow", PassCount: 4, SlowPassCount: 4}
+	hinted := types.Card{ID: "hinted", PassCount: 4, HintedPassCount: 4}
+	mixed := types.Card{ID: "mixed", PassCount: 4, HintedPassCount: 2, SlowPassCount: 4}
+
+	assert.Equal(t, 1.0, effectivePassCount(hinted))
+	assert.Less(t, effectivePassCount(hinted), effectivePassCount(slow))
+	assert.Equal(t, 1.5, effectivePassCount(mixed))
+}
@@ -0,0 +1,105 @@
+// internal/domain/leech.go
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robstave/meowmorize/internal/domain/types"
+)
+
+// defaultLeechThreshold applies when the user's settings cannot be read
+const defaultLeechThreshold = 8
+
+// leechExplainPrompt is the suggested prompt of the leech "explain this card" shortcut
+const leechExplainPrompt = "I keep getting this card wrong. Explain the answer in a different way and give me a mnemonic to remember it."
+
+// checkLeech flags the card as a leech once its lapses, including the fail being recorded,
+// reach the user's threshold. Depending on the user's settings the leech is also retired.
+func (s *Service) checkLeech(card *types.Card, username string) {
+	if card.Leech {
+		return
+	}
+
+	threshold, action := defaultLeechThreshold, types.LeechFlag
+	if user, err := s.userRepo.GetUserByUsername(username); err == nil && user != nil {
+		if user.LeechThreshold > 0 {
+			threshold = user.LeechThreshold
+		}
+		action = user.LeechAction
+	}
+
+	lapses, err := s.sessionLogRepo.CountLapses(card.ID, card.LapsesSince)
+	if err != nil {
+		s.logger.Error("Failed to count lapses", "card_id", card.ID, "error", err)
+		return
+	}
+	if int(lapses)+1 < threshold {
+		return
+	}
+
+	card.Leech = true
+	if action == types.LeechRetire {
+		retireCard(card, time.Now())
+	}
+	s.logger.Info("Card flagged as leech", "card_id", card.ID, "lapses", lapses+1, "retired", card.Retired)
+}
+
+// clearLeech gives the card a fresh start: earlier lapses no longer count
+func clearLeech(card *types.Card, now time.Time) {
+	card.Leech = false
+	card.LapsesSince = now
+}
+
+// GetLeeches lists the leeches across all of the user's decks
+func (s *Service) GetLeeches(username string) ([]types.Leech, error) {
+	decks, err := s.deckRepo.GetAllDecksByUser(username)
+	if err != nil {
+		s.logger.Error("Failed to retrieve decks", "username", username, "error", err)
+		return nil, err
+	}
+
+	leeches := []types.Leech{}
+	index := map[string]int{} // A card can be in more than one deck
+	for _, deck := range decks {
+		for _, card := range deck.Cards {
+			if !card.Leech {
+				continue
+			}
+			if i, ok := index[card.ID]; ok {
+				leeches[i].Decks = append(leeches[i].Decks, types.LeechDeck{ID: deck.ID, Name: deck.Name})
+				continue
+			}
+
+			lapses, err := s.sessionLogRepo.CountLapses(card.ID, card.LapsesSince)
+			if err != nil {
+				s.logger.Error("Failed to count lapses", "card_id", card.ID, "error", err)
+				return nil, err
+			}
+
+			index[card.ID] = len(leeches)
+			leeches = append(leeches, types.Leech{
+				CardID:  card.ID,
+				Front:   card.Front.Text,
+				Back:    card.Back.Text,
+				Lapses:  int(lapses),
+				Retired: card.Retired,
+				Decks:   []types.LeechDeck{{ID: deck.ID, Name: deck.Name}},
+				Explain: types.ExplainShortcut{
+					Method: "POST",
+					Path:   fmt.Sprintf("/api/cards/explain/%s", card.ID),
+					Prompt: leechExplainPrompt,
+				},
+			})
+		}
+	}
+
+	// The explain shortcut only works with an LLM configured
+	if len(leeches) > 0 && s.IsLLMAvailable() {
+		for i := range leeches {
+			leeches[i].Explain.Available = true
+		}
+	}
+
+	return leeches, nil
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/robstave/meowmorize/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUpdateCardStats_FlagsAndRetiresLeech(t *testing.T) {
+	card := types.Card{ID: "card1", FailCount: 3}
+
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow", LeechThreshold: 4, LeechAction: types.LeechRetire}, nil)
+	cardRepo.On("GetCardByID", "card1").Return(&card, nil)
+	sessionRepo.On("CountLapses", "card1", mock.Anything).Return(int64(3), nil)
+	cardRepo.On("UpdateCard", mock.MatchedBy(func(c types.Card) bool {
+		return c.Leech && c.Retired && c.FailCount == 4
+	})).Return(nil).Once()
+
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	assert.NoError(t, s.UpdateCardStats("card1", types.IncrementFail, nil, "", "meow"))
+	cardRepo.AssertExpectations(t)
+}
+
+func TestUpdateCardStats_BelowLeechThreshold(t *testing.T) {
+	card := types.Card{ID: "card1"}
+
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow", LeechThreshold: 4, LeechAction: types.LeechFlag}, nil)
+	cardRepo.On("GetCardByID", "card1").Return(&card, nil)
+	sessionRepo.On("CountLapses", "card1", mock.Anything).Return(int64(2), nil)
+	cardRepo.On("UpdateCard", mock.MatchedBy(func(c types.Card) bool {
+		return !c.Leech && !c.Retired
+	})).Return(nil).Once()
+
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	assert.NoError(t, s.UpdateCardStats("card1", types.IncrementFail, nil, "", "meow"))
+	cardRepo.AssertExpectations(t)
+}
+
+func TestUpdateCardStats_ClearLeech(t *testing.T) {
+	card := types.Card{ID: "card1", Leech: true}
+
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	cardRepo.On("GetCardByID", "card1").Return(&card, nil)
+	cardRepo.On("UpdateCard", mock.MatchedBy(func(c types.Card) bool {
+		return !c.Leech && !c.LapsesSince.IsZero()
+	})).Return(nil).Once()
+
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	assert.NoError(t, s.UpdateCardStats("card1", types.ClearLeech, nil, "", "meow"))
+	cardRepo.AssertExpectations(t)
+}
+
+func TestGetLeeches(t *testing.T) {
+	leech := types.Card{ID: "leech", Front: types.CardFront{Text: "Capital of Burkina Faso"}, Leech: true}
+	decks := []types.Deck{
+		{ID: "deck1", Name: "Capitals", Cards: []types.Card{leech, {ID: "fine"}}},
+		{ID: "deck2", Name: "Africa", Cards: []types.Card{leech}},
+	}
+
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	deckRepo.On("GetAllDecksByUser", "meow").Return(decks, nil)
+	sessionRepo.On("CountLapses", "leech", mock.Anything).Return(int64(9), nil).Once()
+	llmRepo.On("Available").Return(true)
+
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	leeches, err := s.GetLeeches("meow")
+	assert.NoError(t, err)
+	if assert.Len(t, leeches, 1) {
+		assert.Equal(t, 9, leeches[0].Lapses)
+		assert.Equal(t, []types.LeechDeck{{ID: "deck1", Name: "Capitals"}, {ID: "deck2", Name: "Africa"}}, leeches[0].Decks)
+		assert.True(t, leeches[0].Explain.Available)
+		assert.Equal(t, "/api/cards/explain/leech", leeches[0].Explain.Path)
+	}
+	sessionRepo.AssertExpectations(t)
+}
@@ -0,0 +1,351 @@
+// internal/domain/lint.go
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/robstave/meowmorize/internal/domain/types"
+)
+
+// lintCategories are the issue categories of the lint rubric; others are dropped
+var lintCategories = []string{types.LintAmbiguity, types.LintMultipleFacts, types.LintFactual, types.LintFormatting}
+
+// StartDeckLint starts linting every card of the user's deck in the background and returns
+// the job to follow. Findings of an earlier job nobody acted on are replaced.
+func (s *Service) StartDeckLint(deckID string, username string) (types.LintJob, error) {
+	deck, err := s.deckRepo.GetDeckByID(deckID)
+	if err != nil || deck.UserID != username {
+		return types.LintJob{}, errors.New("deck not found")
+	}
+	if !s.IsLLMAvailable() {
+		return types.LintJob{}, types.ErrLLMNotInitialized
+	}
+
+	// Held from the check for a running job until the new one is registered, so two
+	// quick requests cannot both start a job for the deck
+	s.lintMu.Lock()
+	defer s.lintMu.Unlock()
+
+	latest, err := s.lintRepo.GetLatestJob(deckID, username)
+	if err != nil {
+		s.logger.Error("Failed to retrieve lint job", "deck_id", deckID, "error", err)
+		return types.LintJob{}, err
+	}
+	if latest != nil && latest.Status == types.LintRunning {
+		return types.LintJob{}, errors.New("deck is already being linted")
+	}
+
+	if err := s.lintRepo.DeletePendingFindings(deckID, username); err != nil {
+		s.logger.Error("Failed to delete pending lint findings", "deck_id", deckID, "error", err)
+		return types.LintJob{}, err
+	}
+
+	job := types.LintJob{
+		ID:        uuid.New().String(),
+		DeckID:    deckID,
+		UserID:    username,
+		Status:    types.LintRunning,
+		Total:     len(deck.Cards),
+		StartedAt: time.Now(),
+	}
+	if err := s.lintRepo.CreateJob(job); err != nil {
+		s.logger.Error("Failed to create lint job", "deck_id", deckID, "error", err)
+		return types.LintJob{}, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.lintCancels[job.ID] = cancel
+
+	s.logger.Info("Lint job started", "job_id", job.ID, "deck_id", deckID, "cards", job.Total)
+	go s.runLintJob(ctx, job, deck)
+	return job, nil
+}
+
+// runLintJob lints the cards one by one, saving the progress after each. The job stops
+// early when it is cancelled, the LLM goes away or the user's quota is used up.
+func (s *Service) runLintJob(ctx context.Context, job types.LintJob, deck types.Deck) {
+	defer func() {
+		s.lintMu.Lock()
+		if cancel, ok := s.lintCancels[job.ID]; ok {
+			cancel()
+			delete(s.lintCancels, job.ID)
+		}
+		s.lintMu.Unlock()
+	}()
+
+	var stopErr error
+	for _, card := range deck.Cards {
+		if ctx.Err() != nil {
+			break
+		}
+
+		finding, err := s.lintCard(ctx, job, deck.Name, card)
+		if err != nil && (ctx.Err() != nil || errors.Is(err, types.ErrLLMQuotaExceeded) || errors.Is(err, types.ErrLLMNotInitialized)) {
+			stopErr = err
+			break
+		}
+		switch {
+		case err != nil:
+			s.logger.Warn("Failed to lint card", "job_id", job.ID, "card_id", card.ID, "error", err)
+			job.Failed++
+		case finding != nil:
+			if err := s.lintRepo.AddFinding(*finding); err != nil {
+				s.logger.Error("Failed to save lint finding", "job_id", job.ID, "card_id", card.ID, "error", err)
+				job.Failed++
+			} else {
+				job.Findings++
+			}
+		}
+
+		job.Processed++
+		if err := s.lintRepo.UpdateJob(job); err != nil {
+			s.logger.Error("Failed to save lint progress", "job_id", job.ID, "error", err)
+		}
+	}
+
+	switch {
+	case ctx.Err() != nil:
+		job.Status = types.LintCancelled
+	case stopErr != nil:
+		job.Status = types.LintFailed
+		job.Error = stopErr.Error()
+	default:
+		job.Status = types.LintDone
+	}
+	job.FinishedAt = time.Now()
+	if err := s.lintRepo.UpdateJob(job); err != nil {
+		s.logger.Error("Failed to finish lint job", "job_id", job.ID, "error", err)
+	}
+	s.logger.Info("Lint job finished", "job_id", job.ID, "status", job.Status, "processed", job.Processed, "findings", job.Findings)
+}
+
+// lintCard has the LLM review the card, returning nil when it found nothing wrong
+func (s *Service) lintCard(ctx context.Context, job types.LintJob, deckName string, card types.Card) (*types.LintFinding, error) {
+	prompt, err := s.renderPrompt(job.UserID, types.LLMPurposeLint, types.PromptData{
+		Front: card.Front.Text,
+		Back:  card.Back.Text,
+		Deck:  deckName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.runPrompt(ctx, job.UserID, prompt, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	issues, front, back, err := parseLint(response)
+	if err != nil {
+		return nil, err
+	}
+	if len(issues) == 0 {
+		return nil, nil
+	}
+	if front == card.Front.Text {
+		front = ""
+	}
+	if back == card.Back.Text {
+		back = ""
+	}
+
+	return &types.LintFinding{
+		ID:             uuid.New().String(),
+		JobID:          job.ID,
+		DeckID:         job.DeckID,
+		CardID:         card.ID,
+		UserID:         job.UserID,
+		Issues:         issues,
+		SuggestedFront: front,
+		SuggestedBack:  back,
+		Status:         types.FindingPending,
+		CreatedAt:      time.Now(),
+	}, nil
+}
+
+// GetDeckLint returns the user's latest lint job on the deck
+func (s *Service) GetDeckLint(deckID string, username string) (types.LintJob, error) {
+	job, err := s.lintRepo.GetLatestJob(deckID, username)
+	if err != nil {
+		s.logger.Error("Failed to retrieve lint job", "deck_id", deckID, "error", err)
+		return types.LintJob{}, err
+	}
+	if job == nil {
+		return types.LintJob{}, errors.New("lint job not found")
+	}
+	return *job, nil
+}
+
+// CancelDeckLint stops the running lint job on the deck. The findings so far are kept.
+func (s *Service) CancelDeckLint(deckID string, username string) error {
+	job, err := s.GetDeckLint(deckID, username)
+	if err != nil {
+		return err
+	}
+
+	s.lintMu.Lock()
+	cancel, ok := s.lintCancels[job.ID]
+	s.lintMu.Unlock()
+	if job.Status != types.LintRunning || !ok {
+		return errors.New("no lint job is running")
+	}
+	cancel()
+	return nil
+}
+
+// GetLintFindings lists the user's findings on the deck, optionally only those with the status
+func (s *Service) GetLintFindings(deckID string, username string, status string) ([]types.LintFinding, error) {
+	findings, err := s.lintRepo.GetFindingsByDeck(deckID, username, status)
+	if err != nil {
+		s.logger.Error("Failed to retrieve lint findings", "deck_id", deckID, "error", err)
+		return nil, err
+	}
+	if findings == nil {
+		findings = []types.LintFinding{}
+	}
+	return findings, nil
+}
+
+// AcceptLintFinding rewrites the card with the suggestion of the finding, with any edits
+// applied, and records the change as a revision of the card
+func (s *Service) AcceptLintFinding(findingID string, username string, edit types.LintFindingEdit) (*types.Card, error) {
+	finding, err := s.pendingFinding(findingID, username)
+	if err != nil {
+		return nil, err
+	}
+
+	card, err := s.cardRepo.GetCardByID(finding.CardID)
+	if err != nil {
+		s.logger.Error("Failed to retrieve card", "card_id", finding.CardID, "error", err)
+		return nil, err
+	}
+	if card == nil {
+		return nil, errors.New("card not found")
+	}
+
+	front, back := card.Front.Text, card.Back.Text
+	if finding.SuggestedFront != "" {
+		front = finding.SuggestedFront
+	}
+	if finding.SuggestedBack != "" {
+		back = finding.SuggestedBack
+	}
+	if edit.Front != nil {
+		front = *edit.Front
+	}
+	if edit.Back != nil {
+		back = *edit.Back
+	}
+	if strings.TrimSpace(front) == "" || strings.TrimSpace(back) == "" {
+		return nil, errors.New("card front and back are required")
+	}
+
+	if front != card.Front.Text || back != card.Back.Text {
+		revision := types.CardRevision{
+			ID:        uuid.New().String(),
+			CardID:    card.ID,
+			UserID:    username,
+			Source:    types.RevisionLint,
+			SourceID:  finding.ID,
+			FrontFrom: card.Front.Text,
+			FrontTo:   front,
+			BackFrom:  card.Back.Text,
+			BackTo:    back,
+			CreatedAt: time.Now(),
+		}
+		card.Front.Text = front
+		card.Back.Text = back
+		if err := s.cardRepo.UpdateCard(*card); err != nil {
+			s.logger.Error("Failed to update card", "card_id", card.ID, "error", err)
+			return nil, err
+		}
+		if err := s.cardRepo.AddRevision(revision); err != nil {
+			s.logger.Error("Failed to record card revision", "card_id", card.ID, "error", err)
+			return nil, err
+		}
+		s.markTranslationsStale(card.ID)
+	}
+
+	if err := s.lintRepo.UpdateFindingStatus(finding.ID, types.FindingAccepted); err != nil {
+		s.logger.Error("Failed to update lint finding", "finding_id", finding.ID, "error", err)
+		return nil, err
+	}
+	s.logger.Info("Lint finding accepted", "finding_id", finding.ID, "card_id", card.ID)
+	return card, nil
+}
+
+// DismissLintFinding marks the finding as dismissed without changing the card
+func (s *Service) DismissLintFinding(findingID string, username string) error {
+	finding, err := s.pendingFinding(findingID, username)
+	if err != nil {
+		return err
+	}
+	if err := s.lintRepo.UpdateFindingStatus(finding.ID, types.FindingDismissed); err != nil {
+		s.logger.Error("Failed to update lint finding", "finding_id", finding.ID, "error", err)
+		return err
+	}
+	return nil
+}
+
+// GetCardRevisions lists the changes to the card, newest first
+func (s *Service) GetCardRevisions(cardID string) ([]types.CardRevision, error) {
+	revisions, err := s.cardRepo.GetRevisions(cardID)
+	if err != nil {
+		s.logger.Error("Failed to retrieve card revisions", "card_id", cardID, "error", err)
+		return nil, err
+	}
+	if revisions == nil {
+		revisions = []types.CardRevision{}
+	}
+	return revisions, nil
+}
+
+// pendingFinding returns the finding if it belongs to the user and nobody acted on it yet
+func (s *Service) pendingFinding(findingID string, username string) (*types.LintFinding, error) {
+	finding, err := s.lintRepo.GetFindingByID(findingID)
+	if err != nil {
+		s.logger.Error("Failed to retrieve lint finding", "finding_id", findingID, "error", err)
+		return nil, err
+	}
+	if finding == nil || finding.UserID != username {
+		return nil, errors.New("finding not found")
+	}
+	if finding.Status != types.FindingPending {
+		return nil, errors.New("finding already resolved")
+	}
+	return finding, nil
+}
+
+// parseLint reads the issues and the suggested rewrite from the LLM's reply
+func parseLint(response string) ([]types.LintIssue, string, string, error) {
+	raw := jsonObjectPattern.FindString(response)
+	if raw == "" {
+		return nil, "", "", errors.New("no JSON object in the response")
+	}
+
+	var reply struct {
+		Issues []types.LintIssue `json:"issues"`
+		Front  string            `json:"front"`
+		Back   string            `json:"back"`
+	}
+	if err := json.Unmarshal([]byte(raw), &reply); err != nil {
+		return nil, "", "", err
+	}
+
+	var issues []types.LintIssue
+	for _, issue := range reply.Issues {
+		issue.Category = strings.ToLower(strings.TrimSpace(issue.Category))
+		if !slices.Contains(lintCategories, issue.Category) {
+			continue
+		}
+		issue.Message = strings.TrimSpace(issue.Message)
+		issues = append(issues, issue)
+	}
+	return issues, strings.TrimSpace(reply.Front), strings.TrimSpace(reply.Back), nil
+}
@@ -0,0 +1,237 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/robstave/meowmorize/internal/adapters/repositories/mocks"
+	"github.com/robstave/meowmorize/internal/domain/types"
+	"github.com/robstave/meowmorize/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type lintTestMocks struct {
+	cardRepo *mocks.CardRepository
+	deckRepo *mocks.DeckRepository
+	llmRepo  *mocks.LLMRepository
+	lintRepo *mocks.LintRepository
+}
+
+func newLintTestService() (*Service, lintTestMocks) {
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+	lintRepo := setupLintRepository()
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+
+	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo, nil, nil, nil, nil, nil, lintRepo).(*Service)
+	return s, lintTestMocks{cardRepo: cardRepo, deckRepo: deckRepo, llmRepo: llmRepo, lintRepo: lintRepo}
+}
+
+// recordJobUpdates keeps every saved state of the job
+func recordJobUpdates(lintRepo *mocks.LintRepository) func() []types.LintJob {
+	var mu sync.Mutex
+	var updates []types.LintJob
+	lintRepo.On("UpdateJob", mock.Anything).Run(func(args mock.Arguments) {
+		mu.Lock()
+		updates = append(updates, args.Get(0).(types.LintJob))
+		mu.Unlock()
+	}).Return(nil)
+	return func() []types.LintJob {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]types.LintJob(nil), updates...)
+	}
+}
+
+func TestRunLintJob(t *testing.T) {
+	s, m := newLintTestService()
+	updates := recordJobUpdates(m.lintRepo)
+
+	deck := types.Deck{ID: "deck1", Name: "Capitals", UserID: "meow", Cards: []types.Card{
+		{ID: "c1", Front: types.CardFront{Text: "Capital?"}, Back: types.CardBack{Text: "Paris"}},
+		{ID: "c2", Front: types.CardFront{Text: "Capital of Italy?"}, Back: types.CardBack{Text: "Rome"}},
+		{ID: "c3", Front: types.CardFront{Text: "Capital of Spain?"}, Back: types.CardBack{Text: "Madrid"}},
+	}}
+	m.llmRepo.On("RunPrompt", mock.Anything, mock.MatchedBy(func(prompt string) bool {
+		return strings.Contains(prompt, "Capital?")
+	})).Return("```json\n"+`{"issues": [{"category": "Ambiguity", "message": "Which country?"}, {"category": "spelling", "message": "?"}], "front": "Capital of France?", "back": "Paris"}`+"\n```", nil)
+	m.llmRepo.On("RunPrompt", mock.Anything, mock.MatchedBy(func(prompt string) bool {
+		return strings.Contains(prompt, "Capital of Italy?")
+	})).Return(`{"issues": [], "front": "", "back": ""}`, nil)
+	m.llmRepo.On("RunPrompt", mock.Anything, mock.MatchedBy(func(prompt string) bool {
+		return strings.Contains(prompt, "Capital of Spain?")
+	})).Return("Looks fine to me!", nil)
+	m.lintRepo.On("AddFinding", mock.MatchedBy(func(finding types.LintFinding) bool {
+		return finding.CardID == "c1" && finding.JobID == "job1" && finding.DeckID == "deck1" && finding.Status == types.FindingPending &&
+			len(finding.Issues) == 1 && finding.Issues[0] == types.LintIssue{Category: types.LintAmbiguity, Message: "Which country?"} &&
+			finding.SuggestedFront == "Capital of France?" && finding.SuggestedBack == "" // Unchanged
+	})).Return(nil).Once()
+
+	s.runLintJob(context.Background(), types.LintJob{ID: "job1", DeckID: "deck1", UserID: "meow", Status: types.LintRunning, Total: 3}, deck)
+
+	saved := updates()
+	if assert.Len(t, saved, 4) { // After each card and at the end
+		assert.Equal(t, 1, saved[0].Processed)
+		final := saved[3]
+		assert.Equal(t, types.LintDone, final.Status)
+		assert.Equal(t, 3, final.Processed)
+		assert.Equal(t, 1, final.Findings)
+		assert.Equal(t, 1, final.Failed)
+		assert.False(t, final.FinishedAt.IsZero())
+	}
+	m.lintRepo.AssertExpectations(t)
+}
+
+func TestRunLintJob_Stops(t *testing.T) {
+	s, m := newLintTestService()
+	updates := recordJobUpdates(m.lintRepo)
+
+	deck := types.Deck{ID: "deck1", Cards: []types.Card{{ID: "c1"}, {ID: "c2"}}}
+	m.llmRepo.On("RunPrompt", mock.Anything, mock.Anything).Return("", types.ErrLLMQuotaExceeded).Once()
+
+	s.runLintJob(context.Background(), types.LintJob{ID: "job1", UserID: "meow", Status: types.LintRunning, Total: 2}, deck)
+	saved := updates()
+	if assert.Len(t, saved, 1) {
+		assert.Equal(t, types.LintFailed, saved[0].Status)
+		assert.Equal(t, "daily LLM quota reached", saved[0].Error)
+		assert.Equal(t, 0, saved[0].Processed)
+	}
+
+	// A cancelled job ends as cancelled
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s.runLintJob(ctx, types.LintJob{ID: "job2", UserID: "meow", Status: types.LintRunning, Total: 2}, deck)
+	saved = updates()
+	if assert.Len(t, saved, 2) {
+		assert.Equal(t, types.LintCancelled, saved[1].Status)
+	}
+	m.llmRepo.AssertNumberOfCalls(t, "RunPrompt", 1)
+}
+
+func TestStartDeckLint(t *testing.T) {
+	s, m := newLintTestService()
+	updates := recordJobUpdates(m.lintRepo)
+
+	m.llmRepo.On("Available").Return(true)
+	m.deckRepo.On("GetDeckByID", "deck1").Return(types.Deck{ID: "deck1", UserID: "meow"}, nil)
+	m.deckRepo.On("GetDeckByID", "busy").Return(types.Deck{ID: "busy", UserID: "meow"}, nil)
+	m.deckRepo.On("GetDeckByID", "other").Return(types.Deck{ID: "other", UserID: "someone"}, nil)
+	m.lintRepo.On("GetLatestJob", "deck1", "meow").Return(&types.LintJob{Status: types.LintDone}, nil)
+	m.lintRepo.On("GetLatestJob", "busy", "meow").Return(&types.LintJob{Status: types.LintRunning}, nil)
+	m.lintRepo.On("DeletePendingFindings", "deck1", "meow").Return(nil)
+	m.lintRepo.On("CreateJob", mock.Anything).Return(nil)
+
+	_, err := s.StartDeckLint("other", "meow")
+	assert.EqualError(t, err, "deck not found")
+	_, err = s.StartDeckLint("busy", "meow")
+	assert.EqualError(t, err, "deck is already being linted")
+
+	job, err := s.StartDeckLint("deck1", "meow")
+	assert.NoError(t, err)
+	assert.Equal(t, types.LintRunning, job.Status)
+	assert.NotEmpty(t, job.ID)
+
+	// The deck has no cards, so the job finishes right away
+	assert.Eventually(t, func() bool {
+		saved := updates()
+		return len(saved) == 1 && saved[0].Status == types.LintDone
+	}, time.Second, 10*time.Millisecond)
+	m.lintRepo.AssertExpectations(t)
+}
+
+func TestStartDeckLint_OneJobAtATime(t *testing.T) {
+	s, m := newLintTestService()
+	recordJobUpdates(m.lintRepo)
+
+	var mu sync.Mutex
+	var created []types.LintJob
+	m.llmRepo.On("Available").Return(true)
+	m.deckRepo.On("GetDeckByID", "deck1").Return(types.Deck{ID: "deck1", UserID: "meow"}, nil)
+	m.lintRepo.On("GetLatestJob", "deck1", "meow").Return(func(string, string) *types.LintJob {
+		mu.Lock()
+		defer mu.Unlock()
+		if len(created) == 0 {
+			return nil
+		}
+		// The job stays running as far as the other requests can tell
+		return &types.LintJob{Status: types.LintRunning}
+	}, nil)
+	m.lintRepo.On("DeletePendingFindings", "deck1", "meow").Return(nil)
+	m.lintRepo.On("CreateJob", mock.Anything).Run(func(args mock.Arguments) {
+		mu.Lock()
+		created = append(created, args.Get(0).(types.LintJob))
+		mu.Unlock()
+	}).Return(nil)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := s.StartDeckLint("deck1", "meow")
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	started := 0
+	for err := range errs {
+		if err == nil {
+			started++
+		} else {
+			assert.EqualError(t, err, "deck is already being linted")
+		}
+	}
+	assert.Equal(t, 1, started)
+	assert.Len(t, created, 1)
+}
+
+func TestAcceptLintFinding(t *testing.T) {
+	s, m := newLintTestService()
+
+	finding := &types.LintFinding{ID: "f1", CardID: "c1", UserID: "meow", Status: types.FindingPending, SuggestedFront: "Capital of France?"}
+	m.lintRepo.On("GetFindingByID", "f1").Return(finding, nil)
+	m.lintRepo.On("GetFindingByID", "done").Return(&types.LintFinding{ID: "done", UserID: "meow", Status: types.FindingDismissed}, nil)
+	m.lintRepo.On("GetFindingByID", "theirs").Return(&types.LintFinding{ID: "theirs", UserID: "someone", Status: types.FindingPending}, nil)
+	m.cardRepo.On("GetCardByID", "c1").Return(&types.Card{ID: "c1", Front: types.CardFront{Text: "Capital?"}, Back: types.CardBack{Text: "Paris"}}, nil)
+	m.cardRepo.On("UpdateCard", mock.MatchedBy(func(card types.Card) bool {
+		return card.Front.Text == "Capital of France?" && card.Back.Text == "Paris, on the Seine"
+	})).Return(nil)
+	m.cardRepo.On("AddRevision", mock.MatchedBy(func(revision types.CardRevision) bool {
+		return revision.CardID == "c1" && revision.Source == types.RevisionLint && revision.SourceID == "f1" &&
+			revision.FrontFrom == "Capital?" && revision.FrontTo == "Capital of France?" &&
+			revision.BackFrom == "Paris" && revision.BackTo == "Paris, on the Seine"
+	})).Return(nil)
+	m.cardRepo.On("MarkTranslationsStale", "c1").Return(nil)
+	m.lintRepo.On("UpdateFindingStatus", "f1", types.FindingAccepted).Return(nil)
+
+	back := "Paris, on the Seine"
+	card, err := s.AcceptLintFinding("f1", "meow", types.LintFindingEdit{Back: &back})
+	assert.NoError(t, err)
+	assert.Equal(t, "Capital of France?", card.Front.Text)
+
+	_, err = s.AcceptLintFinding("done", "meow", types.LintFindingEdit{})
+	assert.EqualError(t, err, "finding already resolved")
+	_, err = s.AcceptLintFinding("theirs", "meow", types.LintFindingEdit{})
+	assert.EqualError(t, err, "finding not found")
+	m.cardRepo.AssertExpectations(t)
+	m.lintRepo.AssertExpectations(t)
+}
+
+func TestDismissLintFinding(t *testing.T) {
+	s, m := newLintTestService()
+
+	m.lintRepo.On("GetFindingByID", "f1").Return(&types.LintFinding{ID: "f1", UserID: "meow", Status: types.FindingPending}, nil)
+	m.lintRepo.On("GetFindingByID", "missing").Return(nil, nil)
+	m.lintRepo.On("UpdateFindingStatus", "f1", types.FindingDismissed).Return(nil)
+
+	assert.NoError(t, s.DismissLintFinding("f1", "meow"))
+	assert.EqualError(t, s.DismissLintFinding("missing", "meow"), "finding not found")
+	m.cardRepo.AssertNotCalled(t, "UpdateCard", mock.Anything)
+}
@@ -7,12 +7,11 @@ import (
 	types "github.com/robstave/meowmorize/internal/domain/types"
 )
 
-// GetExplanation sends a prompt to the LLM service and returns the response
-func (s *Service) GetExplanation(prompt string) (string, error) {
+// GetExplanation sends a prompt to the LLM service for the user and returns the response
+func (s *Service) GetExplanation(ctx context.Context, username string, prompt string) (string, error) {
 	s.logger.Info("Getting LLM explanation", "prompt_length", len(prompt))
 
-	ctx := context.Background()
-	response, err := s.llmRepo.RunPrompt(ctx, prompt)
+	response, err := s.runPrompt(ctx, username, renderedPrompt{kind: types.LLMPurposeExplain, text: prompt}, nil)
 	if err != nil {
 		s.logger.Error("Failed to get LLM explanation", "error", err)
 		return "", err
@@ -21,11 +20,23 @@ func (s *Service) GetExplanation(prompt string) (string, error) {
 	return response, nil
 }
 
+// IsLLMAvailable reports whether the LLM is configured. No prompt is sent, so checking
+// costs nothing.
 func (s *Service) IsLLMAvailable() bool {
 	if s.llmRepo == nil {
 		return false
 	}
-	// Try a simple prompt to verify LLM is working
-	_, err := s.llmRepo.RunPrompt(context.Background(), "test")
-	return err != types.ErrLLMNotInitialized
+	return s.llmRepo.Available()
+}
+
+// GetLLMStatus reports the availability of the LLM along with the provider and model in use
+func (s *Service) GetLLMStatus() types.LLMStatus {
+	if s.llmRepo == nil {
+		return types.LLMStatus{}
+	}
+	return types.LLMStatus{
+		Available: s.IsLLMAvailable(),
+		Provider:  s.llmRepo.Provider(),
+		Model:     s.llmRepo.Model(),
+	}
 }
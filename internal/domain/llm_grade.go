@@ -0,0 +1,99 @@
+// internal/domain/llm_grade.go
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/robstave/meowmorize/internal/domain/types"
+)
+
+// jsonObjectPattern finds the JSON object of a reply, also inside a code fence or prose
+var jsonObjectPattern = regexp.MustCompile(`(?s)\{.*\}`)
+
+// gradeAnswerWithLLM has the LLM grade the answer against the card. When the LLM fails or
+// replies with something that is not a verdict, or the user's quota is used up, the grade
+// asks the user to grade themselves.
+func (s *Service) gradeAnswerWithLLM(ctx context.Context, card types.Card, answer string, deckID string, userID string) types.AnswerGrade {
+	grade := types.AnswerGrade{
+		CardID:   card.ID,
+		Answer:   answer,
+		Expected: card.Back.Text,
+	}
+
+	if strings.TrimSpace(answer) == "" {
+		grade.Action = types.IncrementSkip
+		return grade
+	}
+
+	if s.llmRepo == nil {
+		grade.SelfGrade = true
+		return grade
+	}
+
+	data := types.PromptData{
+		Front:      card.Front.Text,
+		Back:       card.Back.Text,
+		Answer:     answer,
+		Alternates: card.Alternates,
+	}
+	if deck, err := s.deckRepo.GetDeckByID(deckID); err == nil {
+		data.Deck = deck.Name
+	}
+	prompt, err := s.renderPrompt(userID, types.LLMPurposeGrade, data)
+	if err != nil {
+		s.logger.Warn("Rendering the grading prompt failed, falling back to self grading", "card_id", card.ID, "error", err)
+		grade.SelfGrade = true
+		return grade
+	}
+
+	response, err := s.runPrompt(ctx, userID, prompt, nil)
+	if err != nil {
+		s.logger.Warn("LLM grading failed, falling back to self grading", "card_id", card.ID, "error", err)
+		grade.SelfGrade = true
+		return grade
+	}
+
+	verdict, feedback, err := parseVerdict(response)
+	if err != nil {
+		s.logger.Warn("Malformed LLM verdict, falling back to self grading", "card_id", card.ID, "error", err)
+		grade.SelfGrade = true
+		return grade
+	}
+
+	grade.Verdict = verdict
+	grade.Feedback = feedback
+	grade.Correct = verdict != types.FailVerdict
+	grade.Action = types.IncrementFail
+	if grade.Correct {
+		grade.Action = types.IncrementPass
+	}
+	return grade
+}
+
+// parseVerdict reads the verdict and feedback from the LLM's reply
+func parseVerdict(response string) (types.Verdict, string, error) {
+	raw := jsonObjectPattern.FindString(response)
+	if raw == "" {
+		return "", "", errors.New("no JSON object in the response")
+	}
+
+	var reply struct {
+		Verdict  string `json:"verdict"`
+		Feedback string `json:"feedback"`
+	}
+	if err := json.Unmarshal([]byte(raw), &reply); err != nil {
+		return "", "", err
+	}
+
+	verdict := types.Verdict(strings.ToLower(strings.TrimSpace(reply.Verdict)))
+	switch verdict {
+	case types.PassVerdict, types.PartialVerdict, types.FailVerdict:
+		return verdict, strings.TrimSpace(reply.Feedback), nil
+	}
+	return "", "", fmt.Errorf("unknown verdict %q", reply.Verdict)
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/robstave/meowmorize/internal/adapters/repositories/mocks"
+	"github.com/robstave/meowmorize/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestParseVerdict(t *testing.T) {
+	verdict, feedback, err := parseVerdict(`{"verdict": "pass", "feedback": "Spot on."}`)
+	assert.NoError(t, err)
+	assert.Equal(t, types.PassVerdict, verdict)
+	assert.Equal(t, "Spot on.", feedback)
+
+	// Code fences, prose and odd casing are tolerated
+	verdict, _, err = parseVerdict("Sure!\n```json\n{\"verdict\": \" Partial \", \"feedback\": \"Almost.\"}\n```")
+	assert.NoError(t, err)
+	assert.Equal(t, types.PartialVerdict, verdict)
+
+	_, _, err = parseVerdict("The answer is correct.")
+	assert.Error(t, err)
+	_, _, err = parseVerdict(`{"verdict": "maybe"}`)
+	assert.Error(t, err)
+	_, _, err = parseVerdict(`{"verdict": pass}`)
+	assert.Error(t, err)
+}
+
+// startLLMGradedSession starts an LLM graded session on a one card deck
+func startLLMGradedSession(t *testing.T, llmResponse string, llmErr error) (MeowDomain, string, *mocks.CardRepository, *mocks.SessionLogRepository) {
+	deckID := uuid.New().String()
+	card := types.Card{
+		ID:     "card1",
+		UserID: "meow",
+		Front:  types.CardFront{Text: "Why do cats purr?"},
+		Back:   types.CardBack{Text: "To communicate contentment and to self-soothe"},
+	}
+	deck := types.Deck{ID: deckID, Cards: []types.Card{card}}
+
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	deckRepo.On("GetDeckByID", deckID).Return(deck, nil)
+	deckRepo.On("UpdateDeck", mock.AnythingOfType("types.Deck")).Return(nil)
+	cardRepo.On("GetCardByID", "card1").Return(&card, nil)
+	llmRepo.On("RunPrompt", mock.Anything, mock.Anything).Return(llmResponse, llmErr)
+	sessionRepo.On("GetRecentResponseTimes", "card1", mock.Anything).Return(nil, nil).Maybe()
+
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	err := s.StartSession(deckID, -1, types.RandomMethod, "meow", types.SessionOptions{Mode: types.LLMGradedMode})
+	assert.NoError(t, err)
+	_, err = s.GetNextCard(deckID)
+	assert.NoError(t, err)
+
+	return s, deckID, cardRepo, sessionRepo
+}
+
+func TestSubmitAnswer_LLMGradedPartial(t *testing.T) {
+	s, deckID, cardRepo, sessionRepo := startLLMGradedSession(t, `{"verdict": "partial", "feedback": "Purring also soothes."}`, nil)
+
+	cardRepo.On("UpdateCard", mock.MatchedBy(func(c types.Card) bool {
+		return c.PassCount == 1 && c.IntervalDays == 1
+	})).Return(nil)
+	sessionRepo.On("CreateLog", mock.MatchedBy(func(log types.SessionLog) bool {
+		return log.Action == string(types.IncrementPass) && log.Answer == "they are happy"
+	})).Return(nil)
+
+	grade, err := s.SubmitAnswer(context.Background(), deckID, "card1", "they are happy", "meow")
+	assert.NoError(t, err)
+	assert.True(t, grade.Correct)
+	assert.Equal(t, types.PartialVerdict, grade.Verdict)
+	assert.Equal(t, "Purring also soothes.", grade.Feedback)
+	assert.Equal(t, types.IncrementPass, grade.Action)
+	cardRepo.AssertExpectations(t)
+	sessionRepo.AssertExpectations(t)
+}
+
+func TestSubmitAnswer_LLMGradedFallback(t *testing.T) {
+	for name, response := range map[string]struct {
+		text string
+		err  error
+	}{
+		"malformed": {text: "I think this is mostly right!"},
+		"error":     {err: errors.New("connection refused")},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s, deckID, cardRepo, sessionRepo := startLLMGradedSession(t, response.text, response.err)
+
+			grade, err := s.SubmitAnswer(context.Background(), deckID, "card1", "they are happy", "meow")
+			assert.NoError(t, err)
+			assert.True(t, grade.SelfGrade)
+			assert.Empty(t, grade.Action)
+			cardRepo.AssertNotCalled(t, "UpdateCard", mock.Anything)
+			sessionRepo.AssertNotCalled(t, "CreateLog", mock.Anything)
+
+			// The user grades the card themselves instead
+			cardRepo.On("UpdateCard", mock.AnythingOfType("types.Card")).Return(nil)
+			sessionRepo.On("CreateLog", mock.AnythingOfType("types.SessionLog")).Return(nil)
+			assert.NoError(t, s.UpdateCardStats("card1", types.IncrementPass, nil, deckID, "meow"))
+			cardRepo.AssertCalled(t, "UpdateCard", mock.AnythingOfType("types.Card"))
+		})
+	}
+}
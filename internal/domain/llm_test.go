@@ -1,11 +1,11 @@
 package domain
 
 import (
+	"context"
 	"errors"
 	"testing"
 
 	"github.com/robstave/meowmorize/internal/domain/types"
-	"github.com/robstave/meowmorize/internal/logger"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -19,8 +19,8 @@ func TestGetExplanation(t *testing.T) {
 
 	llmRepo.On("RunPrompt", mock.Anything, "test prompt").Return("answer", nil)
 
-	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
-	resp, err := s.GetExplanation("test prompt")
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	resp, err := s.GetExplanation(context.Background(), "meow", "test prompt")
 	assert.NoError(t, err)
 	assert.Equal(t, "answer", resp)
 	llmRepo.AssertExpectations(t)
@@ -34,8 +34,8 @@ func TestGetExplanation_Error(t *testing.T) {
 
 	llmRepo.On("RunPrompt", mock.Anything, "bad prompt").Return("", errors.New("fail"))
 
-	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
-	resp, err := s.GetExplanation("bad prompt")
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	resp, err := s.GetExplanation(context.Background(), "meow", "bad prompt")
 	assert.Error(t, err)
 	assert.Equal(t, "", resp)
 	llmRepo.AssertExpectations(t)
@@ -48,16 +48,34 @@ func TestIsLLMAvailable(t *testing.T) {
 	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
 
 	// LLM not initialized
-	llmRepo.On("RunPrompt", mock.Anything, "test").Return("", types.ErrLLMNotInitialized)
-	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	llmRepo.On("Available").Return(false)
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
 	assert.False(t, s.IsLLMAvailable())
 
 	// LLM initialized and returns without error
 	llmRepo.ExpectedCalls = nil
-	llmRepo.On("RunPrompt", mock.Anything, "test").Return("ok", nil)
+	llmRepo.On("Available").Return(true)
 	assert.True(t, s.IsLLMAvailable())
+	llmRepo.AssertNotCalled(t, "RunPrompt", mock.Anything, mock.Anything)
 
 	// Service with nil repo
 	sNil := &Service{}
 	assert.False(t, sNil.IsLLMAvailable())
 }
+
+func TestGetLLMStatus(t *testing.T) {
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+
+	llmRepo.On("Available").Return(true)
+	llmRepo.On("Provider").Return("ollama")
+	llmRepo.On("Model").Return("llama3")
+
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	assert.Equal(t, types.LLMStatus{Available: true, Provider: "ollama", Model: "llama3"}, s.GetLLMStatus())
+
+	sNil := &Service{}
+	assert.Equal(t, types.LLMStatus{}, sNil.GetLLMStatus())
+}
@@ -0,0 +1,179 @@
+// internal/domain/llm_usage.go
+package domain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/robstave/meowmorize/internal/domain/types"
+)
+
+// llmCacheTTL is how long a response is reused for an identical prompt
+const llmCacheTTL = 30 * 24 * time.Hour
+
+// charsPerToken is the rough ratio used to estimate tokens from characters
+const charsPerToken = 4
+
+// runPrompt sends the prompt for the user, streaming the response when onChunk is set.
+// An identical prompt to the same model is answered from the cache. Anything else counts
+// against the user's daily quota, and every request goes into the usage ledger.
+func (s *Service) runPrompt(ctx context.Context, username string, prompt renderedPrompt, onChunk func(chunk string) error) (string, error) {
+	if s.usageRepo == nil {
+		return s.sendPrompt(ctx, prompt.text, onChunk)
+	}
+
+	now := time.Now()
+	usage := types.LLMUsage{
+		ID:              uuid.New().String(),
+		UserID:          username,
+		Day:             s.localDayOf(username, now),
+		Purpose:         prompt.kind,
+		Provider:        s.llmRepo.Provider(),
+		Model:           s.llmRepo.Model(),
+		PromptChars:     len(prompt.text),
+		PromptTokens:    estimateTokens(len(prompt.text)),
+		TemplateID:      prompt.templateID,
+		TemplateVersion: prompt.templateVersion,
+		CreatedAt:       now,
+	}
+	hash := promptHash(usage.Provider, usage.Model, prompt.text)
+
+	cached, err := s.usageRepo.GetCachedResponse(hash, now.Add(-llmCacheTTL))
+	if err != nil {
+		s.logger.Warn("Failed to read the LLM cache", "error", err)
+	}
+	if cached != nil {
+		if onChunk != nil {
+			if err := onChunk(cached.Response); err != nil {
+				return "", err
+			}
+		}
+		usage.Cached = true
+		s.recordUsage(usage, cached.Response)
+		return cached.Response, nil
+	}
+
+	if err := s.checkLLMQuota(username, usage.Day); err != nil {
+		return "", err
+	}
+
+	response, err := s.sendPrompt(ctx, prompt.text, onChunk)
+	usage.LatencyMs = time.Since(now).Milliseconds()
+	if errors.Is(err, types.ErrLLMNotInitialized) {
+		// Nothing was sent
+		return "", err
+	}
+	usage.Failed = err != nil
+	s.recordUsage(usage, response)
+	if err != nil {
+		return "", err
+	}
+
+	entry := types.LLMCacheEntry{Hash: hash, Provider: usage.Provider, Model: usage.Model, Response: response, CreatedAt: time.Now()}
+	if err := s.usageRepo.CacheResponse(entry); err != nil {
+		s.logger.Warn("Failed to cache the LLM response", "error", err)
+	}
+	return response, nil
+}
+
+// sendPrompt sends the prompt to the LLM, streaming the response when onChunk is set
+func (s *Service) sendPrompt(ctx context.Context, prompt string, onChunk func(chunk string) error) (string, error) {
+	if onChunk == nil {
+		return s.llmRepo.RunPrompt(ctx, prompt)
+	}
+	return s.llmRepo.StreamPrompt(ctx, prompt, onChunk)
+}
+
+// checkLLMQuota fails once the user has sent as many requests today as their quota allows
+func (s *Service) checkLLMQuota(username string, day string) error {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		s.logger.Error("Failed to retrieve user", "username", username, "error", err)
+		return err
+	}
+	if user == nil || user.LLMDailyQuota <= 0 {
+		return nil
+	}
+
+	used, err := s.usageRepo.CountRequests(username, day)
+	if err != nil {
+		s.logger.Error("Failed to count LLM requests", "username", username, "error", err)
+		return err
+	}
+	if used >= int64(user.LLMDailyQuota) {
+		s.logger.Info("Daily LLM quota reached", "username", username, "quota", user.LLMDailyQuota)
+		return types.ErrLLMQuotaExceeded
+	}
+	return nil
+}
+
+// recordUsage adds the request to the ledger. A failure to record does not fail the request.
+func (s *Service) recordUsage(usage types.LLMUsage, response string) {
+	usage.ResponseChars = len(response)
+	usage.ResponseTokens = estimateTokens(len(response))
+	if err := s.usageRepo.RecordUsage(usage); err != nil {
+		s.logger.Error("Failed to record LLM usage", "username", usage.UserID, "error", err)
+	}
+}
+
+// GetLLMUsage sums up the user's LLM usage of their current local day
+func (s *Service) GetLLMUsage(username string) (types.LLMUsageSummary, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		s.logger.Error("Failed to retrieve user", "username", username, "error", err)
+		return types.LLMUsageSummary{}, err
+	}
+	if user == nil {
+		return types.LLMUsageSummary{}, errors.New("user not found")
+	}
+
+	day := s.localDayOf(username, time.Now())
+	usage, err := s.usageRepo.GetUsageByDay(username, day)
+	if err != nil {
+		s.logger.Error("Failed to retrieve LLM usage", "username", username, "error", err)
+		return types.LLMUsageSummary{}, err
+	}
+
+	summary := types.LLMUsageSummary{Day: day, Quota: user.LLMDailyQuota}
+	for _, entry := range usage {
+		if entry.Cached {
+			summary.CachedRequests++
+		} else {
+			summary.Requests++
+		}
+		summary.PromptTokens += entry.PromptTokens
+		summary.ResponseTokens += entry.ResponseTokens
+	}
+	summary.Remaining = -1
+	if summary.Quota > 0 {
+		summary.Remaining = max(summary.Quota-summary.Requests, 0)
+	}
+	return summary, nil
+}
+
+// SetLLMDailyQuota changes how many LLM requests the user may make per day, 0 is unlimited
+func (s *Service) SetLLMDailyQuota(userID string, quota int) error {
+	if quota < 0 {
+		return errors.New("quota must not be negative")
+	}
+	if err := s.userRepo.UpdateUserLLMQuota(userID, quota); err != nil {
+		s.logger.Error("Failed to update LLM quota", "user_id", userID, "error", err)
+		return err
+	}
+	return nil
+}
+
+// promptHash keys the cache on the provider, model and prompt
+func promptHash(provider string, model string, prompt string) string {
+	sum := sha256.Sum256([]byte(provider + "\x00" + model + "\x00" + prompt))
+	return hex.EncodeToString(sum[:])
+}
+
+// estimateTokens approximates the tokens of a text from its length
+func estimateTokens(chars int) int {
+	return (chars + charsPerToken - 1) / charsPerToken
+}
@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/robstave/meowmorize/internal/adapters/repositories/mocks"
+	"github.com/robstave/meowmorize/internal/domain/types"
+	"github.com/robstave/meowmorize/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newUsageTestService builds a service with a usage ledger for a user with the given quota
+func newUsageTestService(quota int) (*Service, *mocks.LLMRepository, *mocks.LLMUsageRepository) {
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+	usageRepo := setupLLMUsageRepository()
+
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow", LLMDailyQuota: quota}, nil)
+	userRepo.On("UpdateUserLLMQuota", "dummy", 5).Return(nil)
+	deckRepo.On("GetDeckByID", "deck1").Return(types.Deck{ID: "deck1", Name: "Capitals"}, nil)
+	llmRepo.On("Provider").Return("ollama")
+	llmRepo.On("Model").Return("llama3")
+
+	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo, nil, nil, nil, usageRepo, nil, nil).(*Service)
+	return s, llmRepo, usageRepo
+}
+
+func TestRunPrompt_RecordsAndCaches(t *testing.T) {
+	s, llmRepo, usageRepo := newUsageTestService(10)
+
+	hash := promptHash("ollama", "llama3", "Why?")
+	usageRepo.On("GetCachedResponse", hash, mock.Anything).Return(nil, nil)
+	usageRepo.On("CountRequests", "meow", mock.Anything).Return(int64(3), nil)
+	llmRepo.On("RunPrompt", mock.Anything, "Why?").Return("Because of the weather.", nil)
+	usageRepo.On("RecordUsage", mock.MatchedBy(func(usage types.LLMUsage) bool {
+		return usage.UserID == "meow" && usage.Purpose == types.LLMPurposeExplain && usage.Provider == "ollama" &&
+			usage.Model == "llama3" && usage.PromptChars == 4 && usage.PromptTokens == 1 &&
+			usage.ResponseChars == 23 && usage.ResponseTokens == 6 && !usage.Cached && !usage.Failed && usage.Day != ""
+	})).Return(nil)
+	usageRepo.On("CacheResponse", mock.MatchedBy(func(entry types.LLMCacheEntry) bool {
+		return entry.Hash == hash && entry.Response == "Because of the weather."
+	})).Return(nil)
+
+	response, err := s.runPrompt(context.Background(), "meow", renderedPrompt{kind: types.LLMPurposeExplain, text: "Why?"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "Because of the weather.", response)
+	usageRepo.AssertExpectations(t)
+}
+
+func TestRunPrompt_CacheHit(t *testing.T) {
+	s, llmRepo, usageRepo := newUsageTestService(1)
+
+	usageRepo.On("GetCachedResponse", promptHash("ollama", "llama3", "Why?"), mock.Anything).
+		Return(&types.LLMCacheEntry{Response: "Cached answer"}, nil)
+	usageRepo.On("RecordUsage", mock.MatchedBy(func(usage types.LLMUsage) bool {
+		return usage.Cached && usage.ResponseChars == 13
+	})).Return(nil)
+
+	var chunks []string
+	response, err := s.runPrompt(context.Background(), "meow", renderedPrompt{kind: types.LLMPurposeExplain, text: "Why?"}, func(chunk string) error {
+		chunks = append(chunks, chunk)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Cached answer", response)
+	assert.Equal(t, []string{"Cached answer"}, chunks)
+
+	// Neither the LLM nor the quota is touched
+	llmRepo.AssertNotCalled(t, "StreamPrompt", mock.Anything, mock.Anything, mock.Anything)
+	usageRepo.AssertNotCalled(t, "CountRequests", mock.Anything, mock.Anything)
+}
+
+func TestRunPrompt_QuotaExceeded(t *testing.T) {
+	s, llmRepo, usageRepo := newUsageTestService(3)
+
+	usageRepo.On("GetCachedResponse", mock.Anything, mock.Anything).Return(nil, nil)
+	usageRepo.On("CountRequests", "meow", mock.Anything).Return(int64(3), nil)
+
+	_, err := s.runPrompt(context.Background(), "meow", renderedPrompt{kind: types.LLMPurposeExplain, text: "Why?"}, nil)
+	assert.ErrorIs(t, err, types.ErrLLMQuotaExceeded)
+	llmRepo.AssertNotCalled(t, "RunPrompt", mock.Anything, mock.Anything)
+	usageRepo.AssertNotCalled(t, "RecordUsage", mock.Anything)
+}
+
+func TestRunPrompt_Failure(t *testing.T) {
+	s, llmRepo, usageRepo := newUsageTestService(0)
+
+	usageRepo.On("GetCachedResponse", mock.Anything, mock.Anything).Return(nil, nil)
+	llmRepo.On("RunPrompt", mock.Anything, "Why?").Return("", errors.New("boom"))
+	usageRepo.On("RecordUsage", mock.MatchedBy(func(usage types.LLMUsage) bool {
+		return usage.Failed
+	})).Return(nil)
+
+	_, err := s.runPrompt(context.Background(), "meow", renderedPrompt{kind: types.LLMPurposeExplain, text: "Why?"}, nil)
+	assert.EqualError(t, err, "boom")
+	// An unlimited quota is not counted, and failures are not cached
+	usageRepo.AssertNotCalled(t, "CountRequests", mock.Anything, mock.Anything)
+	usageRepo.AssertNotCalled(t, "CacheResponse", mock.Anything)
+	usageRepo.AssertExpectations(t)
+}
+
+func TestGradeAnswerWithLLM_QuotaExceeded(t *testing.T) {
+	s, _, usageRepo := newUsageTestService(1)
+
+	usageRepo.On("GetCachedResponse", mock.Anything, mock.Anything).Return(nil, nil)
+	usageRepo.On("CountRequests", "meow", mock.Anything).Return(int64(1), nil)
+
+	grade := s.gradeAnswerWithLLM(context.Background(), types.Card{ID: "card1", Back: types.CardBack{Text: "Paris"}}, "paris", "deck1", "meow")
+	assert.True(t, grade.SelfGrade)
+}
+
+func TestGetLLMUsage(t *testing.T) {
+	s, _, usageRepo := newUsageTestService(5)
+
+	usageRepo.On("GetUsageByDay", "meow", mock.Anything).Return([]types.LLMUsage{
+		{PromptTokens: 10, ResponseTokens: 20},
+		{PromptTokens: 5, ResponseTokens: 5, Cached: true},
+		{PromptTokens: 1, Failed: true},
+	}, nil)
+
+	summary, err := s.GetLLMUsage("meow")
+	assert.NoError(t, err)
+	assert.Equal(t, 5, summary.Quota)
+	assert.Equal(t, 2, summary.Requests)
+	assert.Equal(t, 1, summary.CachedRequests)
+	assert.Equal(t, 3, summary.Remaining)
+	assert.Equal(t, 16, summary.PromptTokens)
+	assert.Equal(t, 25, summary.ResponseTokens)
+	assert.NotEmpty(t, summary.Day)
+}
+
+func TestSetLLMDailyQuota(t *testing.T) {
+	s, _, _ := newUsageTestService(5)
+
+	assert.NoError(t, s.SetLLMDailyQuota("dummy", 5))
+	assert.EqualError(t, s.SetLLMDailyQuota("dummy", -1), "quota must not be negative")
+}
@@ -8,6 +8,31 @@ import (
 	"github.com/robstave/meowmorize/internal/domain/types"
 )
 
+// eligibleCards returns the cards a method may select from: the retired cards for the
+// Retired method, the retired cards due for their resurrect review for the Resurrect
+// method, and the active cards otherwise
+func eligibleCards(cards []types.Card, method types.SessionMethod, now time.Time) []types.Card {
+	eligible := make([]types.Card, 0, len(cards))
+	for _, card := range cards {
+		switch method {
+		case types.RetiredMethod:
+			if !card.Retired {
+				continue
+			}
+		case types.ResurrectMethod:
+			if !card.Retired || card.DueAt.After(now) {
+				continue
+			}
+		default:
+			if card.Retired {
+				continue
+			}
+		}
+		eligible = append(eligible, card)
+	}
+	return eligible
+}
+
 // selectRandomCards selects random cards from the deck
 func selectRandomCards(cards []types.Card, count int) []types.Card {
 	rand.Seed(time.Now().UnixNano())
@@ -55,26 +80,43 @@ func selectWorstCards(cards []types.Card, count int) []types.Card {
 
 // calculateFailRate computes the fail rate percentage for a card
 func calculateFailRate(card types.Card) float64 {
-	if card.PassCount == 0 {
+	passes := effectivePassCount(card)
+	if passes == 0 {
 		return 100.0 // If no successes, highest priority
 	}
-	return (float64(card.FailCount) / float64(card.PassCount)) * 100.0
+	return (float64(card.FailCount) / passes) * 100.0
 }
 
 // calculateSkipRate computes the skip rate percentage for a card
 func calculateSkipRate(card types.Card) float64 {
-	if card.PassCount == 0 {
+	passes := effectivePassCount(card)
+	if passes == 0 {
 		return 100.0 // If no successes, highest priority
 	}
-	return (float64(card.SkipCount) / float64(card.PassCount)) * 100.0
+	return (float64(card.SkipCount) / passes) * 100.0
 }
 
 // calculateCombinedRate computes the combined fail and skip rate percentage for a card
 func calculateCombinedRate(card types.Card) float64 {
-	if card.PassCount == 0 {
+	passes := effectivePassCount(card)
+	if passes == 0 {
 		return 100.0 // If no successes, highest priority
 	}
-	return ((float64(card.FailCount) + float64(card.SkipCount)) / float64(card.PassCount)) * 100.0
+	return ((float64(card.FailCount) + float64(card.SkipCount)) / passes) * 100.0
+}
+
+// slowPassWeight is how much a slow pass counts compared to a fast one
+const slowPassWeight = 0.5
+
+// hintedPassWeight is how much a pass that needed a hint counts
+const hintedPassWeight = 0.25
+
+// effectivePassCount counts slow and hinted passes as only partly successful,
+// so hesitant or prompted recalls rank as weaker than quick ones
+func effectivePassCount(card types.Card) float64 {
+	hinted := min(card.HintedPassCount, card.PassCount)
+	slow := min(card.SlowPassCount, card.PassCount-hinted)
+	return float64(card.PassCount-hinted-slow) + float64(slow)*slowPassWeight + float64(hinted)*hintedPassWeight
 }
 
 // selectStarsCards selects top N cards based on star rating with some randomization
@@ -128,7 +170,7 @@ func selectStarsCards(cards []types.Card, count int) []types.Card {
 //   - If star rating is 0, treat it as 2.5; otherwise use the actual star rating
 //   - Multiply the star rating by 2 (baseline score between 0 and 10)
 //   - Add a random number between -2.5 and 2.5
-//   - If FailCount > PassCount, add a random number between 0 and 2
+//   - If FailCount exceeds the effective pass count (slow passes count half), add a random number between 0 and 2
 //
 // Then it sorts the cards ascending by the score and returns the top 'count' cards.
 func selectAdjustedRandomCards(cards []types.Card, count int) []types.Card {
@@ -147,7 +189,7 @@ func selectAdjustedRandomCards(cards []types.Card, count int) []types.Card {
 		}
 		randomAdj := (rand.Float64() * 5) - 2.5 // random from -2.5 to 2.5
 		extra := 0.0
-		if card.FailCount > card.PassCount {
+		if float64(card.FailCount) > effectivePassCount(card) {
 			extra = rand.Float64() * 2 // random from 0 to 2
 		}
 		score := baseline + randomAdj + extra
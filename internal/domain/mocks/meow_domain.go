@@ -3,6 +3,9 @@
 package mocks
 
 import (
+	context "context"
+	io "io"
+
 	types "github.com/robstave/meowmorize/internal/domain/types"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -12,6 +15,29 @@ type MeowDomain struct {
 	mock.Mock
 }
 
+// AcceptLintFinding provides a mock function with given fields: findingID, username, edit
+func (_m *MeowDomain) AcceptLintFinding(findingID string, username string, edit types.LintFindingEdit) (*types.Card, error) {
+	ret := _m.Called(findingID, username, edit)
+
+	var r0 *types.Card
+	if rf, ok := ret.Get(0).(func(string, string, types.LintFindingEdit) *types.Card); ok {
+		r0 = rf(findingID, username, edit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Card)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, types.LintFindingEdit) error); ok {
+		r1 = rf(findingID, username, edit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AdjustSession provides a mock function with given fields: deckID, cardID, action, value, userID
 func (_m *MeowDomain) AdjustSession(deckID string, cardID string, action types.CardAction, value int, userID string) error {
 	ret := _m.Called(deckID, cardID, action, value, userID)
@@ -26,6 +52,43 @@ func (_m *MeowDomain) AdjustSession(deckID string, cardID string, action types.C
 	return r0
 }
 
+// ApproveCardDraft provides a mock function with given fields: draftID, username, edit
+func (_m *MeowDomain) ApproveCardDraft(draftID string, username string, edit types.CardDraftEdit) (*types.Card, error) {
+	ret := _m.Called(draftID, username, edit)
+
+	var r0 *types.Card
+	if rf, ok := ret.Get(0).(func(string, string, types.CardDraftEdit) *types.Card); ok {
+		r0 = rf(draftID, username, edit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Card)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, types.CardDraftEdit) error); ok {
+		r1 = rf(draftID, username, edit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CancelDeckLint provides a mock function with given fields: deckID, username
+func (_m *MeowDomain) CancelDeckLint(deckID string, username string) error {
+	ret := _m.Called(deckID, username)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(deckID, username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ClearDeckStats provides a mock function with given fields: deckID, clearSession, clearStats
 func (_m *MeowDomain) ClearDeckStats(deckID string, clearSession bool, clearStats bool) error {
 	ret := _m.Called(deckID, clearSession, clearStats)
@@ -191,6 +254,20 @@ func (_m *MeowDomain) DeleteDeck(deckID string) error {
 	return r0
 }
 
+// DeleteExplanationThread provides a mock function with given fields: cardID, username
+func (_m *MeowDomain) DeleteExplanationThread(cardID string, username string) error {
+	ret := _m.Called(cardID, username)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(cardID, username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteUser provides a mock function with given fields: userID
 func (_m *MeowDomain) DeleteUser(userID string) error {
 	ret := _m.Called(userID)
@@ -205,6 +282,41 @@ func (_m *MeowDomain) DeleteUser(userID string) error {
 	return r0
 }
 
+// DismissLintFinding provides a mock function with given fields: findingID, username
+func (_m *MeowDomain) DismissLintFinding(findingID string, username string) error {
+	ret := _m.Called(findingID, username)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(findingID, username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExplainCard provides a mock function with given fields: ctx, cardID, username, question
+func (_m *MeowDomain) ExplainCard(ctx context.Context, cardID string, username string, question string) (types.ExplanationTurn, error) {
+	ret := _m.Called(ctx, cardID, username, question)
+
+	var r0 types.ExplanationTurn
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) types.ExplanationTurn); ok {
+		r0 = rf(ctx, cardID, username, question)
+	} else {
+		r0 = ret.Get(0).(types.ExplanationTurn)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, cardID, username, question)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ExportDeck provides a mock function with given fields: deckID
 func (_m *MeowDomain) ExportDeck(deckID string) (types.Deck, error) {
 	ret := _m.Called(deckID)
@@ -226,6 +338,71 @@ func (_m *MeowDomain) ExportDeck(deckID string) (types.Deck, error) {
 	return r0, r1
 }
 
+// GarbageCollectAttachments provides a mock function with given fields:
+func (_m *MeowDomain) GarbageCollectAttachments() (int, error) {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GenerateCards provides a mock function with given fields: ctx, username, request
+func (_m *MeowDomain) GenerateCards(ctx context.Context, username string, request types.GenerateCardsRequest) ([]types.CardDraft, error) {
+	ret := _m.Called(ctx, username, request)
+
+	var r0 []types.CardDraft
+	if rf, ok := ret.Get(0).(func(context.Context, string, types.GenerateCardsRequest) []types.CardDraft); ok {
+		r0 = rf(ctx, username, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.CardDraft)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, types.GenerateCardsRequest) error); ok {
+		r1 = rf(ctx, username, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetActivityCalendar provides a mock function with given fields: username, deckID, days
+func (_m *MeowDomain) GetActivityCalendar(username string, deckID string, days int) (types.ActivityCalendar, error) {
+	ret := _m.Called(username, deckID, days)
+
+	var r0 types.ActivityCalendar
+	if rf, ok := ret.Get(0).(func(string, string, int) types.ActivityCalendar); ok {
+		r0 = rf(username, deckID, days)
+	} else {
+		r0 = ret.Get(0).(types.ActivityCalendar)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, int) error); ok {
+		r1 = rf(username, deckID, days)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAllDecks provides a mock function with given fields: userID
 func (_m *MeowDomain) GetAllDecks(userID string) ([]types.Deck, error) {
 	ret := _m.Called(userID)
@@ -272,6 +449,38 @@ func (_m *MeowDomain) GetAllUsers() ([]types.User, error) {
 	return r0, r1
 }
 
+// GetAttachment provides a mock function with given fields: id, userID
+func (_m *MeowDomain) GetAttachment(id string, userID string) (*types.Attachment, io.ReadSeekCloser, error) {
+	ret := _m.Called(id, userID)
+
+	var r0 *types.Attachment
+	if rf, ok := ret.Get(0).(func(string, string) *types.Attachment); ok {
+		r0 = rf(id, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Attachment)
+		}
+	}
+
+	var r1 io.ReadSeekCloser
+	if rf, ok := ret.Get(1).(func(string, string) io.ReadSeekCloser); ok {
+		r1 = rf(id, userID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(io.ReadSeekCloser)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, string) error); ok {
+		r2 = rf(id, userID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetCardByID provides a mock function with given fields: cardID
 func (_m *MeowDomain) GetCardByID(cardID string) (*types.Card, error) {
 	ret := _m.Called(cardID)
@@ -295,20 +504,22 @@ func (_m *MeowDomain) GetCardByID(cardID string) (*types.Card, error) {
 	return r0, r1
 }
 
-// GetDeckByID provides a mock function with given fields: deckID
-func (_m *MeowDomain) GetDeckByID(deckID string) (types.Deck, error) {
-	ret := _m.Called(deckID)
+// GetCardDrafts provides a mock function with given fields: username, deckID
+func (_m *MeowDomain) GetCardDrafts(username string, deckID string) ([]types.CardDraft, error) {
+	ret := _m.Called(username, deckID)
 
-	var r0 types.Deck
-	if rf, ok := ret.Get(0).(func(string) types.Deck); ok {
-		r0 = rf(deckID)
+	var r0 []types.CardDraft
+	if rf, ok := ret.Get(0).(func(string, string) []types.CardDraft); ok {
+		r0 = rf(username, deckID)
 	} else {
-		r0 = ret.Get(0).(types.Deck)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.CardDraft)
+		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(deckID)
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(username, deckID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -316,20 +527,20 @@ func (_m *MeowDomain) GetDeckByID(deckID string) (types.Deck, error) {
 	return r0, r1
 }
 
-// GetExplanation provides a mock function with given fields: prompt
-func (_m *MeowDomain) GetExplanation(prompt string) (string, error) {
-	ret := _m.Called(prompt)
+// GetCardHint provides a mock function with given fields: ctx, cardID, deckID, username, level
+func (_m *MeowDomain) GetCardHint(ctx context.Context, cardID string, deckID string, username string, level int) (types.Hint, error) {
+	ret := _m.Called(ctx, cardID, deckID, username, level)
 
-	var r0 string
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(prompt)
+	var r0 types.Hint
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, int) types.Hint); ok {
+		r0 = rf(ctx, cardID, deckID, username, level)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(types.Hint)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(prompt)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, int) error); ok {
+		r1 = rf(ctx, cardID, deckID, username, level)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -337,20 +548,22 @@ func (_m *MeowDomain) GetExplanation(prompt string) (string, error) {
 	return r0, r1
 }
 
-// GetNextCard provides a mock function with given fields: deckID
-func (_m *MeowDomain) GetNextCard(deckID string) (string, error) {
-	ret := _m.Called(deckID)
+// GetCardRevisions provides a mock function with given fields: cardID
+func (_m *MeowDomain) GetCardRevisions(cardID string) ([]types.CardRevision, error) {
+	ret := _m.Called(cardID)
 
-	var r0 string
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(deckID)
+	var r0 []types.CardRevision
+	if rf, ok := ret.Get(0).(func(string) []types.CardRevision); ok {
+		r0 = rf(cardID)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.CardRevision)
+		}
 	}
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(deckID)
+		r1 = rf(cardID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -358,22 +571,20 @@ func (_m *MeowDomain) GetNextCard(deckID string) (string, error) {
 	return r0, r1
 }
 
-// GetSessionLogIdsByUser provides a mock function with given fields: userID, deckID
-func (_m *MeowDomain) GetSessionLogIdsByUser(userID string, deckID string) ([]string, error) {
-	ret := _m.Called(userID, deckID)
+// GetDeckAnalytics provides a mock function with given fields: deckID, username
+func (_m *MeowDomain) GetDeckAnalytics(deckID string, username string) (types.DeckAnalytics, error) {
+	ret := _m.Called(deckID, username)
 
-	var r0 []string
-	if rf, ok := ret.Get(0).(func(string, string) []string); ok {
-		r0 = rf(userID, deckID)
+	var r0 types.DeckAnalytics
+	if rf, ok := ret.Get(0).(func(string, string) types.DeckAnalytics); ok {
+		r0 = rf(deckID, username)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
-		}
+		r0 = ret.Get(0).(types.DeckAnalytics)
 	}
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(userID, deckID)
+		r1 = rf(deckID, username)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -381,22 +592,20 @@ func (_m *MeowDomain) GetSessionLogIdsByUser(userID string, deckID string) ([]st
 	return r0, r1
 }
 
-// GetSessionLogsBySessionID provides a mock function with given fields: sessionID
-func (_m *MeowDomain) GetSessionLogsBySessionID(sessionID string) ([]types.SessionLog, error) {
-	ret := _m.Called(sessionID)
+// GetDeckByID provides a mock function with given fields: deckID
+func (_m *MeowDomain) GetDeckByID(deckID string) (types.Deck, error) {
+	ret := _m.Called(deckID)
 
-	var r0 []types.SessionLog
-	if rf, ok := ret.Get(0).(func(string) []types.SessionLog); ok {
-		r0 = rf(sessionID)
+	var r0 types.Deck
+	if rf, ok := ret.Get(0).(func(string) types.Deck); ok {
+		r0 = rf(deckID)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]types.SessionLog)
-		}
+		r0 = ret.Get(0).(types.Deck)
 	}
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(sessionID)
+		r1 = rf(deckID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -404,22 +613,20 @@ func (_m *MeowDomain) GetSessionLogsBySessionID(sessionID string) ([]types.Sessi
 	return r0, r1
 }
 
-// GetSessionOverview provides a mock function with given fields: userID, deckID
-func (_m *MeowDomain) GetSessionOverview(userID string, deckID string) ([]types.SessionOverview, error) {
-	ret := _m.Called(userID, deckID)
+// GetDeckLint provides a mock function with given fields: deckID, username
+func (_m *MeowDomain) GetDeckLint(deckID string, username string) (types.LintJob, error) {
+	ret := _m.Called(deckID, username)
 
-	var r0 []types.SessionOverview
-	if rf, ok := ret.Get(0).(func(string, string) []types.SessionOverview); ok {
-		r0 = rf(userID, deckID)
+	var r0 types.LintJob
+	if rf, ok := ret.Get(0).(func(string, string) types.LintJob); ok {
+		r0 = rf(deckID, username)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]types.SessionOverview)
-		}
+		r0 = ret.Get(0).(types.LintJob)
 	}
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(userID, deckID)
+		r1 = rf(deckID, username)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -427,20 +634,20 @@ func (_m *MeowDomain) GetSessionOverview(userID string, deckID string) ([]types.
 	return r0, r1
 }
 
-// GetSessionStats provides a mock function with given fields: deckID
-func (_m *MeowDomain) GetSessionStats(deckID string) (types.SessionStats, error) {
-	ret := _m.Called(deckID)
+// GetExplanation provides a mock function with given fields: ctx, username, prompt
+func (_m *MeowDomain) GetExplanation(ctx context.Context, username string, prompt string) (string, error) {
+	ret := _m.Called(ctx, username, prompt)
 
-	var r0 types.SessionStats
-	if rf, ok := ret.Get(0).(func(string) types.SessionStats); ok {
-		r0 = rf(deckID)
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, username, prompt)
 	} else {
-		r0 = ret.Get(0).(types.SessionStats)
+		r0 = ret.Get(0).(string)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(deckID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, username, prompt)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -448,16 +655,37 @@ func (_m *MeowDomain) GetSessionStats(deckID string) (types.SessionStats, error)
 	return r0, r1
 }
 
-// GetUserByUsername provides a mock function with given fields: username
-func (_m *MeowDomain) GetUserByUsername(username string) (*types.User, error) {
+// GetExplanationThread provides a mock function with given fields: cardID, username
+func (_m *MeowDomain) GetExplanationThread(cardID string, username string) (types.ExplanationThread, error) {
+	ret := _m.Called(cardID, username)
+
+	var r0 types.ExplanationThread
+	if rf, ok := ret.Get(0).(func(string, string) types.ExplanationThread); ok {
+		r0 = rf(cardID, username)
+	} else {
+		r0 = ret.Get(0).(types.ExplanationThread)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(cardID, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExplanationThreads provides a mock function with given fields: username
+func (_m *MeowDomain) GetExplanationThreads(username string) ([]types.ExplanationThreadSummary, error) {
 	ret := _m.Called(username)
 
-	var r0 *types.User
-	if rf, ok := ret.Get(0).(func(string) *types.User); ok {
+	var r0 []types.ExplanationThreadSummary
+	if rf, ok := ret.Get(0).(func(string) []types.ExplanationThreadSummary); ok {
 		r0 = rf(username)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*types.User)
+			r0 = ret.Get(0).([]types.ExplanationThreadSummary)
 		}
 	}
 
@@ -471,60 +699,623 @@ func (_m *MeowDomain) GetUserByUsername(username string) (*types.User, error) {
 	return r0, r1
 }
 
-// IsLLMAvailable provides a mock function with given fields:
-func (_m *MeowDomain) IsLLMAvailable() bool {
-	ret := _m.Called()
+// GetForecast provides a mock function with given fields: username, days
+func (_m *MeowDomain) GetForecast(username string, days int) (types.Forecast, error) {
+	ret := _m.Called(username, days)
 
-	var r0 bool
-	if rf, ok := ret.Get(0).(func() bool); ok {
-		r0 = rf()
+	var r0 types.Forecast
+	if rf, ok := ret.Get(0).(func(string, int) types.Forecast); ok {
+		r0 = rf(username, days)
 	} else {
-		r0 = ret.Get(0).(bool)
+		r0 = ret.Get(0).(types.Forecast)
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(username, days)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// SeedUser provides a mock function with given fields:
-func (_m *MeowDomain) SeedUser() error {
+// GetLLMStatus provides a mock function with given fields:
+func (_m *MeowDomain) GetLLMStatus() types.LLMStatus {
 	ret := _m.Called()
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func() error); ok {
+	var r0 types.LLMStatus
+	if rf, ok := ret.Get(0).(func() types.LLMStatus); ok {
 		r0 = rf()
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(types.LLMStatus)
 	}
 
 	return r0
 }
 
-// StartSession provides a mock function with given fields: deckID, count, method, userID
-func (_m *MeowDomain) StartSession(deckID string, count int, method types.SessionMethod, userID string) error {
-	ret := _m.Called(deckID, count, method, userID)
+// GetLLMUsage provides a mock function with given fields: username
+func (_m *MeowDomain) GetLLMUsage(username string) (types.LLMUsageSummary, error) {
+	ret := _m.Called(username)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(string, int, types.SessionMethod, string) error); ok {
-		r0 = rf(deckID, count, method, userID)
+	var r0 types.LLMUsageSummary
+	if rf, ok := ret.Get(0).(func(string) types.LLMUsageSummary); ok {
+		r0 = rf(username)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(types.LLMUsageSummary)
 	}
 
-	return r0
-}
-
-// UpdateCard provides a mock function with given fields: card
-func (_m *MeowDomain) UpdateCard(card types.Card) error {
-	ret := _m.Called(card)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(types.Card) error); ok {
-		r0 = rf(card)
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(username)
 	} else {
-		r0 = ret.Error(0)
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
+}
+
+// GetLeeches provides a mock function with given fields: username
+func (_m *MeowDomain) GetLeeches(username string) ([]types.Leech, error) {
+	ret := _m.Called(username)
+
+	var r0 []types.Leech
+	if rf, ok := ret.Get(0).(func(string) []types.Leech); ok {
+		r0 = rf(username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.Leech)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLintFindings provides a mock function with given fields: deckID, username, status
+func (_m *MeowDomain) GetLintFindings(deckID string, username string, status string) ([]types.LintFinding, error) {
+	ret := _m.Called(deckID, username, status)
+
+	var r0 []types.LintFinding
+	if rf, ok := ret.Get(0).(func(string, string, string) []types.LintFinding); ok {
+		r0 = rf(deckID, username, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.LintFinding)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(deckID, username, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNextCard provides a mock function with given fields: deckID
+func (_m *MeowDomain) GetNextCard(deckID string) (string, error) {
+	ret := _m.Called(deckID)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(deckID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(deckID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPromptTemplates provides a mock function with given fields: username
+func (_m *MeowDomain) GetPromptTemplates(username string) ([]types.PromptTemplate, error) {
+	ret := _m.Called(username)
+
+	var r0 []types.PromptTemplate
+	if rf, ok := ret.Get(0).(func(string) []types.PromptTemplate); ok {
+		r0 = rf(username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.PromptTemplate)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSessionLogIdsByUser provides a mock function with given fields: userID, query
+func (_m *MeowDomain) GetSessionLogIdsByUser(userID string, query types.SessionHistoryQuery) ([]string, int64, error) {
+	ret := _m.Called(userID, query)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string, types.SessionHistoryQuery) []string); ok {
+		r0 = rf(userID, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(string, types.SessionHistoryQuery) int64); ok {
+		r1 = rf(userID, query)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, types.SessionHistoryQuery) error); ok {
+		r2 = rf(userID, query)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetSessionLogsBySessionID provides a mock function with given fields: sessionID
+func (_m *MeowDomain) GetSessionLogsBySessionID(sessionID string) ([]types.SessionLog, error) {
+	ret := _m.Called(sessionID)
+
+	var r0 []types.SessionLog
+	if rf, ok := ret.Get(0).(func(string) []types.SessionLog); ok {
+		r0 = rf(sessionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.SessionLog)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(sessionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSessionOverview provides a mock function with given fields: userID, query
+func (_m *MeowDomain) GetSessionOverview(userID string, query types.SessionHistoryQuery) ([]types.SessionOverview, int64, error) {
+	ret := _m.Called(userID, query)
+
+	var r0 []types.SessionOverview
+	if rf, ok := ret.Get(0).(func(string, types.SessionHistoryQuery) []types.SessionOverview); ok {
+		r0 = rf(userID, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.SessionOverview)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(string, types.SessionHistoryQuery) int64); ok {
+		r1 = rf(userID, query)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, types.SessionHistoryQuery) error); ok {
+		r2 = rf(userID, query)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetSessionReport provides a mock function with given fields: sessionID, userID
+func (_m *MeowDomain) GetSessionReport(sessionID string, userID string) (types.SessionReport, error) {
+	ret := _m.Called(sessionID, userID)
+
+	var r0 types.SessionReport
+	if rf, ok := ret.Get(0).(func(string, string) types.SessionReport); ok {
+		r0 = rf(sessionID, userID)
+	} else {
+		r0 = ret.Get(0).(types.SessionReport)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(sessionID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSessionStats provides a mock function with given fields: deckID
+func (_m *MeowDomain) GetSessionStats(deckID string) (types.SessionStats, error) {
+	ret := _m.Called(deckID)
+
+	var r0 types.SessionStats
+	if rf, ok := ret.Get(0).(func(string) types.SessionStats); ok {
+		r0 = rf(deckID)
+	} else {
+		r0 = ret.Get(0).(types.SessionStats)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(deckID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserByUsername provides a mock function with given fields: username
+func (_m *MeowDomain) GetUserByUsername(username string) (*types.User, error) {
+	ret := _m.Called(username)
+
+	var r0 *types.User
+	if rf, ok := ret.Get(0).(func(string) *types.User); ok {
+		r0 = rf(username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsLLMAvailable provides a mock function with given fields:
+func (_m *MeowDomain) IsLLMAvailable() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// PromoteExplanation provides a mock function with given fields: turnID, username, target
+func (_m *MeowDomain) PromoteExplanation(turnID string, username string, target string) (*types.Card, error) {
+	ret := _m.Called(turnID, username, target)
+
+	var r0 *types.Card
+	if rf, ok := ret.Get(0).(func(string, string, string) *types.Card); ok {
+		r0 = rf(turnID, username, target)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Card)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(turnID, username, target)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PruneSessionLogs provides a mock function with given fields:
+func (_m *MeowDomain) PruneSessionLogs() (int64, error) {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RejectCardDraft provides a mock function with given fields: draftID, username
+func (_m *MeowDomain) RejectCardDraft(draftID string, username string) error {
+	ret := _m.Called(draftID, username)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(draftID, username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResetPromptTemplate provides a mock function with given fields: username, kind
+func (_m *MeowDomain) ResetPromptTemplate(username string, kind string) error {
+	ret := _m.Called(username, kind)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(username, kind)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RetranslateCard provides a mock function with given fields: ctx, cardID, username
+func (_m *MeowDomain) RetranslateCard(ctx context.Context, cardID string, username string) (*types.Card, error) {
+	ret := _m.Called(ctx, cardID, username)
+
+	var r0 *types.Card
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *types.Card); ok {
+		r0 = rf(ctx, cardID, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Card)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, cardID, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SavePromptTemplate provides a mock function with given fields: username, kind, body
+func (_m *MeowDomain) SavePromptTemplate(username string, kind string, body string) (types.PromptTemplate, error) {
+	ret := _m.Called(username, kind, body)
+
+	var r0 types.PromptTemplate
+	if rf, ok := ret.Get(0).(func(string, string, string) types.PromptTemplate); ok {
+		r0 = rf(username, kind, body)
+	} else {
+		r0 = ret.Get(0).(types.PromptTemplate)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(username, kind, body)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SeedUser provides a mock function with given fields:
+func (_m *MeowDomain) SeedUser() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetLLMDailyQuota provides a mock function with given fields: userID, quota
+func (_m *MeowDomain) SetLLMDailyQuota(userID string, quota int) error {
+	ret := _m.Called(userID, quota)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int) error); ok {
+		r0 = rf(userID, quota)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StartDeckLint provides a mock function with given fields: deckID, username
+func (_m *MeowDomain) StartDeckLint(deckID string, username string) (types.LintJob, error) {
+	ret := _m.Called(deckID, username)
+
+	var r0 types.LintJob
+	if rf, ok := ret.Get(0).(func(string, string) types.LintJob); ok {
+		r0 = rf(deckID, username)
+	} else {
+		r0 = ret.Get(0).(types.LintJob)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(deckID, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StartSession provides a mock function with given fields: deckID, count, method, userID, opts
+func (_m *MeowDomain) StartSession(deckID string, count int, method types.SessionMethod, userID string, opts types.SessionOptions) error {
+	ret := _m.Called(deckID, count, method, userID, opts)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int, types.SessionMethod, string, types.SessionOptions) error); ok {
+		r0 = rf(deckID, count, method, userID, opts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StreamExplainCard provides a mock function with given fields: ctx, cardID, username, question, onChunk
+func (_m *MeowDomain) StreamExplainCard(ctx context.Context, cardID string, username string, question string, onChunk func(string) error) (types.ExplanationTurn, error) {
+	ret := _m.Called(ctx, cardID, username, question, onChunk)
+
+	var r0 types.ExplanationTurn
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, func(string) error) types.ExplanationTurn); ok {
+		r0 = rf(ctx, cardID, username, question, onChunk)
+	} else {
+		r0 = ret.Get(0).(types.ExplanationTurn)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, func(string) error) error); ok {
+		r1 = rf(ctx, cardID, username, question, onChunk)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SubmitAnswer provides a mock function with given fields: ctx, deckID, cardID, answer, userID
+func (_m *MeowDomain) SubmitAnswer(ctx context.Context, deckID string, cardID string, answer string, userID string) (types.AnswerGrade, error) {
+	ret := _m.Called(ctx, deckID, cardID, answer, userID)
+
+	var r0 types.AnswerGrade
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) types.AnswerGrade); ok {
+		r0 = rf(ctx, deckID, cardID, answer, userID)
+	} else {
+		r0 = ret.Get(0).(types.AnswerGrade)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = rf(ctx, deckID, cardID, answer, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SubscribeSessionEvents provides a mock function with given fields: sessionID, username
+func (_m *MeowDomain) SubscribeSessionEvents(sessionID string, username string) (<-chan types.SessionEvent, func(), error) {
+	ret := _m.Called(sessionID, username)
+
+	var r0 <-chan types.SessionEvent
+	if rf, ok := ret.Get(0).(func(string, string) <-chan types.SessionEvent); ok {
+		r0 = rf(sessionID, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan types.SessionEvent)
+		}
+	}
+
+	var r1 func()
+	if rf, ok := ret.Get(1).(func(string, string) func()); ok {
+		r1 = rf(sessionID, username)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(func())
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, string) error); ok {
+		r2 = rf(sessionID, username)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// TranslateDeck provides a mock function with given fields: ctx, deckID, username, language
+func (_m *MeowDomain) TranslateDeck(ctx context.Context, deckID string, username string, language string) (*types.Deck, error) {
+	ret := _m.Called(ctx, deckID, username, language)
+
+	var r0 *types.Deck
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *types.Deck); ok {
+		r0 = rf(ctx, deckID, username, language)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Deck)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, deckID, username, language)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UndoReviews provides a mock function with given fields: deckID, count
+func (_m *MeowDomain) UndoReviews(deckID string, count int) (int, error) {
+	ret := _m.Called(deckID, count)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(string, int) int); ok {
+		r0 = rf(deckID, count)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(deckID, count)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateCard provides a mock function with given fields: card
+func (_m *MeowDomain) UpdateCard(card types.Card) error {
+	ret := _m.Called(card)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(types.Card) error); ok {
+		r0 = rf(card)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
 }
 
 // UpdateCardStats provides a mock function with given fields: cardID, action, value, deckID, userID
@@ -569,6 +1360,41 @@ func (_m *MeowDomain) UpdateUserPassword(userID string, password string) error {
 	return r0
 }
 
+// UpdateUserSettings provides a mock function with given fields: username, settings
+func (_m *MeowDomain) UpdateUserSettings(username string, settings types.UserSettings) error {
+	ret := _m.Called(username, settings)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, types.UserSettings) error); ok {
+		r0 = rf(username, settings)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UploadAttachment provides a mock function with given fields: userID, filename, content
+func (_m *MeowDomain) UploadAttachment(userID string, filename string, content []byte) (types.Attachment, error) {
+	ret := _m.Called(userID, filename, content)
+
+	var r0 types.Attachment
+	if rf, ok := ret.Get(0).(func(string, string, []byte) types.Attachment); ok {
+		r0 = rf(userID, filename, content)
+	} else {
+		r0 = ret.Get(0).(types.Attachment)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, []byte) error); ok {
+		r1 = rf(userID, filename, content)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewMeowDomain interface {
 	mock.TestingT
 	Cleanup(func())
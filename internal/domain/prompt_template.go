@@ -0,0 +1,181 @@
+// internal/domain/prompt_template.go
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/robstave/meowmorize/internal/domain/types"
+	"github.com/robstave/meowmorize/prompts"
+)
+
+// builtinTemplates are used for a kind until an admin saves a default for it
+var builtinTemplates = map[string]string{
+	types.LLMPurposeExplain:   prompts.ExplainTemplate,
+	types.LLMPurposeHint:      prompts.HintTemplate,
+	types.LLMPurposeGenerate:  prompts.GenerateTemplate,
+	types.LLMPurposeGrade:     prompts.GradeTemplate,
+	types.LLMPurposeLint:      prompts.LintTemplate,
+	types.LLMPurposeTranslate: prompts.TranslateTemplate,
+}
+
+// samplePromptData sets every variable, so saving a template catches references to
+// variables that do not exist
+var samplePromptData = types.PromptData{
+	Front:      "front",
+	Back:       "back",
+	Deck:       "deck",
+	Question:   "question",
+	Turns:      []types.ExplanationTurn{{Question: "question", Answer: "answer"}},
+	Answer:     "answer",
+	Alternates: []string{"alternate"},
+	Count:      1,
+	Source:     "source",
+	CardFormat: "format",
+	Level:      2,
+	Hints:      []string{"hint"},
+	Language:   "language",
+}
+
+// renderedPrompt is a prompt ready to send, with the template it came from
+type renderedPrompt struct {
+	kind            string
+	text            string
+	templateID      string // Empty for a built-in template
+	templateVersion int
+}
+
+// renderPrompt executes the user's template of the kind with the data. A stored template
+// that no longer executes falls back to the built-in one so the feature keeps working.
+func (s *Service) renderPrompt(username string, kind string, data types.PromptData) (renderedPrompt, error) {
+	tmpl, err := s.promptTemplate(username, kind)
+	if err != nil {
+		return renderedPrompt{}, err
+	}
+
+	text, err := executeTemplate(tmpl.Body, data)
+	if err != nil && !tmpl.BuiltIn {
+		s.logger.Warn("Prompt template failed, using the built-in one", "template_id", tmpl.ID, "error", err)
+		tmpl = builtinTemplate(kind)
+		text, err = executeTemplate(tmpl.Body, data)
+	}
+	if err != nil {
+		return renderedPrompt{}, err
+	}
+	return renderedPrompt{kind: kind, text: text, templateID: tmpl.ID, templateVersion: tmpl.Version}, nil
+}
+
+// promptTemplate returns the template of the kind in use for the user: their own, else the
+// admin default, else the built-in one. An empty username skips straight to the default.
+func (s *Service) promptTemplate(username string, kind string) (types.PromptTemplate, error) {
+	if s.templateRepo == nil {
+		return builtinTemplate(kind), nil
+	}
+
+	owners := []string{""}
+	if username != "" {
+		owners = []string{username, ""}
+	}
+	for _, owner := range owners {
+		tmpl, err := s.templateRepo.GetLatestTemplate(owner, kind)
+		if err != nil {
+			s.logger.Error("Failed to retrieve prompt template", "username", owner, "kind", kind, "error", err)
+			return types.PromptTemplate{}, err
+		}
+		if tmpl != nil {
+			return *tmpl, nil
+		}
+	}
+	return builtinTemplate(kind), nil
+}
+
+// GetPromptTemplates returns the template in use for each kind of prompt. An empty username
+// returns the admin defaults.
+func (s *Service) GetPromptTemplates(username string) ([]types.PromptTemplate, error) {
+	templates := make([]types.PromptTemplate, 0, len(types.PromptKinds))
+	for _, kind := range types.PromptKinds {
+		tmpl, err := s.promptTemplate(username, kind)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, tmpl)
+	}
+	return templates, nil
+}
+
+// SavePromptTemplate adds a new version of the user's template of the kind. An empty
+// username saves the admin default.
+func (s *Service) SavePromptTemplate(username string, kind string, body string) (types.PromptTemplate, error) {
+	if !slices.Contains(types.PromptKinds, kind) {
+		return types.PromptTemplate{}, errors.New("unknown template kind")
+	}
+	if strings.TrimSpace(body) == "" {
+		return types.PromptTemplate{}, errors.New("template body is required")
+	}
+	if _, err := executeTemplate(body, samplePromptData); err != nil {
+		return types.PromptTemplate{}, fmt.Errorf("%w: %v", types.ErrInvalidTemplate, err)
+	}
+
+	// Versions keep counting past a reset, so a version always names one template
+	latest, err := s.templateRepo.GetLatestVersion(username, kind)
+	if err != nil {
+		s.logger.Error("Failed to retrieve prompt template version", "username", username, "kind", kind, "error", err)
+		return types.PromptTemplate{}, err
+	}
+	tmpl := types.PromptTemplate{
+		ID:        uuid.New().String(),
+		UserID:    username,
+		Kind:      kind,
+		Version:   latest + 1,
+		Body:      body,
+		CreatedAt: time.Now(),
+	}
+
+	if err := s.templateRepo.CreateTemplate(tmpl); err != nil {
+		s.logger.Error("Failed to save prompt template", "username", username, "kind", kind, "error", err)
+		return types.PromptTemplate{}, err
+	}
+	s.logger.Info("Prompt template saved", "username", username, "kind", kind, "version", tmpl.Version)
+	return tmpl, nil
+}
+
+// ResetPromptTemplate stops using the user's template of the kind so the admin default is
+// used again. An empty username resets the admin default in favour of the built-in template.
+// The versions are kept, since the LLM usage ledger refers to them.
+func (s *Service) ResetPromptTemplate(username string, kind string) error {
+	if !slices.Contains(types.PromptKinds, kind) {
+		return errors.New("unknown template kind")
+	}
+	reset, err := s.templateRepo.ResetTemplates(username, kind)
+	if err != nil {
+		s.logger.Error("Failed to reset prompt template", "username", username, "kind", kind, "error", err)
+		return err
+	}
+	if reset == 0 {
+		return errors.New("template not found")
+	}
+	return nil
+}
+
+// builtinTemplate returns the template of the kind shipped with the app
+func builtinTemplate(kind string) types.PromptTemplate {
+	return types.PromptTemplate{Kind: kind, Body: builtinTemplates[kind], BuiltIn: true}
+}
+
+// executeTemplate parses and executes a template, trimming the surrounding whitespace
+func executeTemplate(body string, data types.PromptData) (string, error) {
+	tmpl, err := template.New("prompt").Option("missingkey=error").Parse(body)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(b.String()), nil
+}
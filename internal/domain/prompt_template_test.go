@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/robstave/meowmorize/internal/adapters/repositories/mocks"
+	"github.com/robstave/meowmorize/internal/domain/types"
+	"github.com/robstave/meowmorize/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTemplateTestService() (*Service, *mocks.PromptTemplateRepository) {
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	templateRepo := setupPromptTemplateRepository()
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+
+	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, nil, nil, nil, nil, nil, templateRepo, nil).(*Service)
+	return s, templateRepo
+}
+
+func TestBuiltinTemplates(t *testing.T) {
+	s, templateRepo := newTemplateTestService()
+	templateRepo.On("GetLatestTemplate", mock.Anything, mock.Anything).Return(nil, nil)
+
+	prompt, err := s.renderPrompt("meow", types.LLMPurposeExplain, types.PromptData{
+		Front:    "Why do cats purr?",
+		Back:     "Contentment",
+		Question: "And when hurt?",
+		Turns:    []types.ExplanationTurn{{Question: "Why?", Answer: "Because"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "This is regarding the following flashcard:\n\n### Front\nWhy do cats purr?\n\n### Back\nContentment\n\n"+
+		"Earlier in this conversation:\n\nQuestion: Why?\nAnswer: Because\n\nQuestion: And when hurt?", prompt.text)
+	assert.Equal(t, types.LLMPurposeExplain, prompt.kind)
+	assert.Equal(t, "", prompt.templateID)
+	assert.Equal(t, 0, prompt.templateVersion)
+
+	prompt, err = s.renderPrompt("meow", types.LLMPurposeGrade, types.PromptData{
+		Front:      "Capital of France?",
+		Back:       "Paris",
+		Answer:     "paris",
+		Alternates: []string{"Paname", "City of Light"},
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, prompt.text, "Question:\nCapital of France?\n\nExpected answer:\nParis\n\nAlso accepted:\n- Paname\n- City of Light\n\nStudent's answer:\nparis")
+
+	// Every kind has a built-in template that executes
+	for _, kind := range types.PromptKinds {
+		_, err := executeTemplate(builtinTemplates[kind], samplePromptData)
+		assert.NoError(t, err, kind)
+	}
+}
+
+func TestRenderPrompt_UserAndDefaultTemplates(t *testing.T) {
+	s, templateRepo := newTemplateTestService()
+	templateRepo.On("GetLatestTemplate", "meow", types.LLMPurposeExplain).
+		Return(&types.PromptTemplate{ID: "mine", Version: 3, Body: "{{.Deck}}: {{.Question}}"}, nil)
+	templateRepo.On("GetLatestTemplate", "meow", types.LLMPurposeHint).Return(nil, nil)
+	templateRepo.On("GetLatestTemplate", "", types.LLMPurposeHint).
+		Return(&types.PromptTemplate{ID: "default", Version: 1, Body: "Hint for {{.Front}}"}, nil)
+	templateRepo.On("GetLatestTemplate", "meow", types.LLMPurposeGrade).
+		Return(&types.PromptTemplate{ID: "broken", Version: 1, Body: "{{.Front.Nope}}"}, nil)
+
+	prompt, err := s.renderPrompt("meow", types.LLMPurposeExplain, types.PromptData{Deck: "Cats", Question: "Why?"})
+	assert.NoError(t, err)
+	assert.Equal(t, renderedPrompt{kind: types.LLMPurposeExplain, text: "Cats: Why?", templateID: "mine", templateVersion: 3}, prompt)
+
+	prompt, err = s.renderPrompt("meow", types.LLMPurposeHint, types.PromptData{Front: "Purr"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Hint for Purr", prompt.text)
+	assert.Equal(t, "default", prompt.templateID)
+
+	// A stored template that fails falls back to the built-in one
+	prompt, err = s.renderPrompt("meow", types.LLMPurposeGrade, types.PromptData{Front: "Q", Back: "A", Answer: "a"})
+	assert.NoError(t, err)
+	assert.Contains(t, prompt.text, "You are grading a flashcard answer.")
+	assert.Equal(t, "", prompt.templateID)
+}
+
+func TestSavePromptTemplate(t *testing.T) {
+	s, templateRepo := newTemplateTestService()
+	templateRepo.On("GetLatestVersion", "meow", types.LLMPurposeExplain).Return(2, nil)
+	templateRepo.On("GetLatestVersion", "", types.LLMPurposeGrade).Return(0, nil)
+	templateRepo.On("CreateTemplate", mock.Anything).Return(nil)
+
+	saved, err := s.SavePromptTemplate("meow", types.LLMPurposeExplain, "Explain {{.Front}} to me: {{.Question}}")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, saved.Version)
+	assert.Equal(t, "meow", saved.UserID)
+	assert.NotEmpty(t, saved.ID)
+
+	saved, err = s.SavePromptTemplate("", types.LLMPurposeGrade, "Grade {{.Answer}}")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, saved.Version)
+	assert.Equal(t, "", saved.UserID)
+
+	_, err = s.SavePromptTemplate("meow", "poem", "{{.Front}}")
+	assert.EqualError(t, err, "unknown template kind")
+	_, err = s.SavePromptTemplate("meow", types.LLMPurposeExplain, "  ")
+	assert.EqualError(t, err, "template body is required")
+	_, err = s.SavePromptTemplate("meow", types.LLMPurposeExplain, "{{.Front")
+	assert.ErrorIs(t, err, types.ErrInvalidTemplate)
+	_, err = s.SavePromptTemplate("meow", types.LLMPurposeExplain, "{{.Nope}}")
+	assert.ErrorIs(t, err, types.ErrInvalidTemplate)
+
+	templateRepo.AssertNumberOfCalls(t, "CreateTemplate", 2)
+}
+
+func TestResetPromptTemplate(t *testing.T) {
+	s, templateRepo := newTemplateTestService()
+	templateRepo.On("ResetTemplates", "meow", types.LLMPurposeExplain).Return(int64(2), nil)
+	templateRepo.On("ResetTemplates", "meow", types.LLMPurposeHint).Return(int64(0), nil)
+
+	assert.NoError(t, s.ResetPromptTemplate("meow", types.LLMPurposeExplain))
+	assert.EqualError(t, s.ResetPromptTemplate("meow", types.LLMPurposeHint), "template not found")
+	assert.EqualError(t, s.ResetPromptTemplate("meow", "poem"), "unknown template kind")
+}
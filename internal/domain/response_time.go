@@ -0,0 +1,64 @@
+// internal/domain/response_time.go
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// slowPassThreshold is how long an answer may take before a pass counts as slow
+const slowPassThreshold = 10 * time.Second
+
+// maxResponseTime discards timings from cards left open, e.g. when the user walked away
+const maxResponseTime = 5 * time.Minute
+
+// responseTimeSamples is how many recent answers the per-card median is based on
+const responseTimeSamples = 25
+
+// responseTiming returns when the card was served in the deck's session and how long
+// the answer took. The response time is zero when it is unknown or implausibly long.
+func (s *Service) responseTiming(deckID string, cardID string) (*time.Time, int64) {
+	s.sessionsMu.RLock()
+	session, exists := s.sessions[deckID]
+	s.sessionsMu.RUnlock()
+
+	if !exists {
+		return nil, 0
+	}
+
+	servedAt, ok := session.CardServedAt(cardID)
+	if !ok {
+		return nil, 0
+	}
+
+	elapsed := time.Since(servedAt)
+	if elapsed <= 0 || elapsed > maxResponseTime {
+		return &servedAt, 0
+	}
+	return &servedAt, elapsed.Milliseconds()
+}
+
+// medianResponseMs returns the card's median answer time including the latest answer
+func (s *Service) medianResponseMs(cardID string, latest int64) int64 {
+	times, err := s.sessionLogRepo.GetRecentResponseTimes(cardID, responseTimeSamples-1)
+	if err != nil {
+		s.logger.Error("Failed to retrieve response times", "card_id", cardID, "error", err)
+		return latest
+	}
+	return median(append(times, latest))
+}
+
+// median returns the median of the values, or zero if there are none
+func median(values []int64) int64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]int64(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/robstave/meowmorize/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMedian(t *testing.T) {
+	assert.Equal(t, int64(0), median(nil))
+	assert.Equal(t, int64(300), median([]int64{900, 100, 300}))
+	assert.Equal(t, int64(250), median([]int64{400, 100, 200, 300}))
+}
+
+func TestEffectivePassCount_SlowPassesRankWeaker(t *testing.T) {
+	fast := types.Card{ID: "fast", PassCount: 4, FailCount: 1}
+	slow := types.Card{ID: "slow", PassCount: 4, FailCount: 1, SlowPassCount: 4}
+
+	assert.Equal(t, 4.0, effectivePassCount(fast))
+	assert.Equal(t, 2.0, effectivePassCount(slow))
+	assert.Greater(t, calculateFailRate(slow), calculateFailRate(fast))
+
+	selected := selectFailsCards([]types.Card{fast, slow}, 1)
+	assert.Equal(t, "slow", selected[0].ID)
+}
+
+func TestUpdateCardStats_RecordsResponseTime(t *testing.T) {
+	deckID := uuid.New().String()
+	card := types.Card{ID: "card1", UserID: "meow", Back: types.CardBack{Text: "Oslo"}}
+	deck := types.Deck{ID: deckID, Name: "Capitals", Cards: []types.Card{card}}
+
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	deckRepo.On("GetDeckByID", deckID).Return(deck, nil)
+	deckRepo.On("UpdateDeck", mock.AnythingOfType("types.Deck")).Return(nil)
+	cardRepo.On("GetCardByID", "card1").Return(&card, nil)
+	sessionRepo.On("GetRecentResponseTimes", "card1", responseTimeSamples-1).Return([]int64{20000, 30000}, nil)
+	cardRepo.On("UpdateCard", mock.MatchedBy(func(c types.Card) bool {
+		return c.PassCount == 1 && c.SlowPassCount == 1 && c.MedianResponseMs >= 15000
+	})).Return(nil)
+	sessionRepo.On("CreateLog", mock.MatchedBy(func(log types.SessionLog) bool {
+		return log.ServedAt != nil && log.ResponseMs >= 15000
+	})).Return(nil)
+
+	dm := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	assert.NoError(t, dm.StartSession(deckID, -1, types.RandomMethod, "meow", types.SessionOptions{}))
+
+	cardID, err := dm.GetNextCard(deckID)
+	assert.NoError(t, err)
+	assert.Equal(t, "card1", cardID)
+
+	// Pretend the card was served a while ago
+	servedAt := time.Now().Add(-15 * time.Second)
+	dm.(*Service).sessions[deckID].CardStats[0].ServedAt = &servedAt
+
+	assert.NoError(t, dm.UpdateCardStats("card1", types.IncrementPass, nil, deckID, "meow"))
+
+	stats, err := dm.GetSessionStats(deckID)
+	assert.NoError(t, err)
+	assert.Nil(t, stats.CardStats[0].ServedAt)
+	assert.GreaterOrEqual(t, stats.CardStats[0].ResponseMs, int64(15000))
+	assert.GreaterOrEqual(t, stats.CardStats[0].MedianResponseMs, int64(15000))
+
+	cardRepo.AssertExpectations(t)
+	sessionRepo.AssertExpectations(t)
+}
@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+// PruneSessionLogs deletes each user's raw session logs older than their retention.
+// Reviews live on in the daily rollups, which are never pruned.
+func (s *Service) PruneSessionLogs() (int64, error) {
+	users, err := s.userRepo.GetAllUsers()
+	if err != nil {
+		s.logger.Error("Failed to retrieve users", "error", err)
+		return 0, err
+	}
+
+	var pruned int64
+	for _, user := range users {
+		if user.LogRetentionDays <= 0 {
+			continue
+		}
+		cutoff := time.Now().AddDate(0, 0, -user.LogRetentionDays)
+		n, err := s.sessionLogRepo.PruneLogs(user.Username, cutoff)
+		if err != nil {
+			s.logger.Error("Failed to prune session logs", "username", user.Username, "error", err)
+			return pruned, err
+		}
+		pruned += n
+	}
+
+	if pruned > 0 {
+		s.logger.Info("Pruned session logs", "rows", pruned)
+	}
+	return pruned, nil
+}
@@ -0,0 +1,95 @@
+// internal/domain/schedule.go
+package domain
+
+import (
+	"math"
+	"time"
+
+	"github.com/robstave/meowmorize/internal/domain/types"
+)
+
+// intervalGrowth is how much the review interval grows after a pass
+const intervalGrowth = 2.5
+
+// slowIntervalGrowth is the growth after a slow pass; the card is known but not well
+const slowIntervalGrowth = 1.5
+
+// hintedIntervalGrowth is the growth after a pass that needed a hint
+const hintedIntervalGrowth = 1.2
+
+// maxIntervalDays caps the review interval
+const maxIntervalDays = 365
+
+// resurrectIntervalDays is how long a retired card rests before its resurrect review
+const resurrectIntervalDays = 180
+
+// passGrade tells how well a passed card was known
+type passGrade int
+
+const (
+	cleanPass  passGrade = iota
+	slowPass             // Slow or only partially right
+	hintedPass           // Needed a hint
+)
+
+// gradePass grades a pass from its review details. A hint outweighs slowness.
+func gradePass(details reviewDetails) passGrade {
+	switch {
+	case details.HintLevel > 0:
+		return hintedPass
+	case details.Partial || details.ResponseMs > slowPassThreshold.Milliseconds():
+		return slowPass
+	}
+	return cleanPass
+}
+
+// scheduleCard sets the next review of a card after a pass or fail. A pass grows the
+// interval, less so the weaker its grade; a fail makes the card due again right away.
+// Skips leave the schedule alone.
+func scheduleCard(card *types.Card, action types.CardAction, grade passGrade, now time.Time) {
+	switch action {
+	case types.IncrementPass:
+		growth := intervalGrowth
+		switch grade {
+		case slowPass:
+			growth = slowIntervalGrowth
+		case hintedPass:
+			growth = hintedIntervalGrowth
+		}
+		interval := 1
+		if card.IntervalDays > 0 {
+			interval = int(math.Ceil(float64(card.IntervalDays) * growth))
+		}
+		card.IntervalDays = min(interval, maxIntervalDays)
+		card.DueAt = now.AddDate(0, 0, card.IntervalDays)
+	case types.IncrementFail:
+		card.IntervalDays = 0
+		card.DueAt = now
+	}
+}
+
+// retireCard retires the card and schedules its resurrect review
+func retireCard(card *types.Card, now time.Time) {
+	card.Retired = true
+	card.IntervalDays = resurrectIntervalDays
+	card.DueAt = now.AddDate(0, 0, resurrectIntervalDays)
+}
+
+// unretireCard brings the card back into rotation, due right away
+func unretireCard(card *types.Card, now time.Time) {
+	card.Retired = false
+	card.IntervalDays = 0
+	card.DueAt = now
+}
+
+// scheduleRetiredCard handles the review of a retired card. A pass confirms the card is
+// still known and pushes the next resurrect review further out; a fail unretires it.
+func scheduleRetiredCard(card *types.Card, action types.CardAction, now time.Time) {
+	switch action {
+	case types.IncrementPass:
+		card.IntervalDays = max(card.IntervalDays*2, resurrectIntervalDays)
+		card.DueAt = now.AddDate(0, 0, card.IntervalDays)
+	case types.IncrementFail:
+		unretireCard(card, now)
+	}
+}
@@ -1,8 +1,11 @@
 package domain
 
 import (
+	"context"
+	"io"
 	"log/slog"
 	"sync"
+	"time"
 
 	"github.com/robstave/meowmorize/internal/adapters/repositories"
 	"github.com/robstave/meowmorize/internal/domain/types"
@@ -15,8 +18,19 @@ type Service struct {
 	userRepo       repositories.UserRepository
 	sessionLogRepo repositories.SessionLogRepository
 	llmRepo        repositories.LLMRepository
+	attachmentRepo repositories.AttachmentRepository
+	draftRepo      repositories.CardDraftRepository
+	threadRepo     repositories.ExplanationRepository
+	usageRepo      repositories.LLMUsageRepository
+	templateRepo   repositories.PromptTemplateRepository
+	lintRepo       repositories.LintRepository
 	sessions       map[string]*types.Session
 	sessionsMu     sync.RWMutex
+	events         *eventBus
+	locations      map[string]*time.Location // Timezone per username
+	locationsMu    sync.Mutex
+	lintCancels    map[string]context.CancelFunc // Cancels a running lint job, by job ID
+	lintMu         sync.Mutex
 }
 
 type MeowDomain interface {
@@ -39,15 +53,49 @@ type MeowDomain interface {
 	UpdateCardStats(cardID string, action types.CardAction, value *int, deckID string, userID string) error
 
 	// LLM methods
-	GetExplanation(prompt string) (string, error)
+	GetExplanation(ctx context.Context, username string, prompt string) (string, error)
 	IsLLMAvailable() bool
+	GetLLMStatus() types.LLMStatus
+	GetLLMUsage(username string) (types.LLMUsageSummary, error)
+	SetLLMDailyQuota(userID string, quota int) error
+	GetPromptTemplates(username string) ([]types.PromptTemplate, error)
+	SavePromptTemplate(username string, kind string, body string) (types.PromptTemplate, error)
+	ResetPromptTemplate(username string, kind string) error
+	ExplainCard(ctx context.Context, cardID string, username string, question string) (types.ExplanationTurn, error)
+	StreamExplainCard(ctx context.Context, cardID string, username string, question string, onChunk func(chunk string) error) (types.ExplanationTurn, error)
+	GetExplanationThreads(username string) ([]types.ExplanationThreadSummary, error)
+	GetExplanationThread(cardID string, username string) (types.ExplanationThread, error)
+	DeleteExplanationThread(cardID string, username string) error
+	PromoteExplanation(turnID string, username string, target string) (*types.Card, error)
+	GenerateCards(ctx context.Context, username string, request types.GenerateCardsRequest) ([]types.CardDraft, error)
+	GetCardDrafts(username string, deckID string) ([]types.CardDraft, error)
+	ApproveCardDraft(draftID string, username string, edit types.CardDraftEdit) (*types.Card, error)
+	RejectCardDraft(draftID string, username string) error
+	StartDeckLint(deckID string, username string) (types.LintJob, error)
+	GetDeckLint(deckID string, username string) (types.LintJob, error)
+	CancelDeckLint(deckID string, username string) error
+	GetLintFindings(deckID string, username string, status string) ([]types.LintFinding, error)
+	AcceptLintFinding(findingID string, username string, edit types.LintFindingEdit) (*types.Card, error)
+	DismissLintFinding(findingID string, username string) error
+	GetCardRevisions(cardID string) ([]types.CardRevision, error)
+	GetCardHint(ctx context.Context, cardID string, deckID string, username string, level int) (types.Hint, error)
+	TranslateDeck(ctx context.Context, deckID string, username string, language string) (*types.Deck, error)
+	RetranslateCard(ctx context.Context, cardID string, username string) (*types.Card, error)
 
 	// Session Management
-	StartSession(deckID string, count int, method types.SessionMethod, userID string) error
+	StartSession(deckID string, count int, method types.SessionMethod, userID string, opts types.SessionOptions) error
 	AdjustSession(deckID string, cardID string, action types.CardAction, value int, userID string) error
 	GetNextCard(deckID string) (string, error)
 	ClearSession(deckID string) error
 	GetSessionStats(deckID string) (types.SessionStats, error)
+	SubmitAnswer(ctx context.Context, deckID string, cardID string, answer string, userID string) (types.AnswerGrade, error)
+	UndoReviews(deckID string, count int) (int, error)
+	SubscribeSessionEvents(sessionID string, username string) (<-chan types.SessionEvent, func(), error)
+
+	// Attachments
+	UploadAttachment(userID string, filename string, content []byte) (types.Attachment, error)
+	GetAttachment(id string, userID string) (*types.Attachment, io.ReadSeekCloser, error)
+	GarbageCollectAttachments() (int, error)
 
 	// Clear Deck Statistics
 	ClearDeckStats(deckID string, clearSession bool, clearStats bool) error
@@ -58,11 +106,18 @@ type MeowDomain interface {
 	GetAllUsers() ([]types.User, error)
 	DeleteUser(userID string) error
 	UpdateUserPassword(userID string, password string) error
+	UpdateUserSettings(username string, settings types.UserSettings) error
+	GetActivityCalendar(username string, deckID string, days int) (types.ActivityCalendar, error)
+	PruneSessionLogs() (int64, error)
+	GetForecast(username string, days int) (types.Forecast, error)
+	GetDeckAnalytics(deckID string, username string) (types.DeckAnalytics, error)
+	GetLeeches(username string) ([]types.Leech, error)
 	SeedUser() error
 
 	GetSessionLogsBySessionID(sessionID string) ([]types.SessionLog, error)
-	GetSessionLogIdsByUser(userID, deckID string) ([]string, error)
-	GetSessionOverview(userID string, deckID string) ([]types.SessionOverview, error)
+	GetSessionLogIdsByUser(userID string, query types.SessionHistoryQuery) ([]string, int64, error)
+	GetSessionOverview(userID string, query types.SessionHistoryQuery) ([]types.SessionOverview, int64, error)
+	GetSessionReport(sessionID string, userID string) (types.SessionReport, error)
 }
 
 func NewService(logger *slog.Logger,
@@ -70,7 +125,13 @@ func NewService(logger *slog.Logger,
 	cardRepo repositories.CardRepository,
 	userRepo repositories.UserRepository,
 	sessionLogRepo repositories.SessionLogRepository,
-	llmRepo repositories.LLMRepository) MeowDomain {
+	llmRepo repositories.LLMRepository,
+	attachmentRepo repositories.AttachmentRepository,
+	draftRepo repositories.CardDraftRepository,
+	threadRepo repositories.ExplanationRepository,
+	usageRepo repositories.LLMUsageRepository,
+	templateRepo repositories.PromptTemplateRepository,
+	lintRepo repositories.LintRepository) MeowDomain {
 
 	service := &Service{
 		logger:         logger,
@@ -79,8 +140,17 @@ func NewService(logger *slog.Logger,
 		userRepo:       userRepo,
 		sessionLogRepo: sessionLogRepo,
 		llmRepo:        llmRepo,
+		attachmentRepo: attachmentRepo,
+		draftRepo:      draftRepo,
+		threadRepo:     threadRepo,
+		usageRepo:      usageRepo,
+		templateRepo:   templateRepo,
+		lintRepo:       lintRepo,
 		sessions:       make(map[string]*types.Session),
 		sessionsMu:     sync.RWMutex{},
+		events:         newEventBus(),
+		locations:      make(map[string]*time.Location),
+		lintCancels:    make(map[string]context.CancelFunc),
 	}
 
 	// Seed the initial user. This is called on every startup, but will only create the user if it doesn't already exist
@@ -9,13 +9,25 @@ import (
 	"github.com/robstave/meowmorize/internal/domain/types"
 )
 
+// defaultGraduateAfter is how many consecutive passes end learning when not configured
+const defaultGraduateAfter = 2
+
 // generateSessionID generates a unique session ID
 func generateSessionID() string {
 	return uuid.New().String()
 }
 
 // StartSession initializes or resets a session for a given deck
-func (s *Service) StartSession(deckID string, count int, method types.SessionMethod, userID string) error {
+func (s *Service) StartSession(deckID string, count int, method types.SessionMethod, userID string, opts types.SessionOptions) error {
+	for _, step := range opts.LearningSteps {
+		if step < 1 {
+			return errors.New("learning steps must be positive")
+		}
+	}
+	if opts.TimeLimit < 0 || opts.GoalCorrect < 0 {
+		return errors.New("session limits must not be negative")
+	}
+
 	s.sessionsMu.Lock()
 	defer s.sessionsMu.Unlock()
 
@@ -57,12 +69,13 @@ func (s *Service) StartSession(deckID string, count int, method types.SessionMet
 	cardStats := make([]types.CardStats, deck_len)
 	for i, card := range selectedCards {
 		cardStats[i] = types.CardStats{
-			CardID:  card.ID,
-			Viewed:  false,
-			Skipped: false,
-			Passed:  false,
-			Failed:  false,
-			Stars:   card.StarRating,
+			CardID:           card.ID,
+			Viewed:           false,
+			Skipped:          false,
+			Passed:           false,
+			Failed:           false,
+			Stars:            card.StarRating,
+			MedianResponseMs: card.MedianResponseMs,
 		}
 	}
 
@@ -73,26 +86,63 @@ func (s *Service) StartSession(deckID string, count int, method types.SessionMet
 		CurrentIndex: 0,
 	}
 
+	mode := opts.Mode
+	if mode == "" {
+		mode = types.SelfGradedMode
+	}
+	strictness := opts.Strictness
+	if strictness == "" {
+		strictness = types.NormalStrictness
+	}
+	roundPolicy := opts.RoundPolicy
+	if roundPolicy == "" {
+		roundPolicy = types.ResortPolicy
+	}
+	graduateAfter := opts.GraduateAfter
+	if graduateAfter <= 0 {
+		graduateAfter = defaultGraduateAfter
+	}
+
 	sessionID := generateSessionID()
 	// Initialize the session
 	session := &types.Session{
-		DeckID:    deckID,
-		UserID:    userID,
-		SessionID: sessionID,
-		CardStats: cardStats,
-		Method:    method,
-		Index:     0,
-		Stats:     stats,
+		DeckID:        deckID,
+		UserID:        userID,
+		SessionID:     sessionID,
+		CardStats:     cardStats,
+		Method:        method,
+		Mode:          mode,
+		Strictness:    strictness,
+		Index:         0,
+		Stats:         stats,
+		RoundPolicy:   roundPolicy,
+		Round:         1,
+		LearningSteps: opts.LearningSteps,
+		GraduateAfter: graduateAfter,
+		StartedAt:     time.Now(),
+		TimeLimit:     opts.TimeLimit,
+		GoalCorrect:   opts.GoalCorrect,
+	}
+
+	// Followers of a replaced session have nothing more to follow
+	if previous, exists := s.sessions[deckID]; exists {
+		s.events.closeSession(previous.SessionID)
 	}
 
 	// Add or reset the session in the map
 	s.sessions[deckID] = session
-	s.logger.Info("Session started", "deck_id", deckID, "method", method, "card_count", len(selectedCards))
+	s.logger.Info("Session started", "deck_id", deckID, "method", method, "mode", mode, "round_policy", roundPolicy, "card_count", len(selectedCards))
 	return nil
 }
 
-// selectCards selects cards based on the provided method
+// selectCards selects cards based on the provided method. Retired cards are only
+// selected by the Retired and Resurrect methods.
 func selectCards(cards []types.Card, count int, method types.SessionMethod) ([]types.Card, error) {
+	cards = eligibleCards(cards, method, time.Now())
+	if count < 0 || count > len(cards) {
+		count = len(cards)
+	}
+
 	switch method {
 	case types.RandomMethod:
 		return selectRandomCards(cards, count), nil
@@ -108,6 +158,8 @@ func selectCards(cards []types.Card, count int, method types.SessionMethod) ([]t
 		return selectUnratedCards(cards, count), nil // New Unrated method
 	case types.AdjustedRandomMethod:
 		return selectAdjustedRandomCards(cards, count), nil // New Unrated method
+	case types.RetiredMethod, types.ResurrectMethod:
+		return selectRandomCards(cards, count), nil
 	default:
 		return nil, errors.New("invalid session method")
 	}
@@ -115,6 +167,11 @@ func selectCards(cards []types.Card, count int, method types.SessionMethod) ([]t
 
 // AdjustSession updates the session based on card actions
 func (s *Service) AdjustSession(deckID string, cardID string, action types.CardAction, value int, userID string) error {
+	return s.adjustSession(deckID, cardID, action, value, userID, reviewDetails{})
+}
+
+// adjustSession updates the session and logs the action with its review details
+func (s *Service) adjustSession(deckID string, cardID string, action types.CardAction, value int, userID string, details reviewDetails) error {
 	s.sessionsMu.RLock()
 	session, exists := s.sessions[deckID]
 	s.sessionsMu.RUnlock()
@@ -129,9 +186,11 @@ func (s *Service) AdjustSession(deckID string, cardID string, action types.CardA
 
 	// Find the card in the session
 	var cardStat *types.CardStats
+	position := 0
 	for i := range session.CardStats {
 		if session.CardStats[i].CardID == cardID {
 			cardStat = &session.CardStats[i]
+			position = i
 			break
 		}
 	}
@@ -139,6 +198,10 @@ func (s *Service) AdjustSession(deckID string, cardID string, action types.CardA
 	if cardStat == nil {
 		return errors.New("card not found in session")
 	}
+	previousStat := *cardStat
+	previousRelearn := append([]types.RelearnCard(nil), session.Relearn...)
+	previousCorrect, previousCompleted, previousReason := session.Correct, session.Completed, session.CompleteReason
+	previousCompleteLogID := session.CompleteLogID
 
 	logSessionStat := false
 
@@ -191,36 +254,113 @@ func (s *Service) AdjustSession(deckID string, cardID string, action types.CardA
 		return errors.New("invalid card action")
 	}
 
-	// Recalculate session stats
-	session.Stats.TotalCards = len(session.CardStats)
-
-	var viewed = 0
-
-	for i := range session.CardStats {
-		if session.CardStats[i].Viewed {
-			viewed++
+	if logSessionStat {
+		// The card has been answered; the next serve starts a new timing
+		if session.Serving == cardID {
+			session.Serving = ""
+		}
+		if session.SelfGradeCardID == cardID {
+			session.SelfGradeCardID = ""
 		}
+		cardStat.ServedAt = nil
+		cardStat.HintLevel = 0
+		cardStat.ResponseMs = details.ResponseMs
+		if details.MedianResponseMs > 0 {
+			cardStat.MedianResponseMs = details.MedianResponseMs
+		}
+		// Skips count as misses for the learning steps
+		session.RecordResult(cardID, action == types.IncrementPass)
 	}
 
-	session.Stats.ViewedCount = viewed
-	session.Stats.Remaining = session.Stats.TotalCards - viewed
-	session.Stats.CurrentIndex = session.Index
+	recalculateSessionStats(session)
 
 	// Hook: Log the card action.
 	// (Assuming for this example that sessionID is the same as deckID and username is derived from context.)
 	if logSessionStat {
-		err := s.LogSessionAction(deckID, cardID, session.SessionID, userID, string(action))
+		if details.LogID == "" {
+			details.LogID = uuid.New().String()
+		}
+		details.Round = session.Round
+		err := s.logSessionAction(deckID, cardID, session.SessionID, userID, string(action), details)
 
 		if err != nil {
 			s.logger.Error("Failed to log session action", "card_id", cardID, "action", action, "error", err)
 			// Optionally, you could decide to return the error.
 		}
+
+		// Reviews that changed the card can be undone
+		if details.PreviousCard != nil {
+			pushReviewSnapshot(session, types.ReviewSnapshot{
+				Card:      *details.PreviousCard,
+				CardStats: previousStat,
+				Position:  position,
+				LogID:     details.LogID,
+				Relearn:   previousRelearn,
+
+				Correct:        previousCorrect,
+				Served:         max(session.Served-1, 0),
+				Completed:      previousCompleted,
+				CompleteReason: previousReason,
+				CompleteLogID:  previousCompleteLogID,
+			})
+		}
+
+		s.publishSessionEvent(session, types.CardGradedEvent, cardID, action)
+	}
+
+	// Star changes are logged too, so the session report can show them
+	if action == types.SetStars && details.Stars != nil {
+		details.Round = session.Round
+		if err := s.logSessionAction(deckID, cardID, session.SessionID, userID, string(action), details); err != nil {
+			s.logger.Error("Failed to log star change", "card_id", cardID, "error", err)
+		}
 	}
 
 	s.logger.Info("Session adjusted", "deck_id", deckID, "card_id", cardID, "action", action, "session_id", session.SessionID)
 	return nil
 }
 
+// checkSessionReview makes sure the deck's session, if there is one, takes the action on
+// the card. Typed and LLM graded sessions only take answers graded by SubmitAnswer, unless
+// the grader could not grade the card and left it to the user.
+func (s *Service) checkSessionReview(deckID string, cardID string, action types.CardAction, graded bool) error {
+	s.sessionsMu.RLock()
+	defer s.sessionsMu.RUnlock()
+
+	session, exists := s.sessions[deckID]
+	if !exists {
+		return nil
+	}
+	if !session.HasCard(cardID) {
+		return errors.New("card not found in session")
+	}
+
+	switch action {
+	case types.IncrementFail, types.IncrementPass, types.IncrementSkip:
+		if session.TypedAnswers() && !graded && session.SelfGradeCardID != cardID {
+			return errors.New("session only takes typed answers")
+		}
+	}
+	return nil
+}
+
+// recalculateSessionStats refreshes the session stats from the card states
+func recalculateSessionStats(session *types.Session) {
+	session.Stats.TotalCards = len(session.CardStats)
+
+	var viewed = 0
+
+	for i := range session.CardStats {
+		if session.CardStats[i].Viewed {
+			viewed++
+		}
+	}
+
+	session.Stats.ViewedCount = viewed
+	session.Stats.Remaining = session.Stats.TotalCards - viewed
+	session.Stats.CurrentIndex = session.Index
+}
+
 // GetNextCard retrieves the next card ID in the session
 func (s *Service) GetNextCard(deckID string) (string, error) {
 	s.sessionsMu.RLock()
@@ -234,21 +374,63 @@ func (s *Service) GetNextCard(deckID string) (string, error) {
 	s.sessionsMu.Lock()
 	defer s.sessionsMu.Unlock()
 
-	cardID := session.GetNextCard()
+	wasComplete := session.Completed
+	if session.CheckComplete(time.Now()) {
+		if !wasComplete {
+			s.completeSession(session)
+		}
+		return "", types.ErrSessionComplete
+	}
+
+	cardID, newRound := session.Advance()
+	if session.Completed {
+		// The round policy ended the session
+		s.completeSession(session)
+		return "", types.ErrSessionComplete
+	}
+
+	if newRound {
+		// Record the round boundary so the overview can tell the rounds apart
+		if err := s.logSessionAction(deckID, "", session.SessionID, session.UserID, "reshuffle", reviewDetails{Round: session.Round}); err != nil {
+			s.logger.Error("Failed to log reshuffle", "deck_id", deckID, "round", session.Round, "error", err)
+		}
+		s.publishSessionEvent(session, types.ReshuffleEvent, "", "")
+	}
+	if cardID != "" {
+		s.publishSessionEvent(session, types.CardServedEvent, cardID, "")
+	}
 
 	return cardID, nil
 }
 
+// completeSession records the outcome of a session that just completed and tells its followers
+func (s *Service) completeSession(session *types.Session) {
+	details := reviewDetails{
+		LogID:   uuid.New().String(),
+		Round:   session.Round,
+		Outcome: session.CompleteReason,
+		GoalMet: session.GoalMet(),
+	}
+	session.CompleteLogID = details.LogID
+	if err := s.logSessionAction(session.DeckID, "", session.SessionID, session.UserID, "complete", details); err != nil {
+		s.logger.Error("Failed to log session completion", "deck_id", session.DeckID, "session_id", session.SessionID, "error", err)
+	}
+	s.publishSessionEvent(session, types.SessionCompleteEvent, "", "")
+	s.logger.Info("Session complete", "deck_id", session.DeckID, "session_id", session.SessionID, "reason", session.CompleteReason, "goal_met", details.GoalMet)
+}
+
 // ClearSession removes a session from the sessions map
 func (s *Service) ClearSession(deckID string) error {
 	s.sessionsMu.Lock()
 	defer s.sessionsMu.Unlock()
 
-	if _, exists := s.sessions[deckID]; !exists {
+	session, exists := s.sessions[deckID]
+	if !exists {
 		return errors.New("session does not exist for the given deck")
 	}
 
 	delete(s.sessions, deckID)
+	s.events.closeSession(session.SessionID)
 	s.logger.Info("Session cleared", "deck_id", deckID)
 	return nil
 }
@@ -7,18 +7,60 @@ import (
 	"github.com/robstave/meowmorize/internal/domain/types"
 )
 
+// reviewDetails carries the optional data recorded on a SessionLog row
+// alongside the action itself
+type reviewDetails struct {
+	LogID      string // Generated when empty
+	Round      int
+	Answer     string
+	Graded     bool // Graded by SubmitAnswer rather than by the user
+	Partial    bool // The LLM judged the answer partially right; the pass is scheduled like a slow one
+	HintLevel  int  // Highest hint level shown before the answer, see GetCardHint
+	ServedAt   *time.Time
+	ResponseMs int64
+	// MedianResponseMs is not logged; it is mirrored into the session card stats
+	MedianResponseMs int64
+	// PreviousCard is the card before the review, kept so the review can be undone
+	PreviousCard *types.Card
+	// Outcome and GoalMet describe a completed session
+	Outcome types.CompleteReason
+	GoalMet bool
+	// Stars and PreviousStars record a star change
+	Stars         *int
+	PreviousStars *int
+}
+
 // LogSessionAction logs an action for a session.
-// Valid actions include: "pass", "fail", "skip", "reshuffle".
+// Valid actions include: "pass", "fail", "skip", "reshuffle", "complete" and star changes.
 func (s *Service) LogSessionAction(deckID, cardID, sessionID, userID, action string) error {
+	return s.logSessionAction(deckID, cardID, sessionID, userID, action, reviewDetails{})
+}
+
+// logSessionAction logs an action for a session along with its review details.
+func (s *Service) logSessionAction(deckID, cardID, sessionID, userID, action string, details reviewDetails) error {
+	logID := details.LogID
+	if logID == "" {
+		logID = uuid.New().String()
+	}
 	logEntry := types.SessionLog{
-		ID:        uuid.New().String(),
-		DeckID:    deckID,
-		CardID:    cardID,
-		SessionID: sessionID,
-		UserID:    userID,
-		Action:    action,
-		CreatedAt: time.Now(),
+		ID:            logID,
+		DeckID:        deckID,
+		CardID:        cardID,
+		SessionID:     sessionID,
+		UserID:        userID,
+		Action:        action,
+		Answer:        details.Answer,
+		ServedAt:      details.ServedAt,
+		ResponseMs:    details.ResponseMs,
+		Round:         details.Round,
+		Outcome:       details.Outcome,
+		GoalMet:       details.GoalMet,
+		Stars:         details.Stars,
+		PreviousStars: details.PreviousStars,
+		HintLevel:     details.HintLevel,
+		CreatedAt:     time.Now(),
 	}
+	logEntry.Day = s.localDayOf(userID, logEntry.CreatedAt)
 	if err := s.sessionLogRepo.CreateLog(logEntry); err != nil {
 		s.logger.Error("Failed to log session action", "error", err)
 		return err
@@ -32,7 +74,17 @@ func (s *Service) GetSessionLogsBySessionID(sessionID string) ([]types.SessionLo
 	return s.sessionLogRepo.GetSessionLogsBySessionID(sessionID)
 }
 
-// GetSessionLogIdsByUser retrieves session log IDs for a given user and optionally a deck.
-func (s *Service) GetSessionLogIdsByUser(userID, deckID string) ([]string, error) {
-	return s.sessionLogRepo.GetSessionLogIdsByUser(userID, deckID)
+// GetSessionLogIdsByUser retrieves a page of session IDs for a given user, newest first,
+// and the total number of sessions matching the query.
+func (s *Service) GetSessionLogIdsByUser(userID string, query types.SessionHistoryQuery) ([]string, int64, error) {
+	return s.sessionLogRepo.GetSessionLogIdsByUser(userID, query)
+}
+
+// isReviewAction reports whether a session log action is a pass, fail or skip
+func isReviewAction(action string) bool {
+	switch types.CardAction(action) {
+	case types.IncrementPass, types.IncrementFail, types.IncrementSkip:
+		return true
+	}
+	return false
 }
@@ -4,21 +4,18 @@ import (
 	"github.com/robstave/meowmorize/internal/domain/types"
 )
 
-// GetSessionOverview retrieves the last 3 session overviews for a user and deck
-func (s *Service) GetSessionOverview(userID string, deckID string) ([]types.SessionOverview, error) {
-	// Get recent session IDs
-	sessionIDs, err := s.sessionLogRepo.GetSessionLogIdsByUser(userID, deckID)
+// GetSessionOverview retrieves a page of session overviews for a user, newest first,
+// and the total number of sessions matching the query
+func (s *Service) GetSessionOverview(userID string, query types.SessionHistoryQuery) ([]types.SessionOverview, int64, error) {
+	// Get the session IDs of the page
+	sessionIDs, total, err := s.sessionLogRepo.GetSessionLogIdsByUser(userID, query)
 	if err != nil {
-		s.logger.Error("Failed to retrieve session IDs", "user_id", userID, "deck_id", deckID, "error", err)
-		return nil, err
+		s.logger.Error("Failed to retrieve session IDs", "user_id", userID, "deck_id", query.DeckID, "error", err)
+		return nil, 0, err
 	}
 
 	var overviews []types.SessionOverview
-	for i, sessionID := range sessionIDs {
-		if i >= 3 {
-			break
-		}
-
+	for _, sessionID := range sessionIDs {
 		logs, err := s.sessionLogRepo.GetSessionLogsBySessionID(sessionID)
 		if err != nil {
 			s.logger.Error("Failed to retrieve session logs", "session_id", sessionID, "error", err)
@@ -29,14 +26,14 @@ func (s *Service) GetSessionOverview(userID string, deckID string) ([]types.Sess
 			continue
 		}
 
-		overview := calculateSessionOverview(logs, sessionID, deckID)
+		overview := calculateSessionOverview(logs, sessionID, logs[0].DeckID)
 		overview.Timestamp = logs[0].CreatedAt
 
 		// append to the list
 		overviews = append(overviews, overview)
 	}
 
-	return overviews, nil
+	return overviews, total, nil
 }
 
 // calculateSessionOverview calculates the session overview based on the logs
@@ -49,8 +46,13 @@ func calculateSessionOverview(logs []types.SessionLog, sessionID, deckID string)
 	cardAttempts := map[string][]string{}
 
 	totalFlips := 0
-	for _, log := range logs {
-		if log.Action == "reshuffle" {
+	var completion *types.SessionLog
+	for i, log := range logs {
+		if log.Action == "complete" && !log.Undone {
+			completion = &logs[i]
+			continue
+		}
+		if !isReviewAction(log.Action) || log.Undone {
 			continue
 		}
 		cardAttempts[log.CardID] = append(cardAttempts[log.CardID], log.Action)
@@ -78,10 +80,13 @@ func calculateSessionOverview(logs []types.SessionLog, sessionID, deckID string)
 
 	}
 
-	initialPercentage := (float64(initialPasses) / float64(totalCards)) * 100
-	finalPercentage := (float64(finalPasses) / float64(totalCards)) * 100
+	var initialPercentage, finalPercentage float64
+	if totalCards > 0 {
+		initialPercentage = (float64(initialPasses) / float64(totalCards)) * 100
+		finalPercentage = (float64(finalPasses) / float64(totalCards)) * 100
+	}
 
-	return types.SessionOverview{
+	overview := types.SessionOverview{
 		DeckID:          deckID,
 		SessionID:       sessionID,
 		Timestamp:       logs[0].CreatedAt,
@@ -90,4 +95,10 @@ func calculateSessionOverview(logs []types.SessionLog, sessionID, deckID string)
 		CardsAfter:      totalFlips,
 		PercentageAfter: finalPercentage,
 	}
+	if completion != nil {
+		overview.Completed = true
+		overview.Outcome = completion.Outcome
+		overview.GoalMet = completion.GoalMet
+	}
+	return overview
 }
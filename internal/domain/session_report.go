@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"errors"
+
+	"github.com/robstave/meowmorize/internal/domain/types"
+)
+
+// GetSessionReport builds the per-card breakdown of a session owned by the user,
+// compared against the previous session of the same deck
+func (s *Service) GetSessionReport(sessionID string, userID string) (types.SessionReport, error) {
+	logs, err := s.sessionLogRepo.GetSessionLogsBySessionID(sessionID)
+	if err != nil {
+		s.logger.Error("Failed to retrieve session logs", "session_id", sessionID, "error", err)
+		return types.SessionReport{}, err
+	}
+	if len(logs) == 0 || logs[0].UserID != userID {
+		return types.SessionReport{}, errors.New("session not found")
+	}
+
+	report := calculateSessionReport(logs, sessionID)
+
+	for i := range report.CardReports {
+		card, err := s.cardRepo.GetCardByID(report.CardReports[i].CardID)
+		if err != nil || card == nil {
+			// The card may have been deleted since the session
+			continue
+		}
+		report.CardReports[i].Front = card.Front.Text
+	}
+
+	// The previous session is the most recent one of the deck that ended before this one started
+	previousIDs, _, err := s.sessionLogRepo.GetSessionLogIdsByUser(userID, types.SessionHistoryQuery{
+		DeckID: report.DeckID,
+		To:     report.StartedAt,
+		Limit:  1,
+	})
+	if err != nil {
+		s.logger.Error("Failed to retrieve previous session", "session_id", sessionID, "error", err)
+		return report, nil
+	}
+	if len(previousIDs) == 0 {
+		return report, nil
+	}
+
+	previousLogs, err := s.sessionLogRepo.GetSessionLogsBySessionID(previousIDs[0])
+	if err != nil || len(previousLogs) == 0 {
+		return report, nil
+	}
+	previous := calculateSessionReport(previousLogs, previousIDs[0])
+	report.Previous = &types.SessionDelta{
+		PreviousSessionID: previous.SessionID,
+		Cards:             report.Cards - previous.Cards,
+		FirstTryAccuracy:  report.FirstTryAccuracy - previous.FirstTryAccuracy,
+		FinalAccuracy:     report.FinalAccuracy - previous.FinalAccuracy,
+		TimeSpentMs:       report.TimeSpentMs - previous.TimeSpentMs,
+	}
+
+	return report, nil
+}
+
+// calculateSessionReport builds the report of a session from its logs, ordered by time.
+// Undone reviews are left out. Card fronts and the previous session are filled in by the caller.
+func calculateSessionReport(logs []types.SessionLog, sessionID string) types.SessionReport {
+	report := types.SessionReport{
+		SessionID:   sessionID,
+		DeckID:      logs[0].DeckID,
+		UserID:      logs[0].UserID,
+		StartedAt:   logs[0].CreatedAt,
+		EndedAt:     logs[len(logs)-1].CreatedAt,
+		CardReports: []types.CardReport{},
+	}
+
+	// Keep the cards in the order they were first seen
+	index := map[string]int{}
+	cardReport := func(cardID string) *types.CardReport {
+		i, ok := index[cardID]
+		if !ok {
+			i = len(report.CardReports)
+			index[cardID] = i
+			report.CardReports = append(report.CardReports, types.CardReport{CardID: cardID, Attempts: []types.CardAttempt{}})
+		}
+		return &report.CardReports[i]
+	}
+
+	for _, log := range logs {
+		if log.Round > report.Rounds {
+			report.Rounds = log.Round
+		}
+
+		switch {
+		case log.Action == "complete" && !log.Undone:
+			report.Completed = true
+			report.Outcome = log.Outcome
+			report.GoalMet = log.GoalMet
+		case log.Action == "SetStars" && log.CardID != "":
+			card := cardReport(log.CardID)
+			if card.StarsBefore == nil {
+				card.StarsBefore = log.PreviousStars
+			}
+			card.StarsAfter = log.Stars
+		case isReviewAction(log.Action) && !log.Undone:
+			card := cardReport(log.CardID)
+			card.Attempts = append(card.Attempts, types.CardAttempt{
+				Action:     log.Action,
+				Answer:     log.Answer,
+				ResponseMs: log.ResponseMs,
+				Round:      log.Round,
+				At:         log.CreatedAt,
+			})
+			card.TimeSpentMs += log.ResponseMs
+		}
+	}
+
+	var firstTry, finalPasses int
+	for i := range report.CardReports {
+		card := &report.CardReports[i]
+		if len(card.Attempts) == 0 {
+			continue
+		}
+		report.Cards++
+		report.Attempts += len(card.Attempts)
+		report.TimeSpentMs += card.TimeSpentMs
+
+		card.FirstTryCorrect = card.Attempts[0].Action == string(types.IncrementPass)
+		card.FinalResult = card.Attempts[len(card.Attempts)-1].Action
+		if card.FirstTryCorrect {
+			firstTry++
+		}
+		if card.FinalResult == string(types.IncrementPass) {
+			finalPasses++
+		}
+	}
+
+	if report.Cards > 0 {
+		report.FirstTryAccuracy = float64(firstTry) / float64(report.Cards) * 100
+		report.FinalAccuracy = float64(finalPasses) / float64(report.Cards) * 100
+	}
+
+	return report
+}
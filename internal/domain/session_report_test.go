@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/robstave/meowmorize/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetSessionReport(t *testing.T) {
+	base := time.Date(2024, 3, 2, 12, 0, 0, 0, time.UTC)
+	one, three := 1, 3
+	logs := []types.SessionLog{
+		{DeckID: "deck1", SessionID: "s2", UserID: "dummy", CardID: "card1", Action: string(types.IncrementFail), ResponseMs: 4000, Round: 1, CreatedAt: base},
+		{DeckID: "deck1", SessionID: "s2", UserID: "dummy", CardID: "card2", Action: string(types.IncrementPass), ResponseMs: 2000, Round: 1, CreatedAt: base.Add(time.Minute)},
+		{DeckID: "deck1", SessionID: "s2", UserID: "dummy", CardID: "card2", Action: "SetStars", PreviousStars: &one, Stars: &three, CreatedAt: base.Add(time.Minute)},
+		{DeckID: "deck1", SessionID: "s2", UserID: "dummy", Action: "reshuffle", Round: 2, CreatedAt: base.Add(2 * time.Minute)},
+		{DeckID: "deck1", SessionID: "s2", UserID: "dummy", CardID: "card1", Action: string(types.IncrementPass), ResponseMs: 9000, Round: 2, Undone: true, CreatedAt: base.Add(3 * time.Minute)},
+		{DeckID: "deck1", SessionID: "s2", UserID: "dummy", CardID: "card1", Action: string(types.IncrementPass), ResponseMs: 3000, Round: 2, CreatedAt: base.Add(4 * time.Minute)},
+		{DeckID: "deck1", SessionID: "s2", UserID: "dummy", Action: "complete", Outcome: types.GoalReachedReason, GoalMet: true, Round: 2, CreatedAt: base.Add(4 * time.Minute)},
+	}
+	previousLogs := []types.SessionLog{
+		{DeckID: "deck1", SessionID: "s1", UserID: "dummy", CardID: "card1", Action: string(types.IncrementFail), ResponseMs: 5000, CreatedAt: base.AddDate(0, 0, -1)},
+		{DeckID: "deck1", SessionID: "s1", UserID: "dummy", CardID: "card2", Action: string(types.IncrementFail), ResponseMs: 5000, CreatedAt: base.AddDate(0, 0, -1)},
+	}
+
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	sessionRepo.On("GetSessionLogsBySessionID", "s2").Return(logs, nil)
+	sessionRepo.On("GetSessionLogsBySessionID", "s1").Return(previousLogs, nil)
+	sessionRepo.On("GetSessionLogIdsByUser", "dummy", types.SessionHistoryQuery{DeckID: "deck1", To: base, Limit: 1}).Return([]string{"s1"}, int64(1), nil)
+	cardRepo.On("GetCardByID", "card1").Return(&types.Card{ID: "card1", Front: types.CardFront{Text: "Capital of Norway"}}, nil)
+	cardRepo.On("GetCardByID", "card2").Return(&types.Card{ID: "card2", Front: types.CardFront{Text: "Capital of Peru"}}, nil)
+
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	report, err := s.GetSessionReport("s2", "dummy")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "deck1", report.DeckID)
+	assert.Equal(t, 2, report.Rounds)
+	assert.Equal(t, 2, report.Cards)
+	assert.Equal(t, 3, report.Attempts)
+	assert.Equal(t, 50.0, report.FirstTryAccuracy)
+	assert.Equal(t, 100.0, report.FinalAccuracy)
+	assert.Equal(t, int64(9000), report.TimeSpentMs)
+	assert.True(t, report.Completed)
+	assert.True(t, report.GoalMet)
+	assert.Equal(t, types.GoalReachedReason, report.Outcome)
+
+	assert.Len(t, report.CardReports, 2)
+	card1 := report.CardReports[0]
+	assert.Equal(t, "Capital of Norway", card1.Front)
+	assert.Len(t, card1.Attempts, 2)
+	assert.False(t, card1.FirstTryCorrect)
+	assert.Equal(t, string(types.IncrementPass), card1.FinalResult)
+	assert.Equal(t, int64(7000), card1.TimeSpentMs)
+	card2 := report.CardReports[1]
+	assert.True(t, card2.FirstTryCorrect)
+	assert.Equal(t, &one, card2.StarsBefore)
+	assert.Equal(t, &three, card2.StarsAfter)
+
+	if assert.NotNil(t, report.Previous) {
+		assert.Equal(t, "s1", report.Previous.PreviousSessionID)
+		assert.Equal(t, 50.0, report.Previous.FirstTryAccuracy)
+		assert.Equal(t, 100.0, report.Previous.FinalAccuracy)
+		assert.Equal(t, int64(-1000), report.Previous.TimeSpentMs)
+	}
+}
+
+func TestGetSessionReport_OtherUser(t *testing.T) {
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	sessionRepo.On("GetSessionLogsBySessionID", "s1").Return([]types.SessionLog{
+		{DeckID: "deck1", SessionID: "s1", UserID: "someone", CardID: "card1", Action: string(types.IncrementPass)},
+	}, nil)
+
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	_, err := s.GetSessionReport("s1", "dummy")
+	assert.EqualError(t, err, "session not found")
+	cardRepo.AssertNotCalled(t, "GetCardByID", mock.Anything)
+}
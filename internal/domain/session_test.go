@@ -3,11 +3,12 @@ package domain
 
 import (
 	"errors"
+	"sort"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/robstave/meowmorize/internal/domain/types"
-	"github.com/robstave/meowmorize/internal/logger"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -16,14 +17,16 @@ import (
 func TestStartSession_Success(t *testing.T) {
 	deckID := uuid.New().String()
 	card1 := types.Card{
-		ID:    "card1",
-		Front: types.CardFront{Text: "Q1"},
-		Back:  types.CardBack{Text: "A1"},
+		ID:     "card1",
+		UserID: "meow",
+		Front:  types.CardFront{Text: "Q1"},
+		Back:   types.CardBack{Text: "A1"},
 	}
 	card2 := types.Card{
-		ID:    "card2",
-		Front: types.CardFront{Text: "Q2"},
-		Back:  types.CardBack{Text: "A2"},
+		ID:     "card2",
+		UserID: "meow",
+		Front:  types.CardFront{Text: "Q2"},
+		Back:   types.CardBack{Text: "A2"},
 	}
 	deck := types.Deck{
 		ID:    deckID,
@@ -39,8 +42,8 @@ func TestStartSession_Success(t *testing.T) {
 	deckRepo.On("GetDeckByID", deckID).Return(deck, nil)
 	deckRepo.On("UpdateDeck", mock.AnythingOfType("types.Deck")).Return(nil)
 
-	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
-	err := s.StartSession(deckID, -1, types.RandomMethod, "meow")
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	err := s.StartSession(deckID, -1, types.RandomMethod, "meow", types.SessionOptions{})
 	assert.NoError(t, err)
 
 	stats, err := s.GetSessionStats(deckID)
@@ -60,8 +63,8 @@ func TestStartSession_Failure_GetDeck(t *testing.T) {
 	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
 
 	deckRepo.On("GetDeckByID", deckID).Return(types.Deck{}, errors.New("deck not found"))
-	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
-	err := s.StartSession(deckID, 1, types.RandomMethod, "meow")
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	err := s.StartSession(deckID, 1, types.RandomMethod, "meow", types.SessionOptions{})
 	assert.Error(t, err)
 
 	deckRepo.AssertExpectations(t)
@@ -71,9 +74,10 @@ func TestStartSession_Failure_GetDeck(t *testing.T) {
 func TestStartSession_Failure_UpdateDeck(t *testing.T) {
 	deckID := uuid.New().String()
 	card1 := types.Card{
-		ID:    "card1",
-		Front: types.CardFront{Text: "Q1"},
-		Back:  types.CardBack{Text: "A1"},
+		ID:     "card1",
+		UserID: "meow",
+		Front:  types.CardFront{Text: "Q1"},
+		Back:   types.CardBack{Text: "A1"},
 	}
 	deck := types.Deck{
 		ID:    deckID,
@@ -88,8 +92,8 @@ func TestStartSession_Failure_UpdateDeck(t *testing.T) {
 	deckRepo.On("GetDeckByID", deckID).Return(deck, nil)
 	deckRepo.On("UpdateDeck", mock.AnythingOfType("types.Deck")).Return(errors.New("update failed"))
 
-	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
-	err := s.StartSession(deckID, 1, types.RandomMethod, "meow")
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	err := s.StartSession(deckID, 1, types.RandomMethod, "meow", types.SessionOptions{})
 	assert.Error(t, err)
 
 	deckRepo.AssertExpectations(t)
@@ -100,6 +104,7 @@ func TestAdjustSession_Success(t *testing.T) {
 	deckID := uuid.New().String()
 	card := types.Card{
 		ID:         "card1",
+		UserID:     "meow",
 		Front:      types.CardFront{Text: "Q1"},
 		Back:       types.CardBack{Text: "A1"},
 		StarRating: 3,
@@ -126,10 +131,10 @@ func TestAdjustSession_Success(t *testing.T) {
 	})).Return(nil)
 
 	// Initialize service.
-	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
 
 	// Start session.
-	err := s.StartSession(deckID, 1, types.RandomMethod, "meow")
+	err := s.StartSession(deckID, 1, types.RandomMethod, "meow", types.SessionOptions{})
 	assert.NoError(t, err)
 
 	// Adjust session using IncrementPass action.
@@ -158,9 +163,10 @@ func TestAdjustSession_Success(t *testing.T) {
 func TestAdjustSession_InvalidCard(t *testing.T) {
 	deckID := uuid.New().String()
 	card := types.Card{
-		ID:    "card1",
-		Front: types.CardFront{Text: "Q1"},
-		Back:  types.CardBack{Text: "A1"},
+		ID:     "card1",
+		UserID: "meow",
+		Front:  types.CardFront{Text: "Q1"},
+		Back:   types.CardBack{Text: "A1"},
 	}
 	deck := types.Deck{
 		ID:    deckID,
@@ -175,8 +181,8 @@ func TestAdjustSession_InvalidCard(t *testing.T) {
 	deckRepo.On("GetDeckByID", deckID).Return(deck, nil)
 	deckRepo.On("UpdateDeck", mock.AnythingOfType("types.Deck")).Return(nil)
 
-	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
-	err := s.StartSession(deckID, 1, types.RandomMethod, "meow")
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	err := s.StartSession(deckID, 1, types.RandomMethod, "meow", types.SessionOptions{})
 	assert.NoError(t, err)
 
 	// Do not set up GetCardByID for a non-existent card.
@@ -192,14 +198,16 @@ func TestAdjustSession_InvalidCard(t *testing.T) {
 func TestGetNextCard_Success(t *testing.T) {
 	deckID := uuid.New().String()
 	card1 := types.Card{
-		ID:    "card1",
-		Front: types.CardFront{Text: "Q1"},
-		Back:  types.CardBack{Text: "A1"},
+		ID:     "card1",
+		UserID: "meow",
+		Front:  types.CardFront{Text: "Q1"},
+		Back:   types.CardBack{Text: "A1"},
 	}
 	card2 := types.Card{
-		ID:    "card2",
-		Front: types.CardFront{Text: "Q2"},
-		Back:  types.CardBack{Text: "A2"},
+		ID:     "card2",
+		UserID: "meow",
+		Front:  types.CardFront{Text: "Q2"},
+		Back:   types.CardBack{Text: "A2"},
 	}
 	deck := types.Deck{
 		ID:    deckID,
@@ -213,8 +221,8 @@ func TestGetNextCard_Success(t *testing.T) {
 	deckRepo.On("GetDeckByID", deckID).Return(deck, nil)
 	deckRepo.On("UpdateDeck", mock.AnythingOfType("types.Deck")).Return(nil)
 
-	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
-	err := s.StartSession(deckID, -1, types.RandomMethod, "meow")
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	err := s.StartSession(deckID, -1, types.RandomMethod, "meow", types.SessionOptions{})
 	assert.NoError(t, err)
 
 	nextCardID, err := s.GetNextCard(deckID)
@@ -233,7 +241,7 @@ func TestGetNextCard_NoSession(t *testing.T) {
 
 	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
 
-	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
 	nextCardID, err := s.GetNextCard("non-existent-deck")
 	assert.Error(t, err)
 	assert.Empty(t, nextCardID)
@@ -244,9 +252,10 @@ func TestGetNextCard_NoSession(t *testing.T) {
 func TestClearSession_Success(t *testing.T) {
 	deckID := uuid.New().String()
 	card := types.Card{
-		ID:    "card1",
-		Front: types.CardFront{Text: "Q1"},
-		Back:  types.CardBack{Text: "A1"},
+		ID:     "card1",
+		UserID: "meow",
+		Front:  types.CardFront{Text: "Q1"},
+		Back:   types.CardBack{Text: "A1"},
 	}
 	deck := types.Deck{
 		ID:    deckID,
@@ -260,8 +269,8 @@ func TestClearSession_Success(t *testing.T) {
 	deckRepo.On("GetDeckByID", deckID).Return(deck, nil)
 	deckRepo.On("UpdateDeck", mock.AnythingOfType("types.Deck")).Return(nil)
 
-	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
-	err := s.StartSession(deckID, 1, types.RandomMethod, "meow")
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	err := s.StartSession(deckID, 1, types.RandomMethod, "meow", types.SessionOptions{})
 	assert.NoError(t, err)
 
 	err = s.ClearSession(deckID)
@@ -282,10 +291,128 @@ func TestGetSessionStats_NoSession(t *testing.T) {
 
 	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
 
-	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
 	stats, err := s.GetSessionStats("non-existent-deck")
 	assert.NoError(t, err)
 	assert.Equal(t, 0, stats.TotalCards)
 
 	userRepo.AssertExpectations(t)
 }
+
+func TestGetNextCard_LogsReshuffle(t *testing.T) {
+	deckID := uuid.New().String()
+	deck := types.Deck{ID: deckID, Name: "Test Deck", Cards: []types.Card{{ID: "card1", UserID: "meow"}}}
+
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	deckRepo.On("GetDeckByID", deckID).Return(deck, nil)
+	deckRepo.On("UpdateDeck", mock.AnythingOfType("types.Deck")).Return(nil)
+	sessionRepo.On("CreateLog", mock.MatchedBy(func(log types.SessionLog) bool {
+		return log.Action == "reshuffle" && log.Round == 2 && log.DeckID == deckID && log.UserID == "meow"
+	})).Return(nil).Once()
+
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	assert.NoError(t, s.StartSession(deckID, -1, types.RandomMethod, "meow", types.SessionOptions{}))
+
+	for i := 0; i < 2; i++ {
+		cardID, err := s.GetNextCard(deckID)
+		assert.NoError(t, err)
+		assert.Equal(t, "card1", cardID)
+	}
+
+	stats, err := s.GetSessionStats(deckID)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, stats.Round)
+	sessionRepo.AssertExpectations(t)
+}
+
+func TestGetNextCard_StopPolicyEndsSession(t *testing.T) {
+	deckID := uuid.New().String()
+	deck := types.Deck{ID: deckID, Name: "Test Deck", Cards: []types.Card{{ID: "card1", UserID: "meow"}}}
+
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	deckRepo.On("GetDeckByID", deckID).Return(deck, nil)
+	deckRepo.On("UpdateDeck", mock.AnythingOfType("types.Deck")).Return(nil)
+
+	sessionRepo.On("CreateLog", mock.MatchedBy(func(log types.SessionLog) bool {
+		return log.Action == "complete" && log.Outcome == types.RoundsFinishedReason && !log.GoalMet
+	})).Return(nil).Once()
+
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	assert.NoError(t, s.StartSession(deckID, -1, types.RandomMethod, "meow", types.SessionOptions{RoundPolicy: types.StopPolicy}))
+
+	cardID, err := s.GetNextCard(deckID)
+	assert.NoError(t, err)
+	assert.Equal(t, "card1", cardID)
+
+	cardID, err = s.GetNextCard(deckID)
+	assert.ErrorIs(t, err, types.ErrSessionComplete)
+	assert.Empty(t, cardID)
+	sessionRepo.AssertExpectations(t)
+}
+
+func TestStartSession_InvalidLearningSteps(t *testing.T) {
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	err := s.StartSession("deck1", -1, types.RandomMethod, "meow", types.SessionOptions{LearningSteps: []int{3, 0}})
+	assert.EqualError(t, err, "learning steps must be positive")
+	deckRepo.AssertNotCalled(t, "GetDeckByID", mock.Anything)
+}
+
+func TestCalculateSessionOverview_Completion(t *testing.T) {
+	logs := []types.SessionLog{
+		{CardID: "card1", Action: string(types.IncrementPass)},
+		{CardID: "card2", Action: string(types.IncrementFail)},
+		{CardID: "card2", Action: string(types.IncrementPass), Undone: true},
+		{Action: "complete", Outcome: types.TimeUpReason, GoalMet: true},
+	}
+
+	overview := calculateSessionOverview(logs, "session1", "deck1")
+	assert.Equal(t, 2, overview.Cards)
+	assert.Equal(t, 50.0, overview.PercentageAfter)
+	assert.True(t, overview.Completed)
+	assert.Equal(t, types.TimeUpReason, overview.Outcome)
+	assert.True(t, overview.GoalMet)
+}
+
+func TestSelectCards_HonoursRetired(t *testing.T) {
+	now := time.Now()
+	cards := func() []types.Card {
+		return []types.Card{
+			{ID: "active"},
+			{ID: "resting", Retired: true, DueAt: now.AddDate(0, 0, 30)},
+			{ID: "due", Retired: true, DueAt: now.AddDate(0, 0, -1)},
+		}
+	}
+	ids := func(selected []types.Card) []string {
+		var result []string
+		for _, card := range selected {
+			result = append(result, card.ID)
+		}
+		sort.Strings(result)
+		return result
+	}
+
+	for _, method := range []types.SessionMethod{types.RandomMethod, types.FailsMethod, types.SkipsMethod, types.WorstMethod,
+		types.StarsMethod, types.UnratedMethod, types.AdjustedRandomMethod} {
+		selected, err := selectCards(cards(), -1, method)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"active"}, ids(selected), method)
+	}
+
+	selected, err := selectCards(cards(), 10, types.RetiredMethod)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"due", "resting"}, ids(selected))
+
+	selected, err = selectCards(cards(), -1, types.ResurrectMethod)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"due"}, ids(selected))
+}
@@ -4,6 +4,7 @@ package domain
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/robstave/meowmorize/internal/domain/types"
 )
@@ -42,6 +43,8 @@ func (s *Service) ClearDeckStats(deckID string, clearSession bool, clearStats bo
 				session.CardStats[i].Skipped = false
 			}
 			session.Index = 0
+			session.RoundLength = 0
+			session.History = nil
 			session.Stats = types.SessionStats{
 				TotalCards:   len(session.CardStats),
 				ViewedCount:  0,
@@ -59,10 +62,20 @@ func (s *Service) ClearDeckStats(deckID string, clearSession bool, clearStats bo
 	// Clear card statistics if requested
 	if clearStats {
 		s.logger.Info("clear card statistics---++--------", "deck_id", deckID)
+		s.sessionsMu.Lock()
+		if session, exists := s.sessions[deckID]; exists {
+			// Undoing an earlier review would bring back the cleared counters
+			session.History = nil
+		}
+		s.sessionsMu.Unlock()
 		for _, card := range deck.Cards {
 			card.PassCount = 0
 			card.FailCount = 0
 			card.SkipCount = 0
+			card.SlowPassCount = 0
+			card.HintedPassCount = 0
+			card.IntervalDays = 0
+			card.DueAt = time.Time{}
 
 			if err := s.cardRepo.UpdateCard(card); err != nil {
 				s.logger.Error("Failed to update card stats", "card_id", card.ID, "error", err)
@@ -5,7 +5,6 @@ import (
 	"testing"
 
 	"github.com/robstave/meowmorize/internal/domain/types"
-	"github.com/robstave/meowmorize/internal/logger"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -15,7 +14,7 @@ func TestClearDeckStats_Success(t *testing.T) {
 	llmRepo := setupLLMRepository()
 	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
 
-	deck := types.Deck{ID: "deck1", Cards: []types.Card{{ID: "card1", PassCount: 2, FailCount: 1, SkipCount: 1}}}
+	deck := types.Deck{ID: "deck1", Cards: []types.Card{{ID: "card1", UserID: "meow", PassCount: 2, FailCount: 1, SkipCount: 1}}}
 	deckRepo.On("GetDeckByID", "deck1").Return(deck, nil)
 	deckRepo.On("UpdateDeck", mock.MatchedBy(func(d types.Deck) bool {
 		return d.ID == "deck1"
@@ -24,9 +23,9 @@ func TestClearDeckStats_Success(t *testing.T) {
 		return c.ID == "card1" && c.PassCount == 0 && c.FailCount == 0 && c.SkipCount == 0
 	})).Return(nil)
 
-	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
 
-	err := s.StartSession("deck1", -1, types.RandomMethod, "meow")
+	err := s.StartSession("deck1", -1, types.RandomMethod, "meow", types.SessionOptions{})
 	assert.NoError(t, err)
 
 	err = s.ClearDeckStats("deck1", true, true)
@@ -48,7 +47,7 @@ func TestClearDeckStats_GetDeckError(t *testing.T) {
 
 	deckRepo.On("GetDeckByID", "bad").Return(types.Deck{}, errors.New("not found"))
 
-	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	s := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
 	err := s.ClearDeckStats("bad", true, true)
 	assert.Error(t, err)
 	deckRepo.AssertExpectations(t)
@@ -0,0 +1,197 @@
+// internal/domain/translate.go
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/robstave/meowmorize/internal/domain/types"
+)
+
+// codePattern matches fenced code blocks and inline code, which are kept out of the
+// translation so they come back exactly as written
+var codePattern = regexp.MustCompile("(?s)```.*?```|`[^`\n]+`")
+
+// TranslateDeck clones the deck into a new one with the front and back of every card
+// translated to the language by the LLM. Each translated card links to its source card,
+// so later edits to the source flag it as stale. Nothing is created unless every card
+// translates.
+func (s *Service) TranslateDeck(ctx context.Context, deckID string, username string, language string) (*types.Deck, error) {
+	language = strings.TrimSpace(language)
+	if language == "" {
+		return nil, errors.New("target language is required")
+	}
+	deck, err := s.deckRepo.GetDeckByID(deckID)
+	if err != nil || deck.UserID != username {
+		return nil, errors.New("deck not found")
+	}
+	if !s.IsLLMAvailable() {
+		return nil, types.ErrLLMNotInitialized
+	}
+
+	translated := types.Deck{
+		ID:      uuid.New().String(),
+		Name:    fmt.Sprintf("%s (%s)", deck.Name, language),
+		IconURL: deck.IconURL,
+		UserID:  username,
+	}
+	if translated.Description, err = s.translateText(ctx, username, deck.Description, language, deck.Name); err != nil {
+		return nil, err
+	}
+
+	for _, card := range deck.Cards {
+		clone := types.Card{
+			ID:           uuid.New().String(),
+			UserID:       username,
+			Link:         card.Link,
+			SourceCardID: card.ID,
+			Language:     language,
+		}
+		if err := s.translateCard(ctx, username, card, &clone, deck.Name); err != nil {
+			s.logger.Error("Failed to translate card", "card_id", card.ID, "language", language, "error", err)
+			return nil, err
+		}
+		translated.Cards = append(translated.Cards, clone)
+	}
+
+	if err := s.deckRepo.CreateDeck(translated); err != nil {
+		s.logger.Error("Failed to create translated deck", "deck_id", deckID, "error", err)
+		return nil, err
+	}
+	s.logger.Info("Deck translated", "deck_id", deckID, "translated_deck_id", translated.ID, "language", language, "cards", len(translated.Cards))
+	return &translated, nil
+}
+
+// RetranslateCard translates the card again from its source card and clears its stale
+// flag. The change is recorded as a card revision.
+func (s *Service) RetranslateCard(ctx context.Context, cardID string, username string) (*types.Card, error) {
+	card, err := s.cardRepo.GetCardByID(cardID)
+	if err != nil {
+		s.logger.Error("Failed to retrieve card", "card_id", cardID, "error", err)
+		return nil, err
+	}
+	if card == nil || card.UserID != username {
+		return nil, errors.New("card not found")
+	}
+	if card.SourceCardID == "" {
+		return nil, errors.New("card is not a translation")
+	}
+	source, err := s.cardRepo.GetCardByID(card.SourceCardID)
+	if err != nil {
+		s.logger.Error("Failed to retrieve source card", "card_id", card.SourceCardID, "error", err)
+		return nil, err
+	}
+	if source == nil {
+		return nil, errors.New("source card not found")
+	}
+
+	previous := *card
+	if err := s.translateCard(ctx, username, *source, card, s.cardDeckName(username, source.ID)); err != nil {
+		s.logger.Error("Failed to translate card", "card_id", cardID, "language", card.Language, "error", err)
+		return nil, err
+	}
+	card.TranslationStale = false
+	if err := s.cardRepo.UpdateCard(*card); err != nil {
+		s.logger.Error("Failed to update card", "card_id", cardID, "error", err)
+		return nil, err
+	}
+
+	if previous.Front.Text != card.Front.Text || previous.Back.Text != card.Back.Text {
+		revision := types.CardRevision{
+			ID:        uuid.New().String(),
+			CardID:    card.ID,
+			UserID:    username,
+			Source:    types.RevisionTranslate,
+			SourceID:  source.ID,
+			FrontFrom: previous.Front.Text,
+			FrontTo:   card.Front.Text,
+			BackFrom:  previous.Back.Text,
+			BackTo:    card.Back.Text,
+			CreatedAt: time.Now(),
+		}
+		if err := s.cardRepo.AddRevision(revision); err != nil {
+			s.logger.Error("Failed to record card revision", "card_id", card.ID, "error", err)
+			return nil, err
+		}
+		// Translations are sources too
+		s.markTranslationsStale(card.ID)
+	}
+	s.logger.Info("Card retranslated", "card_id", cardID, "source_card_id", source.ID, "language", card.Language)
+	return card, nil
+}
+
+// translateCard sets the front, back and author hint of the target to the source's,
+// translated to the target's language. Alternate answers are language specific and
+// are left for the author.
+func (s *Service) translateCard(ctx context.Context, username string, source types.Card, target *types.Card, deckName string) error {
+	front, err := s.translateText(ctx, username, source.Front.Text, target.Language, deckName)
+	if err != nil {
+		return err
+	}
+	back, err := s.translateText(ctx, username, source.Back.Text, target.Language, deckName)
+	if err != nil {
+		return err
+	}
+	hint, err := s.translateText(ctx, username, source.Hint, target.Language, deckName)
+	if err != nil {
+		return err
+	}
+	target.Front.Text, target.Back.Text, target.Hint = front, back, hint
+	return nil
+}
+
+// translateText has the LLM translate the markdown text. Code is swapped for
+// placeholders before the text is sent and put back afterwards.
+func (s *Service) translateText(ctx context.Context, username string, text string, language string, deckName string) (string, error) {
+	if strings.TrimSpace(text) == "" {
+		return text, nil
+	}
+
+	var code []string
+	masked := codePattern.ReplaceAllStringFunc(text, func(match string) string {
+		code = append(code, match)
+		return codePlaceholder(len(code) - 1)
+	})
+
+	prompt, err := s.renderPrompt(username, types.LLMPurposeTranslate, types.PromptData{
+		Deck:     deckName,
+		Source:   masked,
+		Language: language,
+	})
+	if err != nil {
+		return "", err
+	}
+	response, err := s.runPrompt(ctx, username, prompt, nil)
+	if err != nil {
+		return "", err
+	}
+
+	translated := strings.TrimSpace(response)
+	if translated == "" {
+		return "", fmt.Errorf("%w: it is empty", types.ErrTranslationFailed)
+	}
+	for i, block := range code {
+		placeholder := codePlaceholder(i)
+		if !strings.Contains(translated, placeholder) {
+			return "", fmt.Errorf("%w: code block %d is missing", types.ErrTranslationFailed, i)
+		}
+		translated = strings.Replace(translated, placeholder, block, 1)
+	}
+	return translated, nil
+}
+
+// markTranslationsStale flags the translations of the card after its front or back changed
+func (s *Service) markTranslationsStale(cardID string) {
+	if err := s.cardRepo.MarkTranslationsStale(cardID); err != nil {
+		s.logger.Error("Failed to flag stale translations", "card_id", cardID, "error", err)
+	}
+}
+
+func codePlaceholder(i int) string {
+	return fmt.Sprintf("[[CODE%d]]", i)
+}
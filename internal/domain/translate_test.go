@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/robstave/meowmorize/internal/adapters/repositories/mocks"
+	"github.com/robstave/meowmorize/internal/domain/types"
+	"github.com/robstave/meowmorize/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// translates has the LLM mock answer prompts whose text is source with the translation
+func translates(llmRepo *mocks.LLMRepository, source string, translation string) {
+	llmRepo.On("RunPrompt", mock.Anything, mock.MatchedBy(func(prompt string) bool {
+		return strings.HasSuffix(prompt, "### Text\n"+source)
+	})).Return(translation, nil)
+}
+
+func TestTranslateDeck(t *testing.T) {
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+
+	deck := types.Deck{ID: "deck1", Name: "Go", UserID: "meow", Cards: []types.Card{
+		{ID: "c1", Front: types.CardFront{Text: "**Loop** keyword?"}, Back: types.CardBack{Text: "Use `for`:\n```go\nfor i := 0; i < 3; i++ {}\n```"}, Alternates: []string{"for"}},
+	}}
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	deckRepo.On("GetDeckByID", "deck1").Return(deck, nil)
+	llmRepo.On("Available").Return(true)
+	translates(llmRepo, "**Loop** keyword?", "¿Palabra clave de **bucle**?")
+	translates(llmRepo, "Use [[CODE0]]:\n[[CODE1]]", "Usa [[CODE0]]:\n[[CODE1]]")
+	deckRepo.On("CreateDeck", mock.MatchedBy(func(d types.Deck) bool {
+		if d.Name != "Go (Spanish)" || d.UserID != "meow" || len(d.Cards) != 1 {
+			return false
+		}
+		c := d.Cards[0]
+		return c.SourceCardID == "c1" && c.Language == "Spanish" && c.ID != "c1" &&
+			c.Front.Text == "¿Palabra clave de **bucle**?" &&
+			c.Back.Text == "Usa `for`:\n```go\nfor i := 0; i < 3; i++ {}\n```" && len(c.Alternates) == 0
+	})).Return(nil)
+
+	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo, nil, nil, nil, nil, nil, nil)
+	translated, err := s.TranslateDeck(context.Background(), "deck1", "meow", " Spanish ")
+	assert.NoError(t, err)
+	assert.Equal(t, "Go (Spanish)", translated.Name)
+	deckRepo.AssertExpectations(t)
+
+	_, err = s.TranslateDeck(context.Background(), "deck1", "someone", "Spanish")
+	assert.EqualError(t, err, "deck not found")
+	_, err = s.TranslateDeck(context.Background(), "deck1", "meow", "")
+	assert.EqualError(t, err, "target language is required")
+}
+
+func TestTranslateDeck_LostCodeBlock(t *testing.T) {
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+
+	deck := types.Deck{ID: "deck1", Name: "Go", UserID: "meow", Cards: []types.Card{
+		{ID: "c1", Front: types.CardFront{Text: "Print?"}, Back: types.CardBack{Text: "`fmt.Println`"}},
+	}}
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	deckRepo.On("GetDeckByID", "deck1").Return(deck, nil)
+	llmRepo.On("Available").Return(true)
+	translates(llmRepo, "Print?", "¿Imprimir?")
+	translates(llmRepo, "[[CODE0]]", "fmt.Println")
+
+	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo, nil, nil, nil, nil, nil, nil)
+	_, err := s.TranslateDeck(context.Background(), "deck1", "meow", "Spanish")
+	assert.ErrorIs(t, err, types.ErrTranslationFailed)
+	deckRepo.AssertNotCalled(t, "CreateDeck", mock.Anything)
+}
+
+func TestRetranslateCard(t *testing.T) {
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+
+	source := &types.Card{ID: "c1", UserID: "meow", Front: types.CardFront{Text: "Cat"}, Back: types.CardBack{Text: "A small feline"}}
+	translation := &types.Card{ID: "es1", UserID: "meow", SourceCardID: "c1", Language: "Spanish", TranslationStale: true,
+		Front: types.CardFront{Text: "Gato"}, Back: types.CardBack{Text: "Un felino"}}
+
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	cardRepo.On("GetCardByID", "c1").Return(source, nil)
+	cardRepo.On("GetCardByID", "es1").Return(translation, nil)
+	deckRepo.On("GetAllDecksByUser", "meow").Return(nil, nil)
+	translates(llmRepo, "Cat", "Gato")
+	translates(llmRepo, "A small feline", "Un felino pequeño")
+	cardRepo.On("UpdateCard", mock.MatchedBy(func(c types.Card) bool {
+		return c.ID == "es1" && !c.TranslationStale && c.Back.Text == "Un felino pequeño"
+	})).Return(nil)
+	cardRepo.On("AddRevision", mock.MatchedBy(func(r types.CardRevision) bool {
+		return r.CardID == "es1" && r.Source == types.RevisionTranslate && r.SourceID == "c1" &&
+			r.BackFrom == "Un felino" && r.BackTo == "Un felino pequeño"
+	})).Return(nil)
+	cardRepo.On("MarkTranslationsStale", "es1").Return(nil)
+
+	s := NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo, nil, nil, nil, nil, nil, nil)
+	card, err := s.RetranslateCard(context.Background(), "es1", "meow")
+	assert.NoError(t, err)
+	assert.False(t, card.TranslationStale)
+	cardRepo.AssertExpectations(t)
+
+	_, err = s.RetranslateCard(context.Background(), "c1", "meow")
+	assert.EqualError(t, err, "card is not a translation")
+}
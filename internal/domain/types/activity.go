@@ -0,0 +1,23 @@
+package types
+
+// ActivityDay holds the reviews of one local day
+type ActivityDay struct {
+	Date    string `json:"date"` // YYYY-MM-DD in the user's timezone
+	Reviews int    `json:"reviews"`
+	Passes  int    `json:"passes"`
+	Fails   int    `json:"fails"`
+	Frozen  bool   `json:"frozen,omitempty"` // A missed day covered by a streak freeze
+}
+
+// ActivityCalendar is the study calendar of a user, ready for a heatmap
+type ActivityCalendar struct {
+	Timezone string `json:"timezone"`
+	// Days lists the active and frozen days of the requested range, oldest first.
+	// Days without reviews are left out.
+	Days          []ActivityDay `json:"days"`
+	CurrentStreak int           `json:"current_streak"` // Active days in the running streak
+	LongestStreak int           `json:"longest_streak"`
+	ActiveToday   bool          `json:"active_today"`
+	StreakFreezes int           `json:"streak_freezes"` // Monthly freeze allowance
+	FreezesLeft   int           `json:"freezes_left"`   // Freezes left this month
+}
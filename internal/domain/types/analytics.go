@@ -0,0 +1,48 @@
+package types
+
+// PassRateBucket counts the reviewed cards whose pass rate falls in [Min, Max)
+type PassRateBucket struct {
+	Min   int `json:"min"` // Percent
+	Max   int `json:"max"` // Percent; the last bucket includes 100
+	Cards int `json:"cards"`
+}
+
+// StarCount is one bar of the star histogram
+type StarCount struct {
+	Stars int `json:"stars"`
+	Cards int `json:"cards"`
+}
+
+// HardCard is a card that is hard to remember, ranked by its combined fail and skip rate
+type HardCard struct {
+	CardID       string  `json:"card_id"`
+	Front        string  `json:"front"`
+	CombinedRate float64 `json:"combined_rate"`
+	PassCount    int     `json:"pass_count"`
+	FailCount    int     `json:"fail_count"`
+	SkipCount    int     `json:"skip_count"`
+}
+
+// TrendPoint holds the review counts of one study day, for the deck sparkline
+type TrendPoint struct {
+	Day      string  `json:"day"` // YYYY-MM-DD in the user's timezone
+	Reviews  int     `json:"reviews"`
+	Passes   int     `json:"passes"`
+	Fails    int     `json:"fails"`
+	Skips    int     `json:"skips"`
+	PassRate float64 `json:"pass_rate"` // Percentage of the reviews that were passes
+}
+
+// DeckAnalytics is the health of a deck
+type DeckAnalytics struct {
+	DeckID        string           `json:"deck_id"`
+	DeckName      string           `json:"deck_name"`
+	Cards         int              `json:"cards"`
+	NeverReviewed int              `json:"never_reviewed"`
+	Retired       int              `json:"retired"`
+	PassRate      float64          `json:"pass_rate"` // Over all reviews of the active cards
+	PassRates     []PassRateBucket `json:"pass_rate_distribution"`
+	Stars         []StarCount      `json:"star_histogram"`
+	HardestCards  []HardCard       `json:"hardest_cards"`
+	Trend         []TrendPoint     `json:"trend"` // Recent study days, oldest first
+}
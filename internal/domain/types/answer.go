@@ -0,0 +1,43 @@
+// internal/domain/types/answer.go
+package types
+
+// Strictness controls how forgiving the typed-answer grader is
+type Strictness string
+
+const (
+	// ExactStrictness only ignores case, surrounding whitespace and punctuation
+	ExactStrictness Strictness = "Exact"
+	// StrictStrictness allows roughly one typo per ten characters
+	StrictStrictness Strictness = "Strict"
+	// NormalStrictness allows roughly one typo per five characters and ignores accents
+	NormalStrictness Strictness = "Normal"
+	// LenientStrictness allows roughly one typo per three characters and ignores accents
+	LenientStrictness Strictness = "Lenient"
+)
+
+// Verdict is the LLM's judgement of a free-text answer
+type Verdict string
+
+const (
+	PassVerdict    Verdict = "pass"
+	PartialVerdict Verdict = "partial"
+	FailVerdict    Verdict = "fail"
+)
+
+// AnswerGrade is the result of grading a typed answer against a card
+type AnswerGrade struct {
+	CardID     string     `json:"card_id"`
+	Answer     string     `json:"answer"`
+	Expected   string     `json:"expected"`
+	MatchedAlt bool       `json:"matched_alternate"`
+	Distance   int        `json:"distance"`
+	Similarity float64    `json:"similarity"`
+	Correct    bool       `json:"correct"`
+	Action     CardAction `json:"action"`
+
+	// Verdict and Feedback are set when the LLM graded the answer. SelfGrade is set when
+	// it could not; nothing was recorded and the user grades the card instead.
+	Verdict   Verdict `json:"verdict,omitempty"`
+	Feedback  string  `json:"feedback,omitempty"`
+	SelfGrade bool    `json:"self_grade,omitempty"`
+}
@@ -0,0 +1,54 @@
+// internal/domain/types/attachment.go
+package types
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrUnsupportedAttachment = errors.New("unsupported attachment type")
+var ErrAttachmentTooLarge = errors.New("attachment is too large")
+
+// Attachment kinds
+const (
+	ImageAttachment = "image"
+	AudioAttachment = "audio"
+)
+
+// AttachmentURLPrefix is how cards and decks reference an attachment.
+// For example: ![diagram](/api/attachments/<sha256>)
+const AttachmentURLPrefix = "/api/attachments/"
+
+// Attachment is an uploaded image or audio file. The ID is the SHA-256 of the
+// content, so identical uploads share a single stored file.
+type Attachment struct {
+	ID          string    `gorm:"primaryKey;type:varchar(64)" json:"id"`
+	UserID      string    `gorm:"index;not null" json:"user_id"`
+	Filename    string    `gorm:"type:varchar(255)" json:"filename"`
+	ContentType string    `gorm:"type:varchar(100);not null" json:"content_type"`
+	Kind        string    `gorm:"type:varchar(20);not null" json:"kind"`
+	Size        int64     `json:"size"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// AttachmentOwner records a user who uploaded the attachment. Identical uploads
+// share one Attachment, so each uploader gets their own owner row.
+type AttachmentOwner struct {
+	AttachmentID string    `gorm:"primaryKey;type:varchar(64)" json:"attachment_id"`
+	UserID       string    `gorm:"primaryKey" json:"user_id"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// URL returns the path used to reference the attachment from card text
+func (a Attachment) URL() string {
+	return AttachmentURLPrefix + a.ID
+}
+
+// AttachmentData is an attachment bundled with its content, used when
+// exporting and importing decks
+type AttachmentData struct {
+	ID          string `json:"id"`
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Data        []byte `json:"data"` // base64 in JSON
+}
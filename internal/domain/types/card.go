@@ -3,19 +3,36 @@ package types
 import "time"
 
 type Card struct {
-	ID         string    `gorm:"primaryKey" json:"id"`
-	Front      CardFront `gorm:"embedded;embeddedPrefix:front_" json:"front"`
-	Back       CardBack  `gorm:"embedded;embeddedPrefix:back_" json:"back"`
-	UserID     string    `gorm:"type:text" json:"user_id"`
-	Link       string    `gorm:"type:text" json:"link"`
-	PassCount  int       `gorm:"default:0" json:"pass_count"`
-	FailCount  int       `gorm:"default:0" json:"fail_count"`
-	SkipCount  int       `gorm:"default:0" json:"skip_count"`
-	StarRating int       `gorm:"default:0" json:"star_rating"`
-	Retired    bool      `gorm:"default:false" json:"retired"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
-	ReviewedAt time.Time `json:"reviewed_at"`
+	ID               string    `gorm:"primaryKey" json:"id"`
+	Front            CardFront `gorm:"embedded;embeddedPrefix:front_" json:"front"`
+	Back             CardBack  `gorm:"embedded;embeddedPrefix:back_" json:"back"`
+	UserID           string    `gorm:"type:text" json:"user_id"`
+	Link             string    `gorm:"type:text" json:"link"`
+	Alternates       []string  `gorm:"type:text;serializer:json" json:"alternates"` // Accepted alternate answers for typed sessions
+	PassCount        int       `gorm:"default:0" json:"pass_count"`
+	FailCount        int       `gorm:"default:0" json:"fail_count"`
+	SkipCount        int       `gorm:"default:0" json:"skip_count"`
+	SlowPassCount    int       `gorm:"default:0" json:"slow_pass_count"`    // Passes slower than the slow answer threshold
+	HintedPassCount  int       `gorm:"default:0" json:"hinted_pass_count"`  // Passes after asking for a hint
+	MedianResponseMs int64     `gorm:"default:0" json:"median_response_ms"` // Median answer time over recent reviews
+	StarRating       int       `gorm:"default:0" json:"star_rating"`
+	Retired          bool      `gorm:"default:false" json:"retired"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+	ReviewedAt       time.Time `json:"reviewed_at"`
+	IntervalDays     int       `gorm:"default:0" json:"interval_days"` // Days until the next review after a pass
+	DueAt            time.Time `gorm:"index" json:"due_at"`            // Next review; zero until the card is first reviewed
+	Leech            bool      `gorm:"default:false" json:"leech"`     // Failed too often, see User.LeechThreshold
+	LapsesSince      time.Time `json:"lapses_since"`                   // Lapses are counted from the session logs after this time
+
+	Notes string `gorm:"type:text" json:"notes"` // Extra notes, e.g. explanations promoted from the card's thread
+	Hint  string `gorm:"type:text" json:"hint"`  // Author-written hint; without one, hints are generated by the LLM
+
+	// A translated card links to the card it was translated from. TranslationStale is
+	// set when the source's front, back or hint changes afterwards.
+	SourceCardID     string `gorm:"size:36;index" json:"source_card_id,omitempty"`
+	Language         string `gorm:"size:50" json:"language,omitempty"`
+	TranslationStale bool   `gorm:"default:false" json:"translation_stale"`
 }
 
 type CardFront struct {
@@ -37,4 +54,5 @@ const (
 	Retire        CardAction = "Retire"
 	Unretire      CardAction = "Unretire"
 	ResetStats    CardAction = "ResetStats"
+	ClearLeech    CardAction = "ClearLeech"
 )
@@ -12,4 +12,7 @@ type Deck struct {
 	UserID       string    `gorm:"not null" json:"user_id"`            // NEW: owner of the deck
 	Cards        []Card    `gorm:"many2many:deck_cards;" json:"cards"` // Updated to many-to-many
 	LastAccessed time.Time `gorm:"autoUpdateTime" json:"last_accessed"`
+
+	// Attachments carries referenced media in exported decks; it is not stored with the deck
+	Attachments []AttachmentData `gorm:"-" json:"attachments,omitempty"`
 }
@@ -0,0 +1,36 @@
+package types
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrSourceTooLarge = errors.New("source text is too large")
+var ErrNoCardsGenerated = errors.New("no cards in the LLM response")
+
+// CardDraft is a card generated by the LLM. It waits in the review queue until it is
+// approved, which creates the card in the deck, or rejected.
+type CardDraft struct {
+	ID        string    `gorm:"primaryKey" json:"id"`
+	UserID    string    `gorm:"index;not null" json:"user_id"`
+	DeckID    string    `gorm:"index;not null" json:"deck_id"`
+	Front     string    `gorm:"type:text;not null" json:"front"`
+	Back      string    `gorm:"type:text;not null" json:"back"`
+	Link      string    `gorm:"type:text" json:"link"`
+	Source    string    `gorm:"type:text" json:"source"` // Name of the uploaded file the card was generated from
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// GenerateCardsRequest asks the LLM for cards on the source text
+type GenerateCardsRequest struct {
+	DeckID string
+	Count  int
+	Text   string
+	Source string
+}
+
+// CardDraftEdit changes a draft as it is approved. Fields left nil are kept.
+type CardDraftEdit struct {
+	Front *string `json:"front"`
+	Back  *string `json:"back"`
+}
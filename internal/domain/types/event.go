@@ -0,0 +1,27 @@
+package types
+
+import "time"
+
+// SessionEventType identifies what happened in a session
+type SessionEventType string
+
+const (
+	// SnapshotEvent carries the current state when a subscriber connects
+	SnapshotEvent        SessionEventType = "snapshot"
+	CardServedEvent      SessionEventType = "card_served"
+	CardGradedEvent      SessionEventType = "card_graded"
+	ReshuffleEvent       SessionEventType = "reshuffle"
+	ReviewsUndoneEvent   SessionEventType = "reviews_undone"
+	SessionCompleteEvent SessionEventType = "session_complete"
+)
+
+// SessionEvent is published whenever a session changes, with the updated stats
+type SessionEvent struct {
+	Type      SessionEventType `json:"type"`
+	DeckID    string           `json:"deck_id"`
+	SessionID string           `json:"session_id"`
+	CardID    string           `json:"card_id,omitempty"`
+	Action    CardAction       `json:"action,omitempty"`
+	Stats     SessionStats     `json:"stats"`
+	Timestamp time.Time        `json:"timestamp"`
+}
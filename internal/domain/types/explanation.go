@@ -0,0 +1,35 @@
+package types
+
+import "time"
+
+// ExplanationTurn is one question about a card and the LLM's answer. The turns of a
+// user on a card make up the card's explanation thread.
+type ExplanationTurn struct {
+	ID        string    `gorm:"primaryKey" json:"id"`
+	CardID    string    `gorm:"index:idx_explanation_thread;not null" json:"card_id"`
+	UserID    string    `gorm:"index:idx_explanation_thread;not null" json:"user_id"`
+	Question  string    `gorm:"type:text;not null" json:"question"`
+	Answer    string    `gorm:"type:text;not null" json:"answer"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ExplanationThread is the conversation of a user about a card, oldest turn first
+type ExplanationThread struct {
+	CardID string            `json:"card_id"`
+	Turns  []ExplanationTurn `json:"turns"`
+}
+
+// ExplanationThreadSummary lists a thread without its turns
+type ExplanationThreadSummary struct {
+	CardID       string    `json:"card_id"`
+	Front        string    `json:"front"`
+	Turns        int       `json:"turns"`
+	LastQuestion string    `json:"last_question"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// Where a promoted explanation goes
+const (
+	PromoteToBack  = "back"
+	PromoteToNotes = "notes"
+)
@@ -0,0 +1,29 @@
+package types
+
+// ForecastDay is the number of reviews due on one local day
+type ForecastDay struct {
+	Date string `json:"date"` // YYYY-MM-DD in the user's timezone
+	Due  int    `json:"due"`
+}
+
+// DeckForecast is the upcoming workload and estimated retention of a deck
+type DeckForecast struct {
+	DeckID   string        `json:"deck_id"`
+	DeckName string        `json:"deck_name"`
+	Days     []ForecastDay `json:"days"`
+	Overdue  int           `json:"overdue"`   // Due before today; also counted in the first day
+	NewCards int           `json:"new_cards"` // Never reviewed, so not scheduled yet
+	// Retention is the share of passes among the recent passes and fails, nil without any
+	Retention    *float64 `json:"retention,omitempty"`
+	RecentPasses int      `json:"recent_passes"`
+	RecentFails  int      `json:"recent_fails"`
+}
+
+// Forecast is the review workload of a user over the coming days
+type Forecast struct {
+	Timezone      string         `json:"timezone"`
+	RetentionDays int            `json:"retention_days"` // Window the retention estimates are based on
+	Total         []ForecastDay  `json:"total"`
+	Decks         []DeckForecast `json:"decks"`
+	Retention     *float64       `json:"retention,omitempty"` // Across all decks
+}
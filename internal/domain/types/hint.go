@@ -0,0 +1,33 @@
+package types
+
+import "time"
+
+// MaxHintLevel is how many generated hints a card has, each more specific than the last
+const MaxHintLevel = 3
+
+// Hint sources
+const (
+	HintAuthor = "author" // Written on the card, see Card.Hint
+	HintLLM    = "llm"
+)
+
+// CardHint is a generated hint, cached so each level is only generated once. The
+// fingerprint is taken from the card's front and back, so editing the card retires
+// its hints.
+type CardHint struct {
+	ID          string    `gorm:"primaryKey;size:36" json:"id"`
+	CardID      string    `gorm:"size:36;index:idx_card_hint" json:"card_id"`
+	Fingerprint string    `gorm:"size:64;index:idx_card_hint" json:"-"`
+	Level       int       `json:"level"`
+	Text        string    `gorm:"type:text" json:"text"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Hint is a hint for a card as served to the user
+type Hint struct {
+	CardID   string `json:"card_id"`
+	Level    int    `json:"level"`
+	MaxLevel int    `json:"max_level"` // Highest level the card has
+	Text     string `json:"text"`
+	Source   string `json:"source"` // HintAuthor or HintLLM
+}
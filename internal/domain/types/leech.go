@@ -0,0 +1,26 @@
+package types
+
+// LeechDeck is a deck a leech belongs to
+type LeechDeck struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// ExplainShortcut describes the request that asks the LLM to explain a card
+type ExplainShortcut struct {
+	Available bool   `json:"available"` // Whether the LLM is configured
+	Method    string `json:"method"`
+	Path      string `json:"path"`
+	Prompt    string `json:"prompt"` // Suggested prompt to send along
+}
+
+// Leech is a card that keeps failing
+type Leech struct {
+	CardID  string          `json:"card_id"`
+	Front   string          `json:"front"`
+	Back    string          `json:"back"`
+	Lapses  int             `json:"lapses"`
+	Retired bool            `json:"retired"`
+	Decks   []LeechDeck     `json:"decks"`
+	Explain ExplainShortcut `json:"explain"`
+}
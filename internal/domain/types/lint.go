@@ -0,0 +1,88 @@
+// internal/domain/types/lint.go
+package types
+
+import "time"
+
+// Lint job statuses
+const (
+	LintRunning   = "running"
+	LintDone      = "done"
+	LintFailed    = "failed"
+	LintCancelled = "cancelled"
+)
+
+// Lint finding statuses
+const (
+	FindingPending   = "pending"
+	FindingAccepted  = "accepted"
+	FindingDismissed = "dismissed"
+)
+
+// Lint issue categories
+const (
+	LintAmbiguity     = "ambiguity"      // The front has more than one reasonable answer or is unclear
+	LintMultipleFacts = "multiple_facts" // The card should be split
+	LintFactual       = "factual"        // The back looks wrong or doubtful
+	LintFormatting    = "formatting"     // Broken markdown, overlong text, answer on the front
+)
+
+// LintJob tracks the background linting of a deck
+type LintJob struct {
+	ID         string    `gorm:"primaryKey;size:36" json:"id"`
+	DeckID     string    `gorm:"size:36;index" json:"deck_id"`
+	UserID     string    `gorm:"size:100" json:"user_id"`
+	Status     string    `gorm:"size:20" json:"status"`
+	Total      int       `json:"total"`     // Cards to lint
+	Processed  int       `json:"processed"` // Cards linted so far, including those that failed
+	Failed     int       `json:"failed"`    // Cards the LLM gave no usable answer for
+	Findings   int       `json:"findings"`  // Cards with issues
+	Error      string    `gorm:"type:text" json:"error,omitempty"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"` // Zero while running
+}
+
+// LintIssue is a problem the LLM found with a card
+type LintIssue struct {
+	Category string `json:"category"`
+	Message  string `json:"message"`
+}
+
+// LintFinding holds the issues found with a card and the suggested rewrite
+type LintFinding struct {
+	ID             string      `gorm:"primaryKey;size:36" json:"id"`
+	JobID          string      `gorm:"size:36;index" json:"job_id"`
+	DeckID         string      `gorm:"size:36;index" json:"deck_id"`
+	CardID         string      `gorm:"size:36" json:"card_id"`
+	UserID         string      `gorm:"size:100" json:"user_id"`
+	Issues         []LintIssue `gorm:"type:text;serializer:json" json:"issues"`
+	SuggestedFront string      `gorm:"type:text" json:"suggested_front"` // Empty keeps the front
+	SuggestedBack  string      `gorm:"type:text" json:"suggested_back"`  // Empty keeps the back
+	Status         string      `gorm:"size:20;default:pending" json:"status"`
+	CreatedAt      time.Time   `json:"created_at"`
+}
+
+// LintFindingEdit changes the suggested rewrite before it is accepted; nil fields keep the suggestion
+type LintFindingEdit struct {
+	Front *string `json:"front,omitempty"`
+	Back  *string `json:"back,omitempty"`
+}
+
+// CardRevision records a change to the text of a card
+type CardRevision struct {
+	ID        string    `gorm:"primaryKey;size:36" json:"id"`
+	CardID    string    `gorm:"size:36;index" json:"card_id"`
+	UserID    string    `gorm:"size:100" json:"user_id"`
+	Source    string    `gorm:"size:20" json:"source"`    // What made the change, e.g. RevisionLint
+	SourceID  string    `gorm:"size:36" json:"source_id"` // E.g. the lint finding
+	FrontFrom string    `gorm:"type:text" json:"front_from"`
+	FrontTo   string    `gorm:"type:text" json:"front_to"`
+	BackFrom  string    `gorm:"type:text" json:"back_from"`
+	BackTo    string    `gorm:"type:text" json:"back_to"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Revision sources
+const (
+	RevisionLint      = "lint"      // An accepted lint finding
+	RevisionTranslate = "translate" // A translation redone from its source card
+)
@@ -2,6 +2,68 @@ package types
 
 import (
 	"errors"
+	"time"
 )
 
 var ErrLLMNotInitialized = errors.New("LLM service not initialized")
+
+// LLMStatus reports whether the LLM can be used and which provider and model answer
+type LLMStatus struct {
+	Available bool   `json:"available"`
+	Provider  string `json:"provider"`
+	Model     string `json:"model"`
+}
+
+// ErrLLMQuotaExceeded is returned once a user has used up their daily LLM requests
+var ErrLLMQuotaExceeded = errors.New("daily LLM quota reached")
+
+// What an LLM request was made for
+const (
+	LLMPurposeExplain   = "explain"
+	LLMPurposeHint      = "hint"
+	LLMPurposeGenerate  = "generate"
+	LLMPurposeGrade     = "grade"
+	LLMPurposeLint      = "lint"
+	LLMPurposeTranslate = "translate"
+)
+
+// LLMUsage is an entry of the usage ledger: one prompt of a user, sent to the LLM or
+// answered from the cache. Tokens are estimated from the characters.
+type LLMUsage struct {
+	ID              string    `gorm:"primaryKey;size:36" json:"id"`
+	UserID          string    `gorm:"size:100;index:idx_llm_usage_user_day" json:"user_id"`
+	Day             string    `gorm:"size:10;index:idx_llm_usage_user_day" json:"day"` // User's local day, YYYY-MM-DD
+	Purpose         string    `gorm:"size:20" json:"purpose"`
+	Provider        string    `gorm:"size:20" json:"provider"`
+	Model           string    `gorm:"size:100" json:"model"`
+	PromptChars     int       `json:"prompt_chars"`
+	ResponseChars   int       `json:"response_chars"`
+	PromptTokens    int       `json:"prompt_tokens"`
+	ResponseTokens  int       `json:"response_tokens"`
+	LatencyMs       int64     `json:"latency_ms"`
+	TemplateID      string    `gorm:"size:36" json:"template_id"` // Empty for a built-in template
+	TemplateVersion int       `json:"template_version"`
+	Cached          bool      `json:"cached"` // Answered from the cache, does not count against the quota
+	Failed          bool      `json:"failed"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// LLMCacheEntry is a response kept for a prompt, keyed by the hash of provider, model and prompt
+type LLMCacheEntry struct {
+	Hash      string    `gorm:"primaryKey;size:64" json:"hash"`
+	Provider  string    `gorm:"size:20" json:"provider"`
+	Model     string    `gorm:"size:100" json:"model"`
+	Response  string    `gorm:"type:text" json:"response"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// LLMUsageSummary is the user's LLM usage of their current local day
+type LLMUsageSummary struct {
+	Day            string `json:"day"`
+	Quota          int    `json:"quota"`     // Requests per day, 0 is unlimited
+	Requests       int    `json:"requests"`  // Requests sent to the LLM, counted against the quota
+	Remaining      int    `json:"remaining"` // -1 when unlimited
+	CachedRequests int    `json:"cached_requests"`
+	PromptTokens   int    `json:"prompt_tokens"`
+	ResponseTokens int    `json:"response_tokens"`
+}
@@ -0,0 +1,47 @@
+// internal/domain/types/prompt_template.go
+package types
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrInvalidTemplate is returned for a prompt template that does not parse or execute
+var ErrInvalidTemplate = errors.New("invalid template")
+
+// PromptKinds are the prompts that can be templated, see the LLMPurpose constants
+var PromptKinds = []string{LLMPurposeExplain, LLMPurposeHint, LLMPurposeGenerate, LLMPurposeGrade, LLMPurposeLint, LLMPurposeTranslate}
+
+// PromptTemplate is a version of a prompt template. Saving a template adds a version,
+// so the ledger can tell which one a request used.
+type PromptTemplate struct {
+	ID        string    `gorm:"primaryKey;size:36" json:"id"`
+	UserID    string    `gorm:"size:100;uniqueIndex:idx_prompt_template_version" json:"user_id"` // Empty for the admin default
+	Kind      string    `gorm:"size:20;uniqueIndex:idx_prompt_template_version" json:"kind"`
+	Version   int       `gorm:"uniqueIndex:idx_prompt_template_version" json:"version"`
+	Body      string    `gorm:"type:text" json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+	// ResetAt is set when the template was reset. The version is kept so the LLM usage
+	// that refers to it stays meaningful, but it is no longer used.
+	ResetAt *time.Time `gorm:"index" json:"reset_at,omitempty"`
+
+	BuiltIn bool `gorm:"-" json:"built_in"` // Shipped with the app and never stored, version 0
+}
+
+// PromptData holds the variables available to prompt templates. Which are set depends on
+// the kind of prompt.
+type PromptData struct {
+	Front      string            // Card front
+	Back       string            // Card back
+	Deck       string            // Deck name, empty when unknown
+	Question   string            // The user's question (explain)
+	Turns      []ExplanationTurn // Earlier turns of the thread (explain)
+	Answer     string            // The student's answer (grade)
+	Alternates []string          // Accepted alternate answers (grade)
+	Count      int               // Number of cards to write (generate)
+	Source     string            // Source text (generate, translate)
+	Language   string            // Target language (translate)
+	CardFormat string            // Guide to the card markdown format (generate)
+	Level      int               // Hint level to write, from 1 (hint)
+	Hints      []string          // Hints already given, least specific first (hint)
+}
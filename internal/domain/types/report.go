@@ -0,0 +1,70 @@
+package types
+
+import "time"
+
+// SessionHistoryQuery selects a page of a user's sessions, newest first
+type SessionHistoryQuery struct {
+	DeckID string    // Optional deck filter
+	From   time.Time // Only sessions with activity at or after this time, if set
+	To     time.Time // Only sessions with activity before this time, if set
+	Offset int
+	Limit  int
+}
+
+// CardAttempt is one review of a card within a session
+type CardAttempt struct {
+	Action     string    `json:"action"`
+	Answer     string    `json:"answer,omitempty"`
+	ResponseMs int64     `json:"response_ms,omitempty"`
+	Round      int       `json:"round,omitempty"`
+	At         time.Time `json:"at"`
+}
+
+// CardReport is the per-card breakdown of a session
+type CardReport struct {
+	CardID          string        `json:"card_id"`
+	Front           string        `json:"front"`
+	Attempts        []CardAttempt `json:"attempts"`
+	FirstTryCorrect bool          `json:"first_try_correct"`
+	FinalResult     string        `json:"final_result"`
+	TimeSpentMs     int64         `json:"time_spent_ms"`
+	StarsBefore     *int          `json:"stars_before,omitempty"` // Set when the stars were changed during the session
+	StarsAfter      *int          `json:"stars_after,omitempty"`
+}
+
+// SessionSummary holds the deck-level numbers of a session
+type SessionSummary struct {
+	Cards            int     `json:"cards"`
+	Attempts         int     `json:"attempts"`
+	FirstTryAccuracy float64 `json:"first_try_accuracy"` // Percentage of cards passed on the first try
+	FinalAccuracy    float64 `json:"final_accuracy"`     // Percentage of cards whose last attempt was a pass
+	TimeSpentMs      int64   `json:"time_spent_ms"`      // Sum of the answer times
+}
+
+// SessionDelta compares a session with the previous session of the same deck
+type SessionDelta struct {
+	PreviousSessionID string  `json:"previous_session_id"`
+	Cards             int     `json:"cards"`
+	FirstTryAccuracy  float64 `json:"first_try_accuracy"` // Percentage points
+	FinalAccuracy     float64 `json:"final_accuracy"`     // Percentage points
+	TimeSpentMs       int64   `json:"time_spent_ms"`
+}
+
+// SessionReport is the full breakdown of a session
+type SessionReport struct {
+	SessionID string    `json:"session_id"`
+	DeckID    string    `json:"deck_id"`
+	UserID    string    `json:"user_id"`
+	StartedAt time.Time `json:"started_at"`
+	EndedAt   time.Time `json:"ended_at"`
+	Rounds    int       `json:"rounds"`
+
+	SessionSummary
+
+	Completed bool           `json:"completed"`
+	Outcome   CompleteReason `json:"outcome,omitempty"`
+	GoalMet   bool           `json:"goal_met"`
+
+	CardReports []CardReport  `json:"cards_detail"`
+	Previous    *SessionDelta `json:"previous,omitempty"` // Nil for the first session of the deck
+}
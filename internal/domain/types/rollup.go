@@ -0,0 +1,27 @@
+package types
+
+import "time"
+
+// DailyRollup sums up a user's reviews of a deck on one local day. Rollups are kept up
+// to date as reviews are logged and undone, and outlive the raw session logs.
+type DailyRollup struct {
+	UserID       string    `gorm:"primaryKey;size:100" json:"user_id"`
+	DeckID       string    `gorm:"primaryKey;size:36" json:"deck_id"`
+	Day          string    `gorm:"primaryKey;size:10" json:"day"` // YYYY-MM-DD in the user's timezone
+	Reviews      int       `gorm:"default:0" json:"reviews"`
+	Passes       int       `gorm:"default:0" json:"passes"`
+	Fails        int       `gorm:"default:0" json:"fails"`
+	Skips        int       `gorm:"default:0" json:"skips"`
+	TimedReviews int       `gorm:"default:0" json:"timed_reviews"` // Reviews with a known answer time
+	ResponseMs   int64     `gorm:"default:0" json:"response_ms"`   // Sum of the known answer times
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// UserSettings holds the user preferences that can be changed; nil fields are left as they are
+type UserSettings struct {
+	Timezone         *string `json:"timezone,omitempty"`
+	StreakFreezes    *int    `json:"streak_freezes,omitempty"`
+	LogRetentionDays *int    `json:"log_retention_days,omitempty"` // 0 keeps the raw session logs forever
+	LeechThreshold   *int    `json:"leech_threshold,omitempty"`
+	LeechAction      *string `json:"leech_action,omitempty"`
+}
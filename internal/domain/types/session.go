@@ -2,6 +2,9 @@
 package types
 
 import (
+	"errors"
+	"math/rand"
+	"sort"
 	"sync"
 	"time"
 )
@@ -17,19 +20,94 @@ const (
 	StarsMethod          SessionMethod = "Stars"
 	UnratedMethod        SessionMethod = "Unrated"
 	AdjustedRandomMethod SessionMethod = "AdjustedRandom"
+	// RetiredMethod reviews the retired cards, which every other method leaves out
+	RetiredMethod SessionMethod = "Retired"
+	// ResurrectMethod reviews the retired cards whose resurrect review is due
+	ResurrectMethod SessionMethod = "Resurrect"
 )
 
+// SessionMode represents how answers are graded during a session
+type SessionMode string
+
+const (
+	// SelfGradedMode lets the user mark each card as pass, fail or skip
+	SelfGradedMode SessionMode = "SelfGraded"
+	// TypedMode has the client submit the typed answer and the domain grades it
+	TypedMode SessionMode = "Typed"
+	// LLMGradedMode has the client submit the typed answer and the LLM grades it against the card
+	LLMGradedMode SessionMode = "LLMGraded"
+)
+
+// RoundPolicy decides what happens once every card of a round has been served
+type RoundPolicy string
+
+const (
+	// ResortPolicy starts a new round with skipped cards first, then failed cards, then the rest
+	ResortPolicy RoundPolicy = "Resort"
+	// OnlyMissedPolicy starts a new round with only the cards not passed yet
+	OnlyMissedPolicy RoundPolicy = "OnlyMissed"
+	// ShufflePolicy starts a new round with all cards in random order
+	ShufflePolicy RoundPolicy = "Shuffle"
+	// StopPolicy ends the session after the first round
+	StopPolicy RoundPolicy = "Stop"
+)
+
+// SessionOptions holds the optional settings for starting a session
+type SessionOptions struct {
+	Mode        SessionMode `json:"mode"`
+	Strictness  Strictness  `json:"strictness"`
+	RoundPolicy RoundPolicy `json:"round_policy"`
+	// LearningSteps re-shows a failed card after that many other cards, one step per
+	// pass, e.g. [3, 10]. Learning is off when empty.
+	LearningSteps []int `json:"learning_steps"`
+	// GraduateAfter is how many consecutive passes end learning for a card
+	GraduateAfter int `json:"graduate_after"`
+	// TimeLimit ends the session once it has run that long, 0 means no limit
+	TimeLimit time.Duration `json:"time_limit"`
+	// GoalCorrect ends the session after that many passes, 0 means no goal
+	GoalCorrect int `json:"goal_correct"`
+}
+
+// CompleteReason tells why a session completed
+type CompleteReason string
+
+const (
+	GoalReachedReason    CompleteReason = "goal_reached"
+	TimeUpReason         CompleteReason = "time_up"
+	RoundsFinishedReason CompleteReason = "rounds_finished"
+)
+
+// ErrSessionComplete is returned for the next card once the session is complete
+var ErrSessionComplete = errors.New("session complete")
+
 // SessionLog represents a log entry for a session action.
 type SessionLog struct {
 	ID     string `gorm:"primaryKey" json:"id"`
 	DeckID string `gorm:"index;not null" json:"deck_id"`
-	CardID string `json:"card_id"` // Can be empty for reshuffle
+	CardID string `json:"card_id"` // Can be empty for reshuffle and complete
 
 	SessionID string `gorm:"index;not null" json:"session_id"`
 	UserID    string `gorm:"not null" json:"user_id"`
-	// Action can be one of: "pass", "fail", "skip", "reshuffle"
-	Action    string    `gorm:"type:varchar(50);not null" json:"action"`
-	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	// Action can be one of: "pass", "fail", "skip", "reshuffle", "complete", "SetStars".
+	// Reviews are stored as the card action, e.g. "IncrementPass".
+	Action string `gorm:"type:varchar(50);not null" json:"action"`
+	Answer string `gorm:"type:text" json:"answer,omitempty"` // Typed answer, if any
+	// ServedAt is when GetNextCard handed out the card; ResponseMs is the time
+	// from then until the answer. Both are empty when the timing is unknown.
+	ServedAt   *time.Time `json:"served_at,omitempty"`
+	ResponseMs int64      `gorm:"default:0" json:"response_ms,omitempty"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	Day        string     `gorm:"size:10" json:"day,omitempty"`     // Local day of the user, used for the daily rollups
+	Undone     bool       `gorm:"default:false" json:"undone"`      // Set when the review was undone
+	Round      int        `gorm:"default:0" json:"round,omitempty"` // Session round the entry belongs to
+	// Outcome and GoalMet are set on the "complete" entry written when the session completes
+	Outcome CompleteReason `gorm:"type:varchar(50)" json:"outcome,omitempty"`
+	GoalMet bool           `gorm:"default:false" json:"goal_met,omitempty"`
+	// Stars and PreviousStars are set on SetStars entries
+	Stars         *int `json:"stars,omitempty"`
+	PreviousStars *int `json:"previous_stars,omitempty"`
+	// HintLevel is the highest hint level shown before the review, 0 without a hint
+	HintLevel int `gorm:"default:0" json:"hint_level,omitempty"`
 }
 
 // CardStats represents the state of a card within a session
@@ -40,28 +118,102 @@ type CardStats struct {
 	Failed  bool   `json:"failed"`
 	Passed  bool   `json:"passed"`
 	Stars   int    `json:"stars"`
+
+	Learning  bool `json:"learning"`   // Failed and being re-shown by the learning steps
+	Step      int  `json:"step"`       // Current learning step
+	Streak    int  `json:"streak"`     // Consecutive passes in this session
+	Graduated bool `json:"graduated"`  // Passed enough times in a row after failing
+	HintLevel int  `json:"hint_level"` // Highest hint level shown since the card was served
+
+	ServedAt         *time.Time `json:"served_at,omitempty"` // When the card was last served and not yet answered
+	ResponseMs       int64      `json:"response_ms"`         // Answer time in this session
+	MedianResponseMs int64      `json:"median_response_ms"`  // Median answer time across reviews
 }
 
 // Session represents a review session for a specific deck
 type Session struct {
-	DeckID    string        `json:"deckId"`
-	UserID    string        `json:"userId"`
-	SessionID string        `json:"sessionId"`
-	CardStats []CardStats   `json:"cardStats"`
-	Method    SessionMethod `json:"method"`
-	Index     int           `json:"index"`
-	mu        sync.Mutex    `json:"-"` // To handle concurrent access, not exported to JSON
+	DeckID     string        `json:"deckId"`
+	UserID     string        `json:"userId"`
+	SessionID  string        `json:"sessionId"`
+	CardStats  []CardStats   `json:"cardStats"`
+	Method     SessionMethod `json:"method"`
+	Mode       SessionMode   `json:"mode"`
+	Strictness Strictness    `json:"strictness"`
+	Index      int           `json:"index"`
+	// RoundPolicy decides how the next round starts; Round counts from 1
+	RoundPolicy RoundPolicy `json:"roundPolicy"`
+	Round       int         `json:"round"`
+	// RoundLength limits the round to the first cards of CardStats, 0 means all of them
+	RoundLength int        `json:"roundLength"`
+	mu          sync.Mutex `json:"-"` // To handle concurrent access, not exported to JSON
 
 	Stats SessionStats `json:"stats"`
+
+	// LearningSteps and GraduateAfter configure learning, see SessionOptions.
+	// Relearn holds the failed cards waiting to be re-shown; Served counts every
+	// card served so far and is what their due positions refer to.
+	LearningSteps []int         `json:"learningSteps"`
+	GraduateAfter int           `json:"graduateAfter"`
+	Relearn       []RelearnCard `json:"relearn"`
+	Served        int           `json:"served"`
+
+	// StartedAt, TimeLimit and GoalCorrect bound the session, see SessionOptions.
+	// Correct counts the passes towards the goal.
+	StartedAt      time.Time      `json:"startedAt"`
+	TimeLimit      time.Duration  `json:"timeLimit"`
+	GoalCorrect    int            `json:"goalCorrect"`
+	Correct        int            `json:"correct"`
+	Completed      bool           `json:"completed"`
+	CompleteReason CompleteReason `json:"completeReason,omitempty"`
+	CompleteLogID  string         `json:"-"` // The "complete" session log row, so undo can mark it undone
+
+	// Serving is the card last handed out and not answered yet. SelfGradeCardID is a
+	// card of a typed session the grader could not grade, left to the user to grade.
+	Serving         string `json:"serving,omitempty"`
+	SelfGradeCardID string `json:"-"`
+
+	// History holds the most recent reviews, newest last, so they can be undone
+	History []ReviewSnapshot `json:"-"`
+}
+
+// RelearnCard is a failed card waiting to be shown again
+type RelearnCard struct {
+	CardID string `json:"cardId"`
+	DueAt  int    `json:"dueAt"` // Shown once Session.Served reaches this
+}
+
+// ReviewSnapshot records the state from before a review so it can be undone
+type ReviewSnapshot struct {
+	Card      Card          // The card before the review
+	CardStats CardStats     // The card's session state before the review
+	Position  int           // Where the card sits in the session, so undo serves it again
+	LogID     string        // The session log row written for the review
+	Relearn   []RelearnCard // The learning queue before the review
+	// The session counters before the review. Served is from before the card was
+	// served, since undo serves it again.
+	Correct        int
+	Served         int
+	Completed      bool
+	CompleteReason CompleteReason
+	CompleteLogID  string
 }
 
 // SessionStats holds statistics for a session
 type SessionStats struct {
+	SessionID    string      `json:"sessionId"`
+	Round        int         `json:"round"`
 	TotalCards   int         `json:"totalCards"`
 	ViewedCount  int         `json:"viewedCount"`
 	Remaining    int         `json:"remaining"`
 	CurrentIndex int         `json:"currentIndex"`
 	CardStats    []CardStats `json:"cardStats"`
+
+	Correct        int            `json:"correct"`
+	Goal           int            `json:"goal,omitempty"`
+	EndsAt         *time.Time     `json:"endsAt,omitempty"` // When the time-box runs out
+	Completed      bool           `json:"completed"`
+	CompleteReason CompleteReason `json:"completeReason,omitempty"`
+	GoalMet        bool           `json:"goalMet"`
 }
 
 // SessionOverview represents a summary of a session
@@ -73,30 +225,291 @@ type SessionOverview struct {
 	Cards           int       `json:"cards"`
 	CardsAfter      int       `json:"cards_after"`
 	Timestamp       time.Time `json:"timestamp"`
+
+	Completed bool           `json:"completed"`
+	Outcome   CompleteReason `json:"outcome,omitempty"`
+	GoalMet   bool           `json:"goal_met"`
 }
 
 // GetNextCard returns the ID of the next card in the session
 func (s *Session) GetNextCard() string {
+	cardID, _ := s.Advance()
+	return cardID
+}
+
+// Advance returns the ID of the next card in the session and whether a new
+// round was started to serve it. Once the round policy ends the session, the
+// card ID is empty.
+func (s *Session) Advance() (string, bool) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if len(s.CardStats) == 0 {
-		return ""
+	if len(s.CardStats) == 0 || s.Completed {
+		return "", false
 	}
-	if s.Index >= len(s.CardStats) {
-		s.Index = 0 // Restart the session
-		resortCards(s)
+
+	// Failed cards that are due come back before the queue continues
+	if cardID, ok := s.nextRelearnCard(s.Index >= s.roundLength()); ok {
+		return cardID, false
+	}
+
+	newRound := false
+	if s.Index >= s.roundLength() {
+		if !s.startNextRound() {
+			s.complete(RoundsFinishedReason)
+			return "", false
+		}
+		newRound = true
 	}
 
 	cardID := s.CardStats[s.Index].CardID
+	servedAt := time.Now()
+	s.CardStats[s.Index].ServedAt = &servedAt
+	s.Serving = cardID
 	s.Index++
+	s.Served++
+	// Serving it in the queue counts as the re-show
+	s.removeRelearn(cardID)
 
 	// Update session stats
 	s.Stats.ViewedCount++
 	s.Stats.Remaining = len(s.CardStats) - s.Stats.ViewedCount
 	s.Stats.CurrentIndex = s.Index
 
-	return cardID
+	return cardID, newRound
+}
+
+// roundLength returns how many cards the current round serves
+func (s *Session) roundLength() int {
+	if s.RoundLength > 0 && s.RoundLength < len(s.CardStats) {
+		return s.RoundLength
+	}
+	return len(s.CardStats)
+}
+
+// startNextRound reorders the cards according to the round policy.
+// It returns false when the policy ends the session instead.
+func (s *Session) startNextRound() bool {
+	switch s.RoundPolicy {
+	case StopPolicy:
+		return false
+	case OnlyMissedPolicy:
+		resortCards(s)
+		missed := 0
+		for _, cs := range s.CardStats {
+			if !cs.Passed {
+				missed++
+			}
+		}
+		if missed == 0 {
+			return false
+		}
+		// Passed cards sort last, so the round is the leading missed cards
+		sortPassedLast(s)
+		s.RoundLength = missed
+	case ShufflePolicy:
+		rand.Shuffle(len(s.CardStats), func(i, j int) { s.CardStats[i], s.CardStats[j] = s.CardStats[j], s.CardStats[i] })
+		s.RoundLength = 0
+	default:
+		resortCards(s)
+		s.RoundLength = 0
+	}
+
+	s.Index = 0
+	if s.Round == 0 {
+		s.Round = 1
+	}
+	s.Round++
+	return true
+}
+
+// nextRelearnCard serves the earliest due learning card. At the end of a round
+// the earliest one is served even if not due yet, so learning finishes first.
+func (s *Session) nextRelearnCard(roundEnded bool) (string, bool) {
+	if len(s.Relearn) == 0 {
+		return "", false
+	}
+
+	earliest := 0
+	for i, r := range s.Relearn {
+		if r.DueAt < s.Relearn[earliest].DueAt {
+			earliest = i
+		}
+	}
+	if s.Relearn[earliest].DueAt > s.Served && !roundEnded {
+		return "", false
+	}
+
+	cardID := s.Relearn[earliest].CardID
+	s.Relearn = append(s.Relearn[:earliest], s.Relearn[earliest+1:]...)
+	s.Served++
+
+	servedAt := time.Now()
+	for i := range s.CardStats {
+		if s.CardStats[i].CardID == cardID {
+			s.CardStats[i].ServedAt = &servedAt
+			break
+		}
+	}
+	s.Serving = cardID
+	return cardID, true
+}
+
+// removeRelearn drops the card from the learning queue
+func (s *Session) removeRelearn(cardID string) {
+	for i, r := range s.Relearn {
+		if r.CardID == cardID {
+			s.Relearn = append(s.Relearn[:i], s.Relearn[i+1:]...)
+			return
+		}
+	}
+}
+
+// RecordResult updates the learning state of a card after it was answered.
+// A miss puts the card back to the first learning step; a pass while learning
+// moves it to the next step, until enough consecutive passes graduate it.
+func (s *Session) RecordResult(cardID string, passed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var cs *CardStats
+	for i := range s.CardStats {
+		if s.CardStats[i].CardID == cardID {
+			cs = &s.CardStats[i]
+			break
+		}
+	}
+	if cs == nil {
+		return
+	}
+
+	if passed {
+		cs.Streak++
+		s.Correct++
+	} else {
+		cs.Streak = 0
+	}
+
+	if len(s.LearningSteps) == 0 {
+		return
+	}
+
+	s.removeRelearn(cardID)
+
+	switch {
+	case !passed:
+		cs.Learning = true
+		cs.Graduated = false
+		cs.Step = 0
+	case !cs.Learning:
+		return
+	case cs.Streak >= s.GraduateAfter:
+		cs.Learning = false
+		cs.Graduated = true
+		return
+	case cs.Step < len(s.LearningSteps)-1:
+		cs.Step++
+	}
+
+	s.Relearn = append(s.Relearn, RelearnCard{CardID: cardID, DueAt: s.Served + s.LearningSteps[cs.Step]})
+}
+
+// CheckComplete marks the session complete once its goal or time-box is reached
+// and returns whether it is complete. It must be called before Advance.
+func (s *Session) CheckComplete(now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.Completed {
+		return true
+	}
+	switch {
+	case s.GoalCorrect > 0 && s.Correct >= s.GoalCorrect:
+		s.complete(GoalReachedReason)
+	case s.TimeLimit > 0 && !now.Before(s.StartedAt.Add(s.TimeLimit)):
+		s.complete(TimeUpReason)
+	}
+	return s.Completed
+}
+
+// complete marks the session complete for the given reason
+func (s *Session) complete(reason CompleteReason) {
+	s.Completed = true
+	s.CompleteReason = reason
+}
+
+// GoalMet reports whether the session reached what it was started for: the
+// number of correct answers if there is a goal, otherwise the full time-box
+func (s *Session) GoalMet() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.goalMet()
+}
+
+func (s *Session) goalMet() bool {
+	if s.GoalCorrect > 0 {
+		return s.Correct >= s.GoalCorrect
+	}
+	return s.TimeLimit > 0 && s.CompleteReason == TimeUpReason
+}
+
+// TypedAnswers reports whether answers are graded by the domain or the LLM rather than the user
+func (s *Session) TypedAnswers() bool {
+	return s.Mode == TypedMode || s.Mode == LLMGradedMode
+}
+
+// HasCard reports whether the card is part of the session
+func (s *Session) HasCard(cardID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, cs := range s.CardStats {
+		if cs.CardID == cardID {
+			return true
+		}
+	}
+	return false
+}
+
+// CardServedAt returns when the card was served, if it has not been answered since
+func (s *Session) CardServedAt(cardID string) (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, cs := range s.CardStats {
+		if cs.CardID == cardID && cs.ServedAt != nil {
+			return *cs.ServedAt, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// RecordHint notes that a hint of the level was shown for the card. It returns false
+// when the card is not in the session.
+func (s *Session) RecordHint(cardID string, level int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.CardStats {
+		if s.CardStats[i].CardID == cardID {
+			s.CardStats[i].HintLevel = max(s.CardStats[i].HintLevel, level)
+			return true
+		}
+	}
+	return false
+}
+
+// CardHintLevel returns the highest hint level shown for the card since it was served
+func (s *Session) CardHintLevel(cardID string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, cs := range s.CardStats {
+		if cs.CardID == cardID {
+			return cs.HintLevel
+		}
+	}
+	return 0
 }
 
 func (s *Session) GetSessionStats() SessionStats {
@@ -111,11 +524,23 @@ func (s *Session) GetSessionStats() SessionStats {
 	}
 
 	stats := SessionStats{
+		SessionID:    s.SessionID,
+		Round:        s.Round,
 		TotalCards:   len(s.CardStats),
 		ViewedCount:  viewedCount,
 		Remaining:    len(s.CardStats) - viewedCount,
 		CurrentIndex: s.Index,
 		CardStats:    s.CardStats,
+
+		Correct:        s.Correct,
+		Goal:           s.GoalCorrect,
+		Completed:      s.Completed,
+		CompleteReason: s.CompleteReason,
+		GoalMet:        s.goalMet(),
+	}
+	if s.TimeLimit > 0 {
+		endsAt := s.StartedAt.Add(s.TimeLimit)
+		stats.EndsAt = &endsAt
 	}
 
 	return stats
@@ -141,3 +566,10 @@ func resortCards(s *Session) {
 	s.CardStats = append(skippedCards, append(failedCards, remainingCards...)...)
 
 }
+
+// sortPassedLast moves passed cards behind the others, keeping the order otherwise
+func sortPassedLast(s *Session) {
+	sort.SliceStable(s.CardStats, func(i, j int) bool {
+		return !s.CardStats[i].Passed && s.CardStats[j].Passed
+	})
+}
@@ -3,6 +3,7 @@ package types // Adjust the package name as needed
 import (
 	"sync"
 	"testing"
+	"time"
 )
 
 // Helper function to create a sample session
@@ -70,6 +71,23 @@ func TestGetNextCard(t *testing.T) {
 		}
 	})
 
+	t.Run("Records Served Time", func(t *testing.T) {
+		session := createSampleSession()
+
+		if _, ok := session.CardServedAt("card1"); ok {
+			t.Errorf("Expected card1 to be unserved before GetNextCard")
+		}
+
+		session.GetNextCard()
+
+		if _, ok := session.CardServedAt("card1"); !ok {
+			t.Errorf("Expected card1 to have a served time after GetNextCard")
+		}
+		if _, ok := session.CardServedAt("card2"); ok {
+			t.Errorf("Expected card2 to be unserved")
+		}
+	})
+
 	t.Run("Empty CardStats", func(t *testing.T) {
 		session := &Session{
 			CardStats: []CardStats{},
@@ -223,3 +241,188 @@ func TestGetSessionStats(t *testing.T) {
 		// If this test completes without deadlock or race conditions, it passes
 	})
 }
+
+func TestAdvance_RoundPolicies(t *testing.T) {
+	// finishRound serves the whole first round, passing card2 and failing the rest
+	finishRound := func(policy RoundPolicy) *Session {
+		session := createSampleSession()
+		session.RoundPolicy = policy
+		session.Round = 1
+		for i := 0; i < 3; i++ {
+			session.GetNextCard()
+		}
+		session.CardStats[0].Failed = true
+		session.CardStats[1].Passed = true
+		session.CardStats[2].Skipped = true
+		return &session
+	}
+
+	t.Run("Resort", func(t *testing.T) {
+		session := finishRound(ResortPolicy)
+		cardID, newRound := session.Advance()
+		if cardID != "card3" || !newRound {
+			t.Errorf("Expected skipped card3 to open a new round, got %s (new round %v)", cardID, newRound)
+		}
+		if session.Round != 2 {
+			t.Errorf("Expected round 2, got %d", session.Round)
+		}
+	})
+
+	t.Run("Only Missed", func(t *testing.T) {
+		session := finishRound(OnlyMissedPolicy)
+		served := []string{}
+		for i := 0; i < 3; i++ {
+			cardID, _ := session.Advance()
+			served = append(served, cardID)
+		}
+		// card2 was passed, so the round only has the other two before the next one starts
+		expected := []string{"card3", "card1", "card3"}
+		for i := range expected {
+			if served[i] != expected[i] {
+				t.Errorf("Expected %v, got %v", expected, served)
+				break
+			}
+		}
+		if session.Round != 3 {
+			t.Errorf("Expected round 3, got %d", session.Round)
+		}
+	})
+
+	t.Run("Only Missed Ends When All Passed", func(t *testing.T) {
+		session := finishRound(OnlyMissedPolicy)
+		for i := range session.CardStats {
+			session.CardStats[i] = CardStats{CardID: session.CardStats[i].CardID, Passed: true}
+		}
+		if cardID, newRound := session.Advance(); cardID != "" || newRound {
+			t.Errorf("Expected the session to end, got %s (new round %v)", cardID, newRound)
+		}
+	})
+
+	t.Run("Stop", func(t *testing.T) {
+		session := finishRound(StopPolicy)
+		if cardID, newRound := session.Advance(); cardID != "" || newRound {
+			t.Errorf("Expected the session to end, got %s (new round %v)", cardID, newRound)
+		}
+		if session.Round != 1 {
+			t.Errorf("Expected round to stay 1, got %d", session.Round)
+		}
+	})
+
+	t.Run("Shuffle", func(t *testing.T) {
+		session := finishRound(ShufflePolicy)
+		seen := map[string]bool{}
+		for i := 0; i < 3; i++ {
+			cardID, _ := session.Advance()
+			seen[cardID] = true
+		}
+		if len(seen) != 3 {
+			t.Errorf("Expected every card in the shuffled round, got %v", seen)
+		}
+	})
+}
+
+func TestAdvance_LearningSteps(t *testing.T) {
+	session := &Session{
+		CardStats: []CardStats{
+			{CardID: "card1"}, {CardID: "card2"}, {CardID: "card3"}, {CardID: "card4"}, {CardID: "card5"},
+		},
+		LearningSteps: []int{1, 2},
+		GraduateAfter: 2,
+	}
+
+	next := func() string {
+		cardID, _ := session.Advance()
+		return cardID
+	}
+
+	if got := next(); got != "card1" {
+		t.Fatalf("Expected card1, got %s", got)
+	}
+	session.RecordResult("card1", false)
+
+	// The failed card comes back after one other card
+	if got := next(); got != "card2" {
+		t.Fatalf("Expected card2, got %s", got)
+	}
+	if got := next(); got != "card1" {
+		t.Fatalf("Expected failed card1 to be re-shown, got %s", got)
+	}
+	session.RecordResult("card1", true)
+
+	// One pass moves it to the second step, two other cards later
+	for _, want := range []string{"card3", "card4", "card1"} {
+		if got := next(); got != want {
+			t.Fatalf("Expected %s, got %s", want, got)
+		}
+	}
+	session.RecordResult("card1", true)
+
+	if !session.CardStats[0].Graduated || session.CardStats[0].Learning {
+		t.Errorf("Expected card1 to graduate after two consecutive passes, got %+v", session.CardStats[0])
+	}
+	if got := next(); got != "card5" {
+		t.Errorf("Expected card5, got %s", got)
+	}
+	if len(session.Relearn) != 0 {
+		t.Errorf("Expected an empty learning queue, got %v", session.Relearn)
+	}
+}
+
+func TestAdvance_LearningFinishesBeforeStop(t *testing.T) {
+	session := &Session{
+		CardStats:     []CardStats{{CardID: "card1"}, {CardID: "card2"}},
+		RoundPolicy:   StopPolicy,
+		LearningSteps: []int{10},
+		GraduateAfter: 1,
+	}
+
+	session.GetNextCard()
+	session.RecordResult("card1", false)
+	session.GetNextCard()
+
+	// The round is over, so the learning card is brought forward before the session stops
+	if got := session.GetNextCard(); got != "card1" {
+		t.Fatalf("Expected card1 before the session stops, got %s", got)
+	}
+	session.RecordResult("card1", true)
+
+	if got := session.GetNextCard(); got != "" {
+		t.Errorf("Expected the session to stop, got %s", got)
+	}
+}
+
+func TestCheckComplete(t *testing.T) {
+	now := time.Now()
+
+	t.Run("Goal", func(t *testing.T) {
+		session := &Session{CardStats: []CardStats{{CardID: "card1"}}, GoalCorrect: 2, StartedAt: now}
+		session.RecordResult("card1", true)
+		if session.CheckComplete(now) {
+			t.Fatalf("Expected the session to continue after one correct answer")
+		}
+		session.RecordResult("card1", true)
+		if !session.CheckComplete(now) || session.CompleteReason != GoalReachedReason || !session.GoalMet() {
+			t.Errorf("Expected the goal to be reached, got %s", session.CompleteReason)
+		}
+		if cardID, _ := session.Advance(); cardID != "" {
+			t.Errorf("Expected no more cards, got %s", cardID)
+		}
+	})
+
+	t.Run("Time Up", func(t *testing.T) {
+		session := &Session{CardStats: []CardStats{{CardID: "card1"}}, TimeLimit: 10 * time.Minute, StartedAt: now}
+		if session.CheckComplete(now.Add(9 * time.Minute)) {
+			t.Fatalf("Expected the session to continue within the time-box")
+		}
+		if !session.CheckComplete(now.Add(10*time.Minute)) || session.CompleteReason != TimeUpReason || !session.GoalMet() {
+			t.Errorf("Expected the time-box to run out, got %s", session.CompleteReason)
+		}
+	})
+
+	t.Run("Time Up Before Goal", func(t *testing.T) {
+		session := &Session{CardStats: []CardStats{{CardID: "card1"}}, TimeLimit: time.Minute, GoalCorrect: 30, StartedAt: now}
+		if !session.CheckComplete(now.Add(time.Hour)) || session.GoalMet() {
+			t.Errorf("Expected the session to complete without meeting the goal")
+		}
+	})
+}
@@ -0,0 +1,6 @@
+package types
+
+import "errors"
+
+// ErrTranslationFailed is returned when the LLM's translation is empty or dropped code
+var ErrTranslationFailed = errors.New("the LLM returned an unusable translation")
@@ -12,4 +12,25 @@ type User struct {
 	Role      string    `gorm:"size:50;default:user" json:"role"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+
+	// Timezone is the IANA zone used to split activity into days, e.g. "Europe/Oslo"
+	Timezone string `gorm:"size:64;default:UTC" json:"timezone"`
+	// StreakFreezes is how many missed days per calendar month keep a streak alive
+	StreakFreezes int `gorm:"default:2" json:"streak_freezes"`
+	// LogRetentionDays is how long raw session logs are kept; daily rollups are kept forever.
+	// Zero, the default, keeps the logs forever too. Pruning is opt-in because leech lapses,
+	// the analytics trend, median response times and session reports read the raw logs.
+	LogRetentionDays int `gorm:"default:0" json:"log_retention_days"`
+	// LeechThreshold is how many lapses make a card a leech
+	LeechThreshold int `gorm:"default:8" json:"leech_threshold"`
+	// LeechAction is what happens to a new leech: LeechFlag or LeechRetire
+	LeechAction string `gorm:"size:20;default:flag" json:"leech_action"`
+	// LLMDailyQuota is how many LLM requests the user may make per local day, 0 is unlimited
+	LLMDailyQuota int `gorm:"default:100" json:"llm_daily_quota"`
 }
+
+// Leech actions
+const (
+	LeechFlag   = "flag"   // Only mark the card as a leech
+	LeechRetire = "retire" // Mark the card and retire it
+)
@@ -0,0 +1,130 @@
+// internal/domain/undo.go
+package domain
+
+import (
+	"errors"
+
+	"github.com/robstave/meowmorize/internal/domain/types"
+)
+
+// maxUndoHistory caps how many reviews a session remembers for undo
+const maxUndoHistory = 50
+
+// pushReviewSnapshot adds a review to the session history, dropping the oldest when full
+func pushReviewSnapshot(session *types.Session, snapshot types.ReviewSnapshot) {
+	session.History = append(session.History, snapshot)
+	if len(session.History) > maxUndoHistory {
+		session.History = session.History[len(session.History)-maxUndoHistory:]
+	}
+}
+
+// UndoReviews reverts the last count reviews of the deck's session, newest first.
+// The cards' counters, the session state and position are restored, and the
+// session log rows are marked as undone. It returns the number of reviews undone.
+func (s *Service) UndoReviews(deckID string, count int) (int, error) {
+	s.sessionsMu.Lock()
+	defer s.sessionsMu.Unlock()
+
+	session, exists := s.sessions[deckID]
+	if !exists {
+		return 0, errors.New("session does not exist for the given deck")
+	}
+
+	if count <= 0 {
+		count = 1
+	}
+
+	undone := 0
+	for undone < count && len(session.History) > 0 {
+		snapshot := session.History[len(session.History)-1]
+
+		if err := s.restoreReviewedCard(snapshot.Card); err != nil {
+			return undone, err
+		}
+
+		if err := s.sessionLogRepo.MarkUndone(snapshot.LogID); err != nil {
+			s.logger.Error("Failed to mark session log as undone", "log_id", snapshot.LogID, "error", err)
+			return undone, err
+		}
+
+		// Undoing past the end reopens the session, so its completion no longer counts
+		if session.CompleteLogID != "" && session.CompleteLogID != snapshot.CompleteLogID {
+			if err := s.sessionLogRepo.MarkUndone(session.CompleteLogID); err != nil {
+				s.logger.Error("Failed to mark session completion as undone", "log_id", session.CompleteLogID, "error", err)
+				return undone, err
+			}
+		}
+
+		restoreSessionCard(session, snapshot)
+		session.History = session.History[:len(session.History)-1]
+		undone++
+	}
+
+	recalculateSessionStats(session)
+	if undone > 0 {
+		s.publishSessionEvent(session, types.ReviewsUndoneEvent, "", "")
+	}
+	s.logger.Info("Reviews undone", "deck_id", deckID, "session_id", session.SessionID, "requested", count, "undone", undone)
+	return undone, nil
+}
+
+// restoreReviewedCard puts back the review fields of a card, leaving any edits
+// to its content made since the review in place
+func (s *Service) restoreReviewedCard(previous types.Card) error {
+	card, err := s.cardRepo.GetCardByID(previous.ID)
+	if err != nil {
+		s.logger.Error("Failed to retrieve card for undo", "card_id", previous.ID, "error", err)
+		return err
+	}
+	if card == nil {
+		return errors.New("card not found")
+	}
+
+	card.PassCount = previous.PassCount
+	card.FailCount = previous.FailCount
+	card.SkipCount = previous.SkipCount
+	card.SlowPassCount = previous.SlowPassCount
+	card.HintedPassCount = previous.HintedPassCount
+	card.MedianResponseMs = previous.MedianResponseMs
+	card.ReviewedAt = previous.ReviewedAt
+	card.IntervalDays = previous.IntervalDays
+	card.DueAt = previous.DueAt
+	card.Leech = previous.Leech
+	card.Retired = previous.Retired
+
+	if err := s.cardRepo.UpdateCard(*card); err != nil {
+		s.logger.Error("Failed to restore card for undo", "card_id", previous.ID, "error", err)
+		return err
+	}
+	return nil
+}
+
+// restoreSessionCard puts back the card's session state and the session counters,
+// and moves the session back so the card is served again next
+func restoreSessionCard(session *types.Session, snapshot types.ReviewSnapshot) {
+	// An undone pass no longer counts towards the goal
+	session.Correct = snapshot.Correct
+	session.Served = snapshot.Served
+	session.Completed = snapshot.Completed
+	session.CompleteReason = snapshot.CompleteReason
+	session.CompleteLogID = snapshot.CompleteLogID
+
+	position := snapshot.Position
+	if position >= len(session.CardStats) || session.CardStats[position].CardID != snapshot.CardStats.CardID {
+		// The session was reordered since the review, so find where the card went
+		position = -1
+		for i := range session.CardStats {
+			if session.CardStats[i].CardID == snapshot.CardStats.CardID {
+				position = i
+				break
+			}
+		}
+		if position == -1 {
+			return
+		}
+	}
+
+	session.CardStats[position] = snapshot.CardStats
+	session.Relearn = snapshot.Relearn
+	session.Index = position
+}
@@ -0,0 +1,183 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/robstave/meowmorize/internal/domain/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUndoReviews_RestoresCardAndSession(t *testing.T) {
+	deckID := uuid.New().String()
+	card := types.Card{ID: "card1", UserID: "meow", PassCount: 3, FailCount: 1}
+	other := types.Card{ID: "card2", UserID: "meow", PassCount: 10}
+	deck := types.Deck{ID: deckID, Name: "Capitals", Cards: []types.Card{card, other}}
+
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	deckRepo.On("GetDeckByID", deckID).Return(deck, nil)
+	deckRepo.On("UpdateDeck", mock.AnythingOfType("types.Deck")).Return(nil)
+
+	// The repository hands out a fresh copy each time, like the database would
+	cardRepo.On("GetCardByID", "card1").Return(func(string) *types.Card {
+		c := card
+		return &c
+	}, nil)
+	cardRepo.On("UpdateCard", mock.MatchedBy(func(c types.Card) bool {
+		return c.ID == "card1" && c.FailCount == 2
+	})).Return(nil).Once()
+	cardRepo.On("UpdateCard", mock.MatchedBy(func(c types.Card) bool {
+		return c.ID == "card1" && c.FailCount == 1 && c.PassCount == 3
+	})).Return(nil).Once()
+
+	var logID string
+	sessionRepo.On("CountLapses", "card1", mock.Anything).Return(int64(0), nil)
+	sessionRepo.On("CreateLog", mock.MatchedBy(func(log types.SessionLog) bool {
+		logID = log.ID
+		return log.Action == string(types.IncrementFail)
+	})).Return(nil)
+	sessionRepo.On("MarkUndone", mock.MatchedBy(func(id string) bool {
+		return id == logID
+	})).Return(nil)
+
+	dm := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	assert.NoError(t, dm.StartSession(deckID, -1, types.WorstMethod, "meow", types.SessionOptions{}))
+
+	first, err := dm.GetNextCard(deckID)
+	assert.NoError(t, err)
+	assert.Equal(t, "card1", first)
+	assert.NoError(t, dm.UpdateCardStats("card1", types.IncrementFail, nil, deckID, "meow"))
+
+	undone, err := dm.UndoReviews(deckID, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, undone)
+
+	stats, err := dm.GetSessionStats(deckID)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, stats.ViewedCount)
+	assert.False(t, stats.CardStats[0].Failed)
+
+	// The undone card is served again
+	next, err := dm.GetNextCard(deckID)
+	assert.NoError(t, err)
+	assert.Equal(t, "card1", next)
+
+	// Nothing left to undo
+	undone, err = dm.UndoReviews(deckID, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, undone)
+
+	cardRepo.AssertExpectations(t)
+	sessionRepo.AssertExpectations(t)
+}
+
+func TestUndoReviews_NoSession(t *testing.T) {
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+
+	dm := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	_, err := dm.UndoReviews("missing", 1)
+	assert.EqualError(t, err, "session does not exist for the given deck")
+}
+
+func TestUndoReviews_GoalSessionNotComplete(t *testing.T) {
+	deckID := uuid.New().String()
+	card := types.Card{ID: "card1", UserID: "meow"}
+	other := types.Card{ID: "card2", UserID: "meow"}
+	deck := types.Deck{ID: deckID, Name: "Capitals", Cards: []types.Card{card, other}}
+
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	deckRepo.On("GetDeckByID", deckID).Return(deck, nil)
+	deckRepo.On("UpdateDeck", mock.AnythingOfType("types.Deck")).Return(nil)
+	cardRepo.On("GetCardByID", mock.Anything).Return(func(id string) *types.Card {
+		return &types.Card{ID: id, UserID: "meow"}
+	}, nil)
+	cardRepo.On("UpdateCard", mock.AnythingOfType("types.Card")).Return(nil)
+	sessionRepo.On("GetRecentResponseTimes", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	sessionRepo.On("CreateLog", mock.AnythingOfType("types.SessionLog")).Return(nil)
+	sessionRepo.On("MarkUndone", mock.Anything).Return(nil)
+
+	dm := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	assert.NoError(t, dm.StartSession(deckID, -1, types.RandomMethod, "meow", types.SessionOptions{GoalCorrect: 1}))
+
+	first, err := dm.GetNextCard(deckID)
+	assert.NoError(t, err)
+	assert.NoError(t, dm.UpdateCardStats(first, types.IncrementPass, nil, deckID, "meow"))
+
+	// The pass reached the goal
+	_, err = dm.GetNextCard(deckID)
+	assert.ErrorIs(t, err, types.ErrSessionComplete)
+
+	undone, err := dm.UndoReviews(deckID, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, undone)
+
+	stats, err := dm.GetSessionStats(deckID)
+	assert.NoError(t, err)
+	assert.False(t, stats.Completed)
+	assert.Equal(t, 0, stats.Correct)
+	assert.Equal(t, 0, dm.(*Service).sessions[deckID].Served)
+
+	// The session goes on with the undone card
+	next, err := dm.GetNextCard(deckID)
+	assert.NoError(t, err)
+	assert.Equal(t, first, next)
+}
+
+func TestUndoReviews_FinalReviewUndoesCompletion(t *testing.T) {
+	deckID := uuid.New().String()
+	deck := types.Deck{ID: deckID, Cards: []types.Card{{ID: "card1", UserID: "meow"}, {ID: "card2", UserID: "meow"}}}
+
+	cardRepo, userRepo, deckRepo, sessionRepo := setupRepositories()
+	llmRepo := setupLLMRepository()
+
+	userRepo.On("GetUserByUsername", "meow").Return(&types.User{ID: "dummy", Username: "meow"}, nil)
+	deckRepo.On("GetDeckByID", deckID).Return(deck, nil)
+	deckRepo.On("UpdateDeck", mock.AnythingOfType("types.Deck")).Return(nil)
+	cardRepo.On("GetCardByID", mock.Anything).Return(func(id string) *types.Card {
+		return &types.Card{ID: id, UserID: "meow"}
+	}, nil)
+	cardRepo.On("UpdateCard", mock.AnythingOfType("types.Card")).Return(nil)
+	sessionRepo.On("GetRecentResponseTimes", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+
+	logs := map[string]string{}
+	sessionRepo.On("CreateLog", mock.AnythingOfType("types.SessionLog")).Run(func(args mock.Arguments) {
+		log := args.Get(0).(types.SessionLog)
+		logs[log.Action] = log.ID
+	}).Return(nil)
+	sessionRepo.On("MarkUndone", mock.Anything).Return(nil)
+
+	dm := newTestService(deckRepo, cardRepo, userRepo, sessionRepo, llmRepo)
+	assert.NoError(t, dm.StartSession(deckID, -1, types.RandomMethod, "meow", types.SessionOptions{GoalCorrect: 1}))
+
+	first, err := dm.GetNextCard(deckID)
+	assert.NoError(t, err)
+	assert.NoError(t, dm.UpdateCardStats(first, types.IncrementPass, nil, deckID, "meow"))
+	_, err = dm.GetNextCard(deckID)
+	assert.ErrorIs(t, err, types.ErrSessionComplete)
+	assert.NotEmpty(t, logs["complete"])
+
+	_, err = dm.UndoReviews(deckID, 1)
+	assert.NoError(t, err)
+
+	// Both the review and the completion it led to are undone
+	sessionRepo.AssertCalled(t, "MarkUndone", logs[string(types.IncrementPass)])
+	sessionRepo.AssertCalled(t, "MarkUndone", logs["complete"])
+	sessionRepo.AssertNumberOfCalls(t, "MarkUndone", 2)
+
+	// An undone completion no longer shows in the overview
+	overview := calculateSessionOverview([]types.SessionLog{
+		{ID: logs[string(types.IncrementPass)], CardID: first, Action: string(types.IncrementPass), Undone: true},
+		{ID: logs["complete"], Action: "complete", Outcome: types.GoalReachedReason, GoalMet: true, Undone: true},
+	}, "session1", deckID)
+	assert.False(t, overview.Completed)
+	assert.False(t, overview.GoalMet)
+}
@@ -1,7 +1,9 @@
 package domain
 
 import (
+	"github.com/robstave/meowmorize/internal/adapters/repositories"
 	"github.com/robstave/meowmorize/internal/adapters/repositories/mocks"
+	"github.com/robstave/meowmorize/internal/logger"
 )
 
 func setupRepositories() (*mocks.CardRepository, *mocks.UserRepository, *mocks.DeckRepository, *mocks.SessionLogRepository) {
@@ -16,3 +18,40 @@ func setupLLMRepository() *mocks.LLMRepository {
 	llmRepo := new(mocks.LLMRepository)
 	return llmRepo
 }
+
+func setupCardDraftRepository() *mocks.CardDraftRepository {
+	draftRepo := new(mocks.CardDraftRepository)
+	return draftRepo
+}
+
+func setupExplanationRepository() *mocks.ExplanationRepository {
+	threadRepo := new(mocks.ExplanationRepository)
+	return threadRepo
+}
+
+func setupLLMUsageRepository() *mocks.LLMUsageRepository {
+	usageRepo := new(mocks.LLMUsageRepository)
+	return usageRepo
+}
+
+func setupPromptTemplateRepository() *mocks.PromptTemplateRepository {
+	templateRepo := new(mocks.PromptTemplateRepository)
+	return templateRepo
+}
+
+func setupLintRepository() *mocks.LintRepository {
+	lintRepo := new(mocks.LintRepository)
+	return lintRepo
+}
+
+func setupAttachmentRepository() *mocks.AttachmentRepository {
+	attachmentRepo := new(mocks.AttachmentRepository)
+	return attachmentRepo
+}
+
+// newTestService builds a service from the core repository mocks.
+// Optional subsystems are left unset; tests that need them call NewService directly.
+func newTestService(deckRepo repositories.DeckRepository, cardRepo repositories.CardRepository, userRepo repositories.UserRepository,
+	sessionRepo repositories.SessionLogRepository, llmRepo repositories.LLMRepository) MeowDomain {
+	return NewService(logger.InitializeLogger(), deckRepo, cardRepo, userRepo, sessionRepo, llmRepo, nil, nil, nil, nil, nil, nil)
+}
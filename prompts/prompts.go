@@ -0,0 +1,28 @@
+// Package prompts embeds the prompt documents the backend sends to the LLM
+package prompts
+
+import _ "embed"
+
+// CardFormat explains the Card Start/Card End markdown format cards are imported from.
+// Card generation sends it as the card rules. instructions.md is not a card prompt: it
+// primes a coding assistant to take the source code in chunks.
+//
+//go:embed markdown.md
+var CardFormat string
+
+// Built-in prompt templates, used until an admin or user saves their own. They are Go
+// text/template documents executed with types.PromptData.
+var (
+	//go:embed explain.tmpl
+	ExplainTemplate string
+	//go:embed hint.tmpl
+	HintTemplate string
+	//go:embed generate.tmpl
+	GenerateTemplate string
+	//go:embed grade.tmpl
+	GradeTemplate string
+	//go:embed lint.tmpl
+	LintTemplate string
+	//go:embed translate.tmpl
+	TranslateTemplate string
+)